@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// baseline records files which were known to have invalid boilerplate when it was written, so
+// that they can be ignored until they're next changed
+type baseline struct {
+	// Files maps the slash-separated path of each file, relative to the directory containing the
+	// baseline, to the SHA-256 hash of its contents
+	Files map[string]string `json:"files"`
+
+	dir string
+}
+
+func newBaseline(path string) (*baseline, error) {
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	return &baseline{
+		Files: make(map[string]string),
+		dir:   dir,
+	}, nil
+}
+
+// loadBaseline reads a baseline previously written to path
+func loadBaseline(path string) (*baseline, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := newBaseline(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(contents, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// key returns the path used to identify the given file in the baseline, so that the baseline
+// doesn't depend on the directory boilersuite was run from
+func (b *baseline) key(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(b.dir, absPath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+func hashContents(contents []byte) string {
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])
+}
+
+// add records that the file at path, with the given contents, is grandfathered
+func (b *baseline) add(path string, contents []byte) error {
+	key, err := b.key(path)
+	if err != nil {
+		return err
+	}
+
+	b.Files[key] = hashContents(contents)
+
+	return nil
+}
+
+// has returns whether the file at path is in the baseline at all, and whether it's unchanged since
+// the baseline was written
+func (b *baseline) has(path string, contents []byte) (listed bool, unchanged bool) {
+	key, err := b.key(path)
+	if err != nil {
+		return false, false
+	}
+
+	hash, ok := b.Files[key]
+	if !ok {
+		return false, false
+	}
+
+	return true, hash == hashContents(contents)
+}
+
+// save writes the baseline to path
+func (b *baseline) save(path string) error {
+	contents, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(contents, '\n'), 0o644)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func Test_baseline(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "baseline.json")
+
+	written, err := newBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := written.add(filepath.Join(dir, "pkg", "legacy.go"), []byte("package pkg\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := written.save(path); err != nil {
+		t.Fatalf("failed to save baseline: %s", err)
+	}
+
+	loaded, err := loadBaseline(path)
+	if err != nil {
+		t.Fatalf("failed to load baseline: %s", err)
+	}
+
+	if _, ok := loaded.Files["pkg/legacy.go"]; !ok {
+		t.Fatalf("expected paths to be stored relative to the baseline, got %v", loaded.Files)
+	}
+
+	tests := map[string]struct {
+		path            string
+		contents        string
+		expectListed    bool
+		expectUnchanged bool
+	}{
+		"unchanged file": {
+			path:            filepath.Join(dir, "pkg", "legacy.go"),
+			contents:        "package pkg\n",
+			expectListed:    true,
+			expectUnchanged: true,
+		},
+		"changed file": {
+			path:            filepath.Join(dir, "pkg", "legacy.go"),
+			contents:        "package pkg\n\nfunc New() {}\n",
+			expectListed:    true,
+			expectUnchanged: false,
+		},
+		"file not in baseline": {
+			path:            filepath.Join(dir, "pkg", "new.go"),
+			contents:        "package pkg\n",
+			expectListed:    false,
+			expectUnchanged: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			listed, unchanged := loaded.has(test.path, []byte(test.contents))
+
+			if listed != test.expectListed || unchanged != test.expectUnchanged {
+				t.Errorf("listed=%v unchanged=%v, expected listed=%v unchanged=%v", listed, unchanged, test.expectListed, test.expectUnchanged)
+			}
+		})
+	}
+}
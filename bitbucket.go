@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// bitbucketAPI is the Bitbucket Cloud API as reached through the proxy in Bitbucket Pipelines,
+	// which handles authentication and so only accepts plain HTTP
+	bitbucketAPI = "http://api.bitbucket.org/2.0"
+
+	// bitbucketPipelinesProxy is the proxy through which Bitbucket Pipelines steps can call the
+	// API without credentials
+	bitbucketPipelinesProxy = "http://localhost:29418"
+
+	// bitbucketReportID identifies the Code Insights report, so that each run replaces the report
+	// from the previous run on the same commit
+	bitbucketReportID = "boilersuite"
+
+	// bitbucketAnnotationBatch and bitbucketMaxAnnotations are Bitbucket's limits on how many
+	// annotations can be sent in one request and attached to one report
+	bitbucketAnnotationBatch = 100
+	bitbucketMaxAnnotations  = 1000
+
+	// bitbucketMaxSummary is the longest annotation summary, in characters, which Bitbucket accepts
+	bitbucketMaxSummary = 450
+
+	// bitbucketTimeout bounds each request to the API, so that an unresponsive proxy can't hang
+	// the pipeline
+	bitbucketTimeout = 30 * time.Second
+)
+
+// bitbucketInsights publishes results to Bitbucket Code Insights for the commit being built
+type bitbucketInsights struct {
+	client  *http.Client
+	baseURL string
+
+	workspace string
+	repoSlug  string
+	commit    string
+
+	// cloneDir is the root of the repository, which annotation paths are relative to
+	cloneDir string
+}
+
+// bitbucketInsightsFromEnv returns a publisher for the commit being built in Bitbucket Pipelines,
+// or false if the variables Bitbucket Pipelines sets aren't present
+func bitbucketInsightsFromEnv(getenv func(string) string) (*bitbucketInsights, bool) {
+	insights := &bitbucketInsights{
+		baseURL:   bitbucketAPI,
+		workspace: getenv("BITBUCKET_WORKSPACE"),
+		repoSlug:  getenv("BITBUCKET_REPO_SLUG"),
+		commit:    getenv("BITBUCKET_COMMIT"),
+		cloneDir:  getenv("BITBUCKET_CLONE_DIR"),
+	}
+
+	if insights.workspace == "" || insights.repoSlug == "" || insights.commit == "" {
+		return nil, false
+	}
+
+	proxy, _ := url.Parse(bitbucketPipelinesProxy)
+
+	insights.client = &http.Client{
+		Transport: &http.Transport{Proxy: http.ProxyURL(proxy)},
+		Timeout:   bitbucketTimeout,
+	}
+
+	return insights, true
+}
+
+type bitbucketReport struct {
+	Title      string              `json:"title"`
+	Details    string              `json:"details"`
+	ReportType string              `json:"report_type"`
+	Reporter   string              `json:"reporter"`
+	Result     string              `json:"result"`
+	Data       []bitbucketDataItem `json:"data"`
+}
+
+type bitbucketDataItem struct {
+	Title string `json:"title"`
+	Type  string `json:"type"`
+	Value int    `json:"value"`
+}
+
+type bitbucketAnnotation struct {
+	ExternalID     string `json:"external_id"`
+	AnnotationType string `json:"annotation_type"`
+	Summary        string `json:"summary"`
+	Severity       string `json:"severity"`
+	Path           string `json:"path,omitempty"`
+	Line           int    `json:"line,omitempty"`
+}
+
+// publish replaces the Code Insights report for the commit with one describing r, along with an
+// annotation for each failure up to Bitbucket's limit
+func (b *bitbucketInsights) publish(ctx context.Context, r report) error {
+	result := "PASSED"
+	if r.Failed > 0 {
+		result = "FAILED"
+	}
+
+	reportURL := fmt.Sprintf("%s/repositories/%s/%s/commit/%s/reports/%s", b.baseURL, url.PathEscape(b.workspace), url.PathEscape(b.repoSlug), url.PathEscape(b.commit), bitbucketReportID)
+
+	err := b.send(ctx, http.MethodPut, reportURL, bitbucketReport{
+		Title:      "boilersuite",
+		Details:    "Checks that files have the expected license and copyright boilerplate; " + r.summary(),
+		ReportType: "TEST",
+		Reporter:   "boilersuite",
+		Result:     result,
+		Data: []bitbucketDataItem{
+			{Title: "Files checked", Type: "NUMBER", Value: r.Checked},
+			{Title: "Failures", Type: "NUMBER", Value: r.Failed},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+
+	annotations := b.annotations(r)
+
+	for start := 0; start < len(annotations); start += bitbucketAnnotationBatch {
+		end := min(start+bitbucketAnnotationBatch, len(annotations))
+
+		if err := b.send(ctx, http.MethodPost, reportURL+"/annotations", annotations[start:end]); err != nil {
+			return fmt.Errorf("failed to add annotations: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// annotations returns an annotation for each failure in r, with paths relative to the repository
+func (b *bitbucketInsights) annotations(r report) []bitbucketAnnotation {
+	failures := r.Failures
+	if len(failures) > bitbucketMaxAnnotations {
+		failures = failures[:bitbucketMaxAnnotations]
+	}
+
+	annotations := make([]bitbucketAnnotation, 0, len(failures))
+
+	for i, failure := range failures {
+		summary := failure.Message
+		if failure.Kind != "" {
+			summary += " [" + failure.Kind + "]"
+		}
+
+		// truncating by runes rather than bytes keeps multi-byte characters whole
+		if utf8.RuneCountInString(summary) > bitbucketMaxSummary {
+			summary = string([]rune(summary)[:bitbucketMaxSummary-3]) + "..."
+		}
+
+		annotations = append(annotations, bitbucketAnnotation{
+			ExternalID:     fmt.Sprintf("%s-%d", bitbucketReportID, i+1),
+			AnnotationType: "CODE_SMELL",
+			Summary:        summary,
+			Severity:       "MEDIUM",
+			Path:           b.relativePath(failure.Path),
+			Line:           failure.Line,
+		})
+	}
+
+	return annotations
+}
+
+// relativePath returns path relative to the root of the repository, as Bitbucket expects
+func (b *bitbucketInsights) relativePath(path string) string {
+	if path == "" || b.cloneDir == "" {
+		return filepath.ToSlash(path)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+
+	rel, err := filepath.Rel(b.cloneDir, abs)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// send makes a request with body encoded as JSON, returning an error if it doesn't succeed
+func (b *bitbucketInsights) send(ctx context.Context, method string, endpoint string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s returned %s: %s", method, endpoint, resp.Status, bytes.TrimSpace(message))
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_bitbucketInsightsFromEnv(t *testing.T) {
+	env := map[string]string{
+		"BITBUCKET_WORKSPACE": "example",
+		"BITBUCKET_REPO_SLUG": "project",
+	}
+
+	if _, ok := bitbucketInsightsFromEnv(func(key string) string { return env[key] }); ok {
+		t.Errorf("expected no publisher without BITBUCKET_COMMIT")
+	}
+
+	env["BITBUCKET_COMMIT"] = "abc123"
+
+	insights, ok := bitbucketInsightsFromEnv(func(key string) string { return env[key] })
+	if !ok {
+		t.Fatalf("expected a publisher when running in Bitbucket Pipelines")
+	}
+
+	if insights.workspace != "example" || insights.repoSlug != "project" || insights.commit != "abc123" {
+		t.Errorf("unexpected publisher: %+v", insights)
+	}
+
+	if insights.client.Timeout != bitbucketTimeout {
+		t.Errorf("expected requests to time out after %s, got %s", bitbucketTimeout, insights.client.Timeout)
+	}
+}
+
+func Test_bitbucketInsightsAnnotations(t *testing.T) {
+	insights := &bitbucketInsights{}
+
+	tests := map[string]struct {
+		failure reportFailure
+
+		expectSummaryLength int
+		expectLine          bool
+	}{
+		"failure without a line": {
+			failure:             reportFailure{Path: "LICENSE", Message: "no license file found"},
+			expectSummaryLength: len("no license file found"),
+		},
+		"failure with a line": {
+			failure:             reportFailure{Path: "a.go", Message: "wrong author", Line: 3},
+			expectSummaryLength: len("wrong author"),
+			expectLine:          true,
+		},
+		"long summary with multi-byte characters": {
+			failure:             reportFailure{Path: "a.go", Message: strings.Repeat("©", bitbucketMaxSummary+10)},
+			expectSummaryLength: bitbucketMaxSummary,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			annotations := insights.annotations(report{Failures: []reportFailure{test.failure}})
+			if len(annotations) != 1 {
+				t.Fatalf("expected 1 annotation, got %d", len(annotations))
+			}
+
+			summary := annotations[0].Summary
+			if !utf8.ValidString(summary) {
+				t.Errorf("summary isn't valid UTF-8: %q", summary)
+			}
+
+			if length := utf8.RuneCountInString(summary); length != test.expectSummaryLength {
+				t.Errorf("got a summary of %d characters, wanted %d", length, test.expectSummaryLength)
+			}
+
+			encoded, err := json.Marshal(annotations[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hasLine := strings.Contains(string(encoded), `"line"`); hasLine != test.expectLine {
+				t.Errorf("expected line to be set=%v, got %s", test.expectLine, encoded)
+			}
+		})
+	}
+}
+
+func Test_bitbucketInsightsPublish(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		body   []byte
+	}
+
+	var requests []request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{method: r.Method, path: r.URL.Path, body: body})
+	}))
+	defer server.Close()
+
+	cloneDir := t.TempDir()
+
+	insights := &bitbucketInsights{
+		client:    server.Client(),
+		baseURL:   server.URL,
+		workspace: "example",
+		repoSlug:  "project",
+		commit:    "abc123",
+		cloneDir:  cloneDir,
+	}
+
+	validationErrors := []error{
+		&fileError{
+			path: filepath.Join(cloneDir, "pkg", "a.go"),
+			err: &boilersuite.ValidationError{
+				Reason: "does not start with expected template type",
+				Kind:   boilersuite.ErrorKindWrongAuthor,
+				Line:   2,
+			},
+		},
+	}
+
+	if err := insights.publish(context.Background(), newReport(3, validationErrors, noDeviations)); err != nil {
+		t.Fatalf("failed to publish: %s", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests but got %d", len(requests))
+	}
+
+	reportPath := "/repositories/example/project/commit/abc123/reports/boilersuite"
+
+	if requests[0].method != http.MethodPut || requests[0].path != reportPath {
+		t.Errorf("expected the report to be created with PUT %s, got %s %s", reportPath, requests[0].method, requests[0].path)
+	}
+
+	var published bitbucketReport
+	if err := json.Unmarshal(requests[0].body, &published); err != nil {
+		t.Fatalf("report isn't valid JSON: %s", err)
+	}
+
+	if published.Result != "FAILED" {
+		t.Errorf("expected a failed report, got %q", published.Result)
+	}
+
+	if requests[1].method != http.MethodPost || requests[1].path != reportPath+"/annotations" {
+		t.Errorf("expected annotations to be added with POST, got %s %s", requests[1].method, requests[1].path)
+	}
+
+	var annotations []bitbucketAnnotation
+	if err := json.Unmarshal(requests[1].body, &annotations); err != nil {
+		t.Fatalf("annotations aren't valid JSON: %s", err)
+	}
+
+	expected := bitbucketAnnotation{
+		ExternalID:     "boilersuite-1",
+		AnnotationType: "CODE_SMELL",
+		Summary:        "does not start with expected template type [wrong-author]",
+		Severity:       "MEDIUM",
+		Path:           "pkg/a.go",
+		Line:           2,
+	}
+
+	if len(annotations) != 1 || annotations[0] != expected {
+		t.Errorf("got annotations %+v, wanted [%+v]", annotations, expected)
+	}
+}
+
+func Test_bitbucketInsightsPublishError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no access", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	insights := &bitbucketInsights{
+		client:    server.Client(),
+		baseURL:   server.URL,
+		workspace: "example",
+		repoSlug:  "project",
+		commit:    "abc123",
+	}
+
+	if err := insights.publish(context.Background(), newReport(1, nil, noDeviations)); err == nil {
+		t.Errorf("expected an error when Bitbucket rejects the report")
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package boilerplatetemplates embeds the boilerplate templates which are bundled with boilersuite
+package boilerplatetemplates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultLicense is the license whose notice the bundled templates contain
+const DefaultLicense = "apache-2.0"
+
+// FS holds every bundled template at its root, along with the fragments which they include
+//
+//go:embed *.boilertmpl *.boilerfrag
+var FS embed.FS
+
+// licenses holds the notice for each license other than DefaultLicense, named after the license
+//
+//go:embed licenses/*.txt
+var licenses embed.FS
+
+// LicenseNotice returns the notice which follows the copyright line in headers for the named
+// license, such as "mit". Names are case-insensitive. The notice for DefaultLicense is empty, since
+// the bundled templates already contain it, and an empty name means DefaultLicense.
+func LicenseNotice(name string) (string, error) {
+	name = strings.ToLower(name)
+	if name == "" || name == DefaultLicense {
+		return "", nil
+	}
+
+	notice, err := fs.ReadFile(licenses, path.Join("licenses", name+".txt"))
+	if err != nil {
+		return "", fmt.Errorf("unknown license %q; expected one of %s", name, strings.Join(LicenseNames(), ", "))
+	}
+
+	return string(notice), nil
+}
+
+// licenseTexts holds the full text expected in the LICENSE file of a project under each license,
+// named after the license. Parts of the text which vary between copies, such as the name of the
+// copyright holder, are replaced by a "<<ANY>>" marker.
+//
+//go:embed license-texts/*.txt
+var licenseTexts embed.FS
+
+// LicenseText returns the text expected in the LICENSE file of a project under the named license,
+// such as "mit". Names are case-insensitive, and an empty name means DefaultLicense.
+func LicenseText(name string) (string, error) {
+	name = strings.ToLower(name)
+	if name == "" {
+		name = DefaultLicense
+	}
+
+	text, err := fs.ReadFile(licenseTexts, path.Join("license-texts", name+".txt"))
+	if err != nil {
+		return "", fmt.Errorf("unknown license %q; expected one of %s", name, strings.Join(LicenseNames(), ", "))
+	}
+
+	return string(text), nil
+}
+
+// LicenseNames returns the name of every license which the bundled templates can be used with,
+// in alphabetical order
+func LicenseNames() []string {
+	names := []string{DefaultLicense}
+
+	entries, _ := fs.ReadDir(licenses, "licenses")
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".txt"))
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// runBumpYear updates the copyright year in the valid boilerplate of the selected files
+func runBumpYear(args []string) {
+	flags := flag.NewFlagSet("bump-year", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "bump-year [flags] <path-to-update>", "Updates the copyright year in files which already have valid boilerplate, or extends it to a range with --extend. Combine with --changed-only to only update files touched since a git ref.")
+
+	global := addGlobalFlags(flags)
+	selection := addTargetFlags(flags)
+
+	year := flags.Int("year", time.Now().Year(), "The year which boilerplate is updated to")
+	extend := flags.Bool("extend", false, "If set, the existing year is extended to a range ending in --year, e.g. \"2019-2026\", rather than replaced")
+	commit := flags.Bool("commit", false, "If set, the updated files are committed to git in a single commit")
+
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	logger := env.logger
+
+	selection.checkArgs(flags, logger, "boilersuite bump-year")
+
+	if *commit && selection.stdin {
+		fatal(logger, "--commit can't be used with --stdin")
+	}
+
+	set := selection.find(flags, env)
+
+	opts := boilersuite.BumpYearOptions{
+		Year:   *year,
+		Extend: *extend,
+	}
+
+	// files which aren't valid text can't have valid boilerplate to update
+	bumped, _ := rewriteTargets(env, set.targets, rewriteOptions{stdin: selection.stdin, skipNonUTF8: true, message: "updated year"}, func(t target, text string) (string, bool, error) {
+		text, changed := t.tmpl.BumpYear(text, opts)
+
+		return text, changed, nil
+	})
+
+	env.exitIfCancelled()
+
+	logger.Info("updated copyright year", "year", *year, "files", len(bumped))
+
+	if !*commit || len(bumped) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("Update copyright year to %d", *year)
+
+	if err := gitCommitFiles(set.repoDir(), bumped, message); err != nil {
+		fatal(logger, "failed to commit updated files", "err", err)
+	}
+
+	logger.Info("committed updated files", "message", message)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+	"github.com/cert-manager/boilersuite/internal/version"
+)
+
+// racyInterval is how recently a file can have been modified before it's not cached. A file which
+// is modified again within its filesystem's timestamp granularity could otherwise keep the same
+// size and modification time, and so be skipped even though it changed.
+const racyInterval = 2 * time.Second
+
+// fileCache records the size and modification time of files which passed validation, so that they
+// can be skipped on later runs if they're unchanged. Unlike incrementalState, which tracks
+// directories, this catches files which are edited in place.
+type fileCache struct {
+	// Fingerprint identifies the settings used for the run; if they change, every entry is discarded
+	Fingerprint string `json:"fingerprint"`
+
+	Files map[string]cachedFile `json:"files"`
+
+	path     string
+	runStart time.Time
+}
+
+// cachedFile is the state of a file when it last passed validation
+type cachedFile struct {
+	Size    int64 `json:"size"`
+	ModTime int64 `json:"modTime"`
+
+	// Template is the fingerprint of the template the file was checked against
+	Template string `json:"template"`
+
+	// Expires is when the file's skip_license_check marker expires, in nanoseconds since the
+	// Unix epoch, after which the file must be checked again even if it's unchanged. It's 0 if the
+	// file has no marker which expires.
+	Expires int64 `json:"expires,omitempty"`
+}
+
+// withExpiry returns the state with Expires set from any expiry date on a skip_license_check
+// marker in the given contents of the file
+func (f cachedFile) withExpiry(text string) cachedFile {
+	suppression, ok, err := boilersuite.FindSuppression(text)
+	if err == nil && ok && !suppression.Until.IsZero() {
+		f.Expires = suppression.ExpiresAt().UnixNano()
+	}
+
+	return f
+}
+
+// openFileCache loads the cache for the given target from cacheDir, or from the user's cache
+// directory if cacheDir is empty
+func openFileCache(cacheDir string, targetBase string, checkHeredocs bool) (*fileCache, error) {
+	path, err := cachePath(cacheDir, targetBase)
+	if err != nil {
+		return nil, err
+	}
+
+	// templates are fingerprinted per file, so only settings which change how files are checked
+	// regardless of their template are needed here
+	return loadFileCache(path, fmt.Sprintf("%s/heredocs=%t", version.AppVersion, checkHeredocs))
+}
+
+// cachePath returns the path of the cache for the given target in cacheDir, or in the
+// user's cache directory if cacheDir is empty
+func cachePath(cacheDir string, targetBase string) (string, error) {
+	if cacheDir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+
+		cacheDir = filepath.Join(userCacheDir, "boilersuite")
+	}
+
+	absBase, err := filepath.Abs(targetBase)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(absBase))
+
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// loadFileCache reads the cache at path, if any. If it was written using different settings, an
+// empty cache is returned.
+func loadFileCache(path string, fingerprint string) (*fileCache, error) {
+	cache := &fileCache{
+		Fingerprint: fingerprint,
+		Files:       make(map[string]cachedFile),
+		path:        path,
+		runStart:    time.Now(),
+	}
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cache, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// a corrupt cache is treated as empty so that it's replaced when the cache is saved
+	var stored fileCache
+	if err := json.Unmarshal(contents, &stored); err == nil && stored.Fingerprint == fingerprint && stored.Files != nil {
+		cache.Files = stored.Files
+	}
+
+	return cache, nil
+}
+
+// lookup returns the current state of the file at path, and whether it's unchanged since it last
+// passed validation against the given template
+func (c *fileCache) lookup(path string, tmpl boilersuite.BoilerplateTemplate) (cachedFile, bool, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return cachedFile{}, false, err
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return cachedFile{}, false, err
+	}
+
+	current := cachedFile{
+		Size:     info.Size(),
+		ModTime:  info.ModTime().UnixNano(),
+		Template: tmpl.Fingerprint(),
+	}
+
+	previous, ok := c.Files[absPath]
+	if !ok || (previous.Expires != 0 && !c.runStart.Before(time.Unix(0, previous.Expires))) {
+		return current, false, nil
+	}
+
+	// the expiry comes from the file's contents, which are unchanged if everything else is
+	current.Expires = previous.Expires
+
+	return current, previous == current, nil
+}
+
+// record stores the state of the file at path if it passed, or forgets it otherwise
+func (c *fileCache) record(path string, state cachedFile, passed bool) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	if !passed || c.runStart.Sub(time.Unix(0, state.ModTime)) < racyInterval {
+		delete(c.Files, absPath)
+		return nil
+	}
+
+	c.Files[absPath] = state
+
+	return nil
+}
+
+// save writes the cache, creating its directory if needed
+func (c *fileCache) save() error {
+	contents, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, contents, 0o644)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_fileCache(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	otherTemplates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, "example")
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	dir := t.TempDir()
+	cacheFile := filepath.Join(dir, "cache", "cache.json")
+
+	writeFile := func(name string, contents string, modTime time.Time) string {
+		path := filepath.Join(dir, name)
+
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+	}
+
+	old := time.Now().Add(-time.Hour)
+
+	passing := writeFile("passing.go", "package a\n", old)
+	failing := writeFile("failing.go", "package b\n", old)
+	recent := writeFile("recent.go", "package c\n", time.Now())
+
+	cache, err := loadFileCache(cacheFile, "v1")
+	if err != nil {
+		t.Fatalf("failed to load cache: %s", err)
+	}
+
+	for path, passed := range map[string]bool{passing: true, failing: false, recent: true} {
+		state, unchanged, err := cache.lookup(path, templates["go"])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if unchanged {
+			t.Errorf("expected %q not to be cached yet", path)
+		}
+
+		if err := cache.record(path, state, passed); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := cache.save(); err != nil {
+		t.Fatalf("failed to save cache: %s", err)
+	}
+
+	cache, err = loadFileCache(cacheFile, "v1")
+	if err != nil {
+		t.Fatalf("failed to reload cache: %s", err)
+	}
+
+	lookup := func(path string, tmpl boilersuite.BoilerplateTemplate) bool {
+		_, unchanged, err := cache.lookup(path, tmpl)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return unchanged
+	}
+
+	if !lookup(passing, templates["go"]) {
+		t.Errorf("expected unchanged passing file to be cached")
+	}
+
+	if lookup(failing, templates["go"]) {
+		t.Errorf("expected failing file not to be cached")
+	}
+
+	if lookup(recent, templates["go"]) {
+		t.Errorf("expected recently modified file not to be cached")
+	}
+
+	if lookup(passing, otherTemplates["go"]) {
+		t.Errorf("expected file not to be cached when its template changes")
+	}
+
+	writeFile("passing.go", "package a // changed\n", old)
+
+	if lookup(passing, templates["go"]) {
+		t.Errorf("expected changed file not to be cached")
+	}
+
+	cache, err = loadFileCache(cacheFile, "v2")
+	if err != nil {
+		t.Fatalf("failed to reload cache: %s", err)
+	}
+
+	if len(cache.Files) != 0 {
+		t.Errorf("expected cache written with different settings to be discarded")
+	}
+}
+
+func Test_fileCacheSuppressionExpiry(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	dir := t.TempDir()
+	cacheFile := filepath.Join(dir, "cache.json")
+
+	const contents = "// +skip_license_check until=2030-06-01\n\npackage a\n"
+
+	path := filepath.Join(dir, "suppressed.go")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := loadFileCache(cacheFile, "v1")
+	if err != nil {
+		t.Fatalf("failed to load cache: %s", err)
+	}
+
+	state, _, err := cache.lookup(path, templates["go"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cache.record(path, state.withExpiry(contents), true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cache.save(); err != nil {
+		t.Fatalf("failed to save cache: %s", err)
+	}
+
+	tests := map[string]struct {
+		runStart     time.Time
+		expectCached bool
+	}{
+		"before the marker expires": {
+			runStart:     time.Date(2030, 6, 1, 23, 0, 0, 0, time.UTC),
+			expectCached: true,
+		},
+		"after the marker expires": {
+			runStart:     time.Date(2030, 6, 2, 0, 0, 0, 0, time.UTC),
+			expectCached: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cache, err := loadFileCache(cacheFile, "v1")
+			if err != nil {
+				t.Fatalf("failed to reload cache: %s", err)
+			}
+
+			cache.runStart = test.runStart
+
+			_, unchanged, err := cache.lookup(path, templates["go"])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if unchanged != test.expectCached {
+				t.Errorf("cached=%v, expected %v", unchanged, test.expectCached)
+			}
+		})
+	}
+}
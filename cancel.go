@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	// exitCodeInterrupted matches the code shells use for processes killed by SIGINT
+	exitCodeInterrupted = 130
+
+	// exitCodeTimedOut matches the code used by the timeout command from coreutils
+	exitCodeTimedOut = 124
+)
+
+var (
+	errInterrupted = errors.New("interrupted")
+	errTimedOut    = errors.New("timed out")
+)
+
+// newRunContext returns a context which is cancelled when the process receives SIGINT or SIGTERM,
+// or once the given timeout has passed if it's non-zero. The cause of the cancellation can be found
+// with context.Cause. A second signal terminates the process immediately.
+func newRunContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	parent, cancelParent := context.WithCancelCause(context.Background())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			cancelParent(errInterrupted)
+
+		case <-parent.Done():
+		}
+
+		// restore the default behaviour so that a second signal isn't ignored
+		signal.Stop(signals)
+	}()
+
+	if timeout == 0 {
+		return parent, func() { cancelParent(context.Canceled) }
+	}
+
+	ctx, cancel := context.WithTimeoutCause(parent, timeout, errTimedOut)
+
+	return ctx, func() {
+		cancel()
+		cancelParent(context.Canceled)
+	}
+}
+
+// exitCodeFor returns the exit code for a run which was cancelled for the given cause
+func exitCodeFor(cause error) int {
+	if errors.Is(cause, errTimedOut) {
+		return exitCodeTimedOut
+	}
+
+	return exitCodeInterrupted
+}
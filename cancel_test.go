@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_newRunContextTimeout(t *testing.T) {
+	ctx, cancel := newRunContext(time.Millisecond)
+	defer cancel()
+
+	<-ctx.Done()
+
+	cause := context.Cause(ctx)
+	if !errors.Is(cause, errTimedOut) {
+		t.Fatalf("expected run to time out but got cause %v", cause)
+	}
+
+	if code := exitCodeFor(cause); code != exitCodeTimedOut {
+		t.Errorf("expected exit code %d but got %d", exitCodeTimedOut, code)
+	}
+}
+
+func Test_exitCodeFor(t *testing.T) {
+	if code := exitCodeFor(errInterrupted); code != exitCodeInterrupted {
+		t.Errorf("expected exit code %d but got %d", exitCodeInterrupted, code)
+	}
+}
+
+func Test_getTargetsCancelled(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = getTargets(ctx, ".", templateResolver{templates: templates}, nil, nil, false, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected walking to stop with context.Canceled but got %v", err)
+	}
+}
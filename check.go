@@ -0,0 +1,615 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// runCheck validates the boilerplate of the selected files. If legacy is set, the command was run
+// without a subcommand and so also accepts the flags which were replaced by subcommands.
+func runCheck(args []string, legacy bool) {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "check [flags] <path-to-validate>", "Validates the boilerplate in a directory or file.")
+
+	global := addGlobalFlags(flags)
+	selection := addTargetFlags(flags)
+	selection.addIncrementalFlags(flags)
+
+	checkpointFile := flags.String("checkpoint", "", "If set, progress is periodically recorded in the given file so that an interrupted run can be continued with --resume. The file is removed when the run completes")
+	resume := flags.Bool("resume", false, "If set, continues the run recorded in the file given by --checkpoint, skipping files which were already checked")
+	checkHeredocsFlag := flags.Bool("check-heredocs", false, "If set, also validates boilerplate inside heredocs in shell scripts which write to files with a template, e.g. \"cat <<EOF > something.go\"")
+	historyFile := flags.String("history-file", "", "If set, appends a summary of the run to the given file; written as CSV if the file has a .csv extension or as JSON lines otherwise")
+	baselineFile := flags.String("baseline", "", "If set, files recorded in the given baseline file are allowed to have invalid boilerplate until their contents change")
+	writeBaselineFile := flags.String("write-baseline", "", "If set, records every file with invalid boilerplate in the given baseline file for use with --baseline, rather than failing")
+	skipNonUTF8 := flags.Bool("skip-non-utf8", false, "If set, files which aren't valid UTF-8 (or UTF-16 with a byte order mark) are skipped with a warning rather than reported as failures")
+	noCache := flags.Bool("no-cache", false, "If set, every file is checked, rather than skipping files which are unchanged since they last passed")
+	cacheDir := flags.String("cache-dir", "", "The directory in which the record of files which passed is kept. Defaults to a \"boilersuite\" directory in the user's cache directory")
+	headKB := flags.Int("head-kb", 16, "How many KiB from the start of each file are read when looking for boilerplate. The whole file is only read if no valid boilerplate is found in that part of it. If 0, whole files are always read")
+	progressMode := flags.String("progress", progressAuto, "How to report progress on stderr while files are checked; one of \"auto\" (a progress line if stderr is a terminal), \"tty\", \"plain\" (a status line every 10 seconds, for CI logs) or \"none\"")
+	maxErrors := flags.Int("max-errors", 0, "If set, stops checking files once the given number of failures have been found. 0 means no limit")
+	failFast := flags.Bool("fail-fast", false, "If set, stops checking files after the first failure. Equivalent to --max-errors=1")
+	quiet := flags.Bool("quiet", false, "If set, invalid files aren't listed and only a one-line summary of the run is printed; failure is reported by the exit code")
+	strictUnknown := flags.Bool("strict-unknown", false, "If set, text files which no template matches are reported as failures, rather than silently skipped. Files which never need boilerplate, such as LICENSE or *.json, aren't reported")
+	requireCurrentYear := flags.String("require-current-year", "", "If set, files which were changed relative to the given git ref, e.g. \"origin/main\", must carry the current copyright year, either alone or at the end of a range. Unchanged files can keep any year. Disables the cache")
+	checkLicense := flags.Bool("check-license-file", false, "If set, the root of the target must also have a LICENSE, LICENSE.md or LICENSE.txt file holding the full text of the license given by --license")
+	outputFlag := flags.String("output", outputText, "The format of the results written to stdout; one of \"text\", \"json\", \"rdjson\" (the Reviewdog Diagnostic Format, with suggested fixes for files which \"boilersuite fix\" can fix) \"tap\" (the Test Anything Protocol) or \"csv\" (a row for each failure with the expected and found copyright holders and years)")
+	bitbucketInsightsFlag := flags.Bool("bitbucket-insights", false, "If set when running in Bitbucket Pipelines, the results are also published to the commit as a Code Insights report, with an annotation for each failure")
+	fuzzy := flags.Bool("fuzzy", false, "If set, headers which are similar to their template are classified by how they differ from it, such as \"header differs only in whitespace\" or \"only the license URL differs\", to help triage large numbers of failures")
+	fuzzyThreshold := flags.Float64("fuzzy-threshold", boilersuite.NearMissSimilarity, "How similar a header must be to its template, between 0 and 1, to be classified by --fuzzy")
+
+	var printVersion, listFlag, listTemplatesFlag, listSuppressionsFlag *bool
+
+	if legacy {
+		printVersion = flags.Bool("version", false, "Deprecated: use \"boilersuite version\"")
+		listFlag = flags.Bool("list", false, "Deprecated: use \"boilersuite list\"")
+		listTemplatesFlag = flags.Bool("list-templates", false, "Deprecated: use \"boilersuite templates\"")
+		listSuppressionsFlag = flags.Bool("list-suppressions", false, "Deprecated: use \"boilersuite suppressions\"")
+	}
+
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	logger := env.logger
+
+	switch *outputFlag {
+	case outputText, outputJSON, outputRDJSON, outputTAP, outputCSV:
+	default:
+		fatal(logger, fmt.Sprintf("unknown --output %q; must be %q, %q, %q, %q or %q", *outputFlag, outputText, outputJSON, outputRDJSON, outputTAP, outputCSV))
+	}
+
+	if err := validProgressMode(*progressMode); err != nil {
+		fatal(logger, err.Error())
+	}
+
+	if *maxErrors < 0 {
+		fatal(logger, "--max-errors must not be negative")
+	}
+
+	if *headKB < 0 {
+		fatal(logger, "--head-kb must not be negative")
+	}
+
+	if *failFast {
+		*maxErrors = 1
+	}
+
+	if *fuzzyThreshold < 0 || *fuzzyThreshold > 1 {
+		fatal(logger, "--fuzzy-threshold must be between 0 and 1")
+	}
+
+	var insights *bitbucketInsights
+
+	if *bitbucketInsightsFlag {
+		var ok bool
+
+		insights, ok = bitbucketInsightsFromEnv(os.Getenv)
+		if !ok {
+			logger.Warn("not publishing a Code Insights report since BITBUCKET_WORKSPACE, BITBUCKET_REPO_SLUG and BITBUCKET_COMMIT aren't all set; is this running in Bitbucket Pipelines?")
+		}
+	}
+
+	deviationThreshold := noDeviations
+	if *fuzzy {
+		deviationThreshold = *fuzzyThreshold
+	}
+
+	if legacy {
+		switch {
+		case *printVersion:
+			printVersionInfo()
+			return
+
+		case *listTemplatesFlag:
+			listTemplatesIn(env, flags.Arg(0))
+			return
+
+		case flags.Arg(0) == "explain":
+			explainPaths(env, flags.Args()[1:])
+			return
+		}
+	}
+
+	selection.checkArgs(flags, logger, "boilersuite check")
+
+	if *resume && *checkpointFile == "" {
+		fatal(logger, "--resume requires --checkpoint to be set")
+	}
+
+	if *baselineFile != "" && *writeBaselineFile != "" {
+		fatal(logger, "at most one of --baseline and --write-baseline can be given")
+	}
+
+	if *strictUnknown {
+		env.resolver.unknown = &unknownFiles{}
+	}
+
+	set := selection.find(flags, env)
+	targets, resolver := set.targets, env.resolver
+
+	if legacy && *listFlag {
+		printTargets(env, targets)
+		return
+	}
+
+	if legacy && *listSuppressionsFlag {
+		printSuppressions(env, targets)
+		return
+	}
+
+	var knownViolations *baseline
+	var err error
+
+	if *baselineFile != "" {
+		knownViolations, err = loadBaseline(*baselineFile)
+		if err != nil {
+			fatal(logger, "failed to load baseline", "path", *baselineFile, "err", err)
+		}
+	} else if *writeBaselineFile != "" {
+		knownViolations, err = newBaseline(*writeBaselineFile)
+		if err != nil {
+			fatal(logger, "invalid baseline path", "path", *writeBaselineFile, "err", err)
+		}
+	}
+
+	var yearPolicy *currentYearPolicy
+
+	if *requireCurrentYear != "" {
+		yearPolicy, err = newCurrentYearPolicy(set.repoDir(), *requireCurrentYear, time.Now().Year())
+		if err != nil {
+			fatal(logger, "failed to list changed files", "ref", *requireCurrentYear, "err", err)
+		}
+	}
+
+	var cache *fileCache
+
+	// the cache only records whether files passed, which depends on the year and on the ref
+	// given to --require-current-year
+	if !*noCache && !selection.stdin && yearPolicy == nil {
+		cache, err = openFileCache(*cacheDir, set.base, *checkHeredocsFlag)
+		if err != nil {
+			// the cache only speeds things up, so carry on without it
+			logger.Warn("not using cache", "err", err)
+		}
+	}
+
+	grandfathered, fixedSinceBaseline, cacheHits := 0, 0, 0
+
+	// files which passed are only listed in TAP output
+	var passedPaths []string
+
+	validationErrors := make([]error, 0)
+
+	allTargets := targets
+
+	var progress *checkpoint
+
+	if *checkpointFile != "" {
+		progress = newCheckpoint(*checkpointFile, set.base)
+
+		if *resume {
+			progress, targets, validationErrors = resumeFromCheckpoint(*checkpointFile, set.base, targets, logger)
+
+			// files which were added to the baseline before the interruption aren't checked again
+			if *writeBaselineFile != "" {
+				for _, path := range progress.Baselined {
+					contents, err := os.ReadFile(path)
+					if err != nil {
+						fatal(logger, "failed to read file", "path", path, "err", err)
+					}
+
+					if err := knownViolations.add(path, contents); err != nil {
+						fatal(logger, "failed to add file to baseline", "path", path, "err", err)
+					}
+				}
+			}
+		}
+	}
+
+	progressReporter := newProgressReporter(*progressMode, os.Stderr, len(targets))
+
+	// checked includes files which were checked before resuming from a checkpoint
+	checked := len(allTargets) - len(targets)
+	stoppedEarly := false
+
+	for _, t := range targets {
+		if env.ctx.Err() != nil {
+			break
+		}
+
+		if *maxErrors > 0 && len(validationErrors) >= *maxErrors {
+			stoppedEarly = true
+			break
+		}
+
+		checked++
+
+		var cacheState cachedFile
+
+		if cache != nil {
+			state, unchanged, err := cache.lookup(t.path, t.tmpl)
+			if err != nil {
+				fatal(logger, "failed to check file", "path", t.path, "err", err)
+			}
+
+			if unchanged {
+				logger.Debug("skipping file as it's unchanged since it last passed", "path", t.path)
+				progressReporter.increment()
+				cacheHits++
+
+				if *outputFlag == outputTAP {
+					passedPaths = append(passedPaths, t.path)
+				}
+
+				continue
+			}
+
+			cacheState = state
+		}
+
+		headSize := *headKB * 1024
+
+		// the baseline hashes whole files, and heredocs can be anywhere in a script
+		if knownViolations != nil || (*checkHeredocsFlag && isShellScript(t.path)) {
+			headSize = 0
+		}
+
+		result, readErr := readAndValidate(t, headSize)
+		if readErr != nil {
+			fatal(logger, "failed to read file", "path", t.path, "err", readErr)
+		}
+
+		if result.binary {
+			logger.Debug("skipping binary file", "path", t.path)
+			progressReporter.increment()
+			continue
+		}
+
+		if *skipNonUTF8 && isEncodingError(result.err) {
+			logger.Warn("skipping file with invalid encoding", "path", t.path, "err", result.err)
+			progressReporter.increment()
+			continue
+		}
+
+		contents, err := result.contents, result.err
+		passed := err == nil
+
+		progressReporter.increment()
+
+		if knownViolations != nil {
+			if *writeBaselineFile != "" {
+				if err != nil {
+					if baselineErr := knownViolations.add(t.path, contents); baselineErr != nil {
+						fatal(logger, "failed to add file to baseline", "path", t.path, "err", baselineErr)
+					}
+
+					logger.Debug("recording file in baseline", "path", t.path, "err", err)
+					err = nil
+
+					if progress != nil {
+						progress.baseline(t.path)
+					}
+				}
+			} else if listed, unchanged := knownViolations.has(t.path, contents); listed {
+				if err != nil && unchanged {
+					logger.Debug("ignoring invalid boilerplate since the file is in the baseline", "path", t.path, "err", err)
+					grandfathered++
+					err = nil
+				} else if err == nil {
+					fixedSinceBaseline++
+				}
+			}
+		}
+
+		if err != nil {
+			fileErr := &fileError{path: t.path, err: err}
+			if *outputFlag == outputRDJSON && result.text != "" {
+				fileErr.suggestion = suggestFix(t.tmpl, result.text, time.Now().Year())
+			}
+
+			err = fileErr
+			validationErrors = append(validationErrors, err)
+		} else if yearPolicy != nil {
+			err = yearPolicy.check(t, result.text)
+			if err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+		}
+
+		var fileErr *fileError
+		if *outputFlag == outputCSV && errors.As(err, &fileErr) {
+			fileErr.copyright = newCopyrightDetails(t.tmpl, result.text)
+		}
+
+		passed = passed && err == nil
+
+		if *checkHeredocsFlag && isShellScript(t.path) {
+			heredocErrors := checkHeredocs(t.path, result.text, resolver, time.Now().Year())
+
+			passed = passed && len(heredocErrors) == 0
+			validationErrors = append(validationErrors, heredocErrors...)
+		}
+
+		if passed && *outputFlag == outputTAP {
+			passedPaths = append(passedPaths, t.path)
+		}
+
+		if cache != nil {
+			if cacheErr := cache.record(t.path, cacheState.withExpiry(result.text), passed); cacheErr != nil {
+				fatal(logger, "failed to record file in cache", "path", t.path, "err", cacheErr)
+			}
+		}
+
+		if progress != nil {
+			if checkpointErr := progress.record(t.path, err); checkpointErr != nil {
+				fatal(logger, "failed to write checkpoint", "path", *checkpointFile, "err", checkpointErr)
+			}
+		}
+
+		if err != nil {
+			continue
+		}
+
+		logger.Debug("validated file successfully", "path", t.path)
+	}
+
+	progressReporter.finish()
+
+	if cache != nil {
+		if cacheHits > 0 {
+			logger.Info("skipped files which are unchanged since they last passed; use --no-cache to check them", "files", cacheHits)
+		}
+
+		if err := cache.save(); err != nil {
+			logger.Warn("failed to save cache", "path", cache.path, "err", err)
+		}
+	}
+
+	if env.ctx.Err() != nil {
+		// report what was found before the run was cancelled. Any checkpoint is kept so that the
+		// run can be continued with --resume
+		if *outputFlag != outputText {
+			results := newReport(checked, validationErrors, deviationThreshold)
+			results.Sample, results.Seed = set.sample, set.sampleSeed
+			results.passed = passedPaths
+
+			if err := writeReport(os.Stdout, *outputFlag, results); err != nil {
+				logger.Error("failed to write report", "err", err)
+			}
+		} else {
+			printValidationErrors(os.Stdout, env.palette(os.Stdout), validationErrors, deviationThreshold)
+		}
+
+		env.exitIfCancelled()
+	}
+
+	if stoppedEarly {
+		logger.Info("stopped after reaching the maximum number of failures; remaining files weren't checked", "max", *maxErrors, "unchecked", len(allTargets)-checked)
+	} else if progress != nil {
+		// the run completed, so there's nothing left to resume
+		if err := os.Remove(*checkpointFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			fatal(logger, "failed to remove checkpoint", "path", *checkpointFile, "err", err)
+		}
+	}
+
+	if *writeBaselineFile != "" {
+		if err := knownViolations.save(*writeBaselineFile); err != nil {
+			fatal(logger, "failed to write baseline", "path", *writeBaselineFile, "err", err)
+		}
+
+		logger.Info("recorded files with invalid boilerplate in baseline", "path", *writeBaselineFile, "files", len(knownViolations.Files))
+	} else if knownViolations != nil {
+		if grandfathered > 0 {
+			logger.Info("ignored files with invalid boilerplate which are in the baseline", "path", *baselineFile, "files", grandfathered)
+		}
+
+		if fixedSinceBaseline > 0 {
+			logger.Warn("files in the baseline now have valid boilerplate; regenerate it with --write-baseline to stop them regressing", "path", *baselineFile, "files", fixedSinceBaseline)
+		}
+	}
+
+	if !stoppedEarly {
+		validationErrors = append(validationErrors, checkGeneratorYears(allTargets, resolver, logger)...)
+	}
+
+	if *strictUnknown {
+		unknownErrors, err := unknownFileErrors(set.base, set.unknown, strings.Fields(selection.unknownAllowlist))
+		if err != nil {
+			fatal(logger, "failed to check files without a template", "err", err)
+		}
+
+		validationErrors = append(validationErrors, unknownErrors...)
+	}
+
+	if *checkLicense {
+		license, err := rootLicense(resolver, set.repoDir(), env.baseConfig.license)
+		if err != nil {
+			fatal(logger, "failed to load config for the repository root", "err", err)
+		}
+
+		if err := checkLicenseFile(set.repoDir(), license); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	if *historyFile != "" {
+		summary := runSummary{
+			Timestamp: time.Now().UTC(),
+			Target:    set.base,
+			Checked:   checked,
+			Failed:    len(validationErrors),
+			Sample:    set.sample,
+			Seed:      set.sampleSeed,
+		}
+
+		summary.Commit, err = gitHeadCommit(set.repoDir())
+		if err != nil {
+			logger.Debug("couldn't determine commit for history file", "err", err)
+		}
+
+		err = appendHistory(*historyFile, summary)
+		if err != nil {
+			fatal(logger, "failed to write history file", "path", *historyFile, "err", err)
+		}
+	}
+
+	results := newReport(checked, validationErrors, deviationThreshold)
+	results.Sample, results.Seed = set.sample, set.sampleSeed
+	results.passed = passedPaths
+
+	if insights != nil {
+		if err := insights.publish(env.ctx, results); err != nil {
+			logger.Warn("failed to publish Bitbucket Code Insights report", "err", err)
+		}
+	}
+
+	if *outputFlag != outputText {
+		if err := writeReport(os.Stdout, *outputFlag, results); err != nil {
+			fatal(logger, "failed to write report", "err", err)
+		}
+	}
+
+	if *quiet {
+		// the summary mustn't be mixed in with a machine-readable report
+		summaryOutput := os.Stdout
+		if *outputFlag != outputText {
+			summaryOutput = os.Stderr
+		}
+
+		fmt.Fprintln(summaryOutput, results.coloredSummary(env.palette(summaryOutput)))
+	}
+
+	if len(validationErrors) == 0 {
+		if set.incremental != nil {
+			if err := set.incremental.save(); err != nil {
+				fatal(logger, "failed to save incremental state", "path", selection.incrementalState, "err", err)
+			}
+		}
+
+		logger.Debug("all files validated successfully")
+		return
+	}
+
+	if *quiet {
+		env.exit(1)
+	}
+
+	if *outputFlag == outputText {
+		printValidationErrors(os.Stdout, env.palette(os.Stdout), validationErrors, deviationThreshold)
+	}
+
+	fatal(env.logger, "at least one file had errors", "failed", len(validationErrors))
+}
+
+// validationResult is the outcome of validating a single target
+type validationResult struct {
+	// contents holds as much of the target as was read
+	contents []byte
+
+	// text is contents decoded as UTF-8, without any byte order mark
+	text string
+
+	// binary is true if the target isn't text, in which case it wasn't validated
+	binary bool
+
+	// err is the reason validation failed, if it did
+	err error
+}
+
+// readAndValidate validates the target, reading only its first headSize bytes if they contain valid
+// boilerplate. Otherwise the whole target is read, so that a header which continues past the head
+// or a marker further down the file is still found. The returned error is set if the target
+// couldn't be read.
+func readAndValidate(t target, headSize int) (validationResult, error) {
+	contents, complete, err := t.readHead(headSize)
+	if err != nil {
+		return validationResult{}, err
+	}
+
+	if boilersuite.IsBinary(contents) {
+		return validationResult{contents: contents, binary: true}, nil
+	}
+
+	// the head of a UTF-16 file could end in the middle of a character, so it's read in full
+	if !complete && boilersuite.IsUTF16(contents) {
+		return validateAll(t)
+	}
+
+	text, _, err := boilersuite.DecodeText(contents)
+	if err != nil {
+		// the head could end in the middle of a multi-byte character
+		if !complete {
+			return validateAll(t)
+		}
+
+		return validationResult{contents: contents, err: err}, nil
+	}
+
+	validationErr := t.tmpl.Validate(text)
+	if validationErr == nil || complete {
+		return validationResult{contents: contents, text: text, err: validationErr}, nil
+	}
+
+	return validateAll(t)
+}
+
+func validateAll(t target) (validationResult, error) {
+	contents, err := t.read()
+	if err != nil {
+		return validationResult{}, err
+	}
+
+	text, _, err := boilersuite.DecodeText(contents)
+	if err != nil {
+		return validationResult{contents: contents, err: err}, nil
+	}
+
+	return validationResult{contents: contents, text: text, err: t.tmpl.Validate(text)}, nil
+}
+
+// resumeFromCheckpoint loads the checkpoint at path, returning it along with the targets which still need to
+// be checked and any failures which were recorded before the run was interrupted
+func resumeFromCheckpoint(path string, targetBase string, targets []target, logger *slog.Logger) (*checkpoint, []target, []error) {
+	progress, err := loadCheckpoint(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		logger.Debug("checkpoint doesn't exist; starting from the beginning", "path", path)
+		return newCheckpoint(path, targetBase), targets, nil
+	} else if err != nil {
+		fatal(logger, "failed to load checkpoint", "path", path, "err", err)
+	}
+
+	if progress.Target != targetBase {
+		fatal(logger, fmt.Sprintf("checkpoint was recorded for %q, not %q", progress.Target, targetBase), "path", path)
+	}
+
+	remaining := progress.remaining(targets)
+
+	logger.Info("resuming from checkpoint", "path", path, "checked", len(targets)-len(remaining), "total", len(targets))
+
+	return progress, remaining, progress.failures()
+}
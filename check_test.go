@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+const validGoHeader = `/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+`
+
+func Test_readAndValidate(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	body := "package a\n\n" + strings.Repeat("// filler\n", 1000)
+
+	tests := map[string]struct {
+		contents     string
+		headSize     int
+		expectErr    bool
+		expectBinary bool
+		expectedRead int
+	}{
+		"valid header in head": {
+			contents:     validGoHeader + body,
+			headSize:     1024,
+			expectedRead: 1024,
+		},
+		"invalid file is read in full": {
+			contents:     body,
+			headSize:     1024,
+			expectErr:    true,
+			expectedRead: len(body),
+		},
+		"generated marker past the head": {
+			contents:     body + "// Code generated by a tool. DO NOT EDIT.\n",
+			headSize:     1024,
+			expectedRead: len(body) + len("// Code generated by a tool. DO NOT EDIT.\n"),
+		},
+		"small file": {
+			contents:     validGoHeader + "package a\n",
+			headSize:     16 * 1024,
+			expectedRead: len(validGoHeader + "package a\n"),
+		},
+		"binary": {
+			contents:     "package a\x00\x01" + body,
+			headSize:     1024,
+			expectBinary: true,
+			expectedRead: 1024,
+		},
+		"UTF-16 is read in full": {
+			contents:     string(boilersuite.EncodeText(validGoHeader+body, boilersuite.EncodingUTF16LE)),
+			headSize:     1024,
+			expectedRead: len(boilersuite.EncodeText(validGoHeader+body, boilersuite.EncodingUTF16LE)),
+		},
+		"multi-byte character split by the head": {
+			contents:     validGoHeader + strings.Repeat("a", 1023-len(validGoHeader)) + "é" + body,
+			headSize:     1024,
+			expectedRead: len(validGoHeader + strings.Repeat("a", 1023-len(validGoHeader)) + "é" + body),
+		},
+		"invalid UTF-8": {
+			contents:     validGoHeader + "package a\n\n// caf\xe9\n",
+			headSize:     1024,
+			expectErr:    true,
+			expectedRead: len(validGoHeader + "package a\n\n// caf\xe9\n"),
+		},
+		"no limit": {
+			contents:     validGoHeader + body,
+			headSize:     0,
+			expectedRead: len(validGoHeader + body),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "file.go")
+
+			if err := os.WriteFile(path, []byte(test.contents), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := readAndValidate(target{path: path, tmpl: templates["go"]}, test.headSize)
+			if err != nil {
+				t.Fatalf("failed to read file: %s", err)
+			}
+
+			if (result.err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", result.err, test.expectErr)
+			}
+
+			if result.binary != test.expectBinary {
+				t.Errorf("binary=%v, expectBinary=%v", result.binary, test.expectBinary)
+			}
+
+			if len(result.contents) != test.expectedRead {
+				t.Errorf("expected %d bytes to be read but got %d", test.expectedRead, len(result.contents))
+			}
+		})
+	}
+}
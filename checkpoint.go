@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// checkpointInterval is the minimum time between writes of the checkpoint file
+const checkpointInterval = 5 * time.Second
+
+// checkpoint records the progress of a run so that it can be resumed if interrupted
+type checkpoint struct {
+	Target    string          `json:"target"`
+	Completed []string        `json:"completed"`
+	Failures  []reportFailure `json:"failures"`
+
+	// Baselined holds the files which were added to the baseline given to --write-baseline rather
+	// than reported as failures
+	Baselined []string `json:"baselined,omitempty"`
+
+	path      string
+	lastSaved time.Time
+}
+
+func newCheckpoint(path string, target string) *checkpoint {
+	return &checkpoint{
+		Target:    target,
+		path:      path,
+		lastSaved: time.Now(),
+	}
+}
+
+// loadCheckpoint reads a checkpoint previously written to path
+func loadCheckpoint(path string) (*checkpoint, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newCheckpoint(path, "")
+
+	if err := json.Unmarshal(contents, c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// remaining returns the targets which haven't been completed according to the checkpoint
+func (c *checkpoint) remaining(targets []target) []target {
+	completed := make(map[string]struct{}, len(c.Completed))
+
+	for _, path := range c.Completed {
+		completed[path] = struct{}{}
+	}
+
+	var remaining []target
+
+	for _, t := range targets {
+		if _, ok := completed[t.path]; !ok {
+			remaining = append(remaining, t)
+		}
+	}
+
+	return remaining
+}
+
+// failures returns the errors which were recorded in the checkpoint, keeping the path, kind and
+// location of each so that every output format reports them as it did before the interruption
+func (c *checkpoint) failures() []error {
+	var failures []error
+
+	for _, failure := range c.Failures {
+		failures = append(failures, restoreFailure(failure))
+	}
+
+	return failures
+}
+
+// restoredError is a failure read back from a checkpoint whose original error type can't be
+// rebuilt, keeping its message and kind
+type restoredError struct {
+	message string
+	kind    string
+}
+
+func (e *restoredError) Error() string {
+	return e.message
+}
+
+// restoreFailure rebuilds an error equivalent to the one which was recorded as failure
+func restoreFailure(failure reportFailure) error {
+	var err error
+
+	switch failure.Kind {
+	case "", failureKindNonUTF8, failureKindNoTemplate, failureKindIncompatibleLicense, failureKindStaleYear:
+		err = &restoredError{message: failure.Message, kind: failure.Kind}
+
+	default:
+		mismatch := &boilersuite.ValidationError{
+			Reason:    failure.Message,
+			Kind:      boilersuite.ValidationErrorKind(failure.Kind),
+			Line:      failure.Line,
+			Found:     failure.Found,
+			Expected:  failure.Expected,
+			Misplaced: failure.Kind == string(boilersuite.ErrorKindMisplaced),
+		}
+
+		if failure.Similarity != nil {
+			mismatch.Similarity = *failure.Similarity
+		}
+
+		err = mismatch
+	}
+
+	if failure.Path == "" {
+		return err
+	}
+
+	return &fileError{path: failure.Path, err: err}
+}
+
+// record notes that the given path was checked, and saves the checkpoint if it hasn't
+// been saved recently
+func (c *checkpoint) record(path string, validationErr error) error {
+	c.Completed = append(c.Completed, path)
+
+	if validationErr != nil {
+		c.Failures = append(c.Failures, newReportFailure(validationErr, noDeviations))
+	}
+
+	if time.Since(c.lastSaved) < checkpointInterval {
+		return nil
+	}
+
+	return c.save()
+}
+
+// baseline notes that the file at path was added to the baseline instead of failing. It's saved
+// along with the next call to record.
+func (c *checkpoint) baseline(path string) {
+	c.Baselined = append(c.Baselined, path)
+}
+
+// save atomically writes the checkpoint to its path, so that a crash while saving
+// can't leave a corrupt checkpoint behind
+func (c *checkpoint) save() error {
+	contents, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	c.lastSaved = time.Now()
+
+	return os.Rename(tmp.Name(), c.path)
+}
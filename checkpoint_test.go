@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_checkpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	progress := newCheckpoint(path, "repo")
+
+	if err := progress.record("repo/a.go", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := progress.record("repo/b.go", errors.New("invalid boilerplate in \"repo/b.go\"")); err != nil {
+		t.Fatal(err)
+	}
+
+	progress.baseline("repo/c.go")
+
+	if err := progress.save(); err != nil {
+		t.Fatalf("failed to save checkpoint: %s", err)
+	}
+
+	loaded, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("failed to load checkpoint: %s", err)
+	}
+
+	if !reflect.DeepEqual(loaded.Baselined, []string{"repo/c.go"}) {
+		t.Errorf("baselined=%v, expected [repo/c.go]", loaded.Baselined)
+	}
+
+	if loaded.Target != "repo" {
+		t.Errorf("target=%q, expected %q", loaded.Target, "repo")
+	}
+
+	remaining := loaded.remaining([]target{{path: "repo/a.go"}, {path: "repo/b.go"}, {path: "repo/c.go"}})
+	if len(remaining) != 1 || remaining[0].path != "repo/c.go" {
+		t.Errorf("expected only repo/c.go to remain but got %v", remaining)
+	}
+
+	var failures []string
+
+	for _, failure := range loaded.failures() {
+		failures = append(failures, failure.Error())
+	}
+
+	if !reflect.DeepEqual(failures, []string{"invalid boilerplate in \"repo/b.go\""}) {
+		t.Errorf("unexpected failures %v", failures)
+	}
+}
+
+func Test_checkpointStructuredFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	failures := []error{
+		&fileError{path: "repo/a.go", err: &boilersuite.ValidationError{
+			Reason:     "first line of boilerplate doesn't match",
+			Kind:       boilersuite.ErrorKindWrongAuthor,
+			Similarity: 0.95,
+			Line:       1,
+			Found:      "Example",
+			Expected:   "cert-manager",
+		}},
+		&fileError{path: "repo/b.go", err: &boilersuite.ValidationError{
+			Reason: "missing boilerplate",
+			Kind:   boilersuite.ErrorKindMissingBoilerplate,
+		}},
+		&fileError{path: "repo/c.py", err: errNoTemplate},
+		&fileError{path: "repo/d.go", err: fmt.Errorf("%w: the file was changed", errStaleYear)},
+		errors.New("not about a single file"),
+	}
+
+	progress := newCheckpoint(path, "repo")
+
+	for _, failure := range failures {
+		var fileErr *fileError
+
+		failurePath := "repo/other.go"
+		if errors.As(failure, &fileErr) {
+			failurePath = fileErr.path
+		}
+
+		if err := progress.record(failurePath, failure); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := progress.save(); err != nil {
+		t.Fatalf("failed to save checkpoint: %s", err)
+	}
+
+	loaded, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("failed to load checkpoint: %s", err)
+	}
+
+	expected := newReport(len(failures), failures, noDeviations)
+	got := newReport(len(failures), loaded.failures(), noDeviations)
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("report changed after resuming from a checkpoint:\ngot      %+v\nexpected %+v", got, expected)
+	}
+
+	for i, failure := range loaded.failures() {
+		if failure.Error() != failures[i].Error() {
+			t.Errorf("got message %q, expected %q", failure.Error(), failures[i].Error())
+		}
+	}
+}
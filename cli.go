@@ -0,0 +1,624 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+	"github.com/cert-manager/boilersuite/internal/version"
+)
+
+// globalOptions holds the flags shared by every command which loads templates
+type globalOptions struct {
+	authors               *commaListFlag
+	authorRegex           string
+	logLevel              string
+	logFormat             string
+	color                 string
+	verbose               bool
+	cpuProfile            string
+	memProfile            string
+	trace                 string
+	timeout               time.Duration
+	noDeprecationWarnings bool
+	generatorSuffixes     string
+	alternatives          string
+	extAliases            string
+	generatedPatterns     stringListFlag
+	generatedMaxLines     int
+	checkGenerated        bool
+	exemptFrontMatter     bool
+	anyYear               bool
+	ignoreYear            bool
+	extraCopyrightHolders string
+	templateRules         string
+	resolutionOrder       string
+	detectLanguage        bool
+	defaultCommentPrefix  string
+	license               string
+}
+
+func addGlobalFlags(fs *flag.FlagSet) *globalOptions {
+	o := &globalOptions{
+		authors: newCommaListFlag(defaultAuthor),
+	}
+
+	fs.StringVar(&o.license, "license", boilerplatetemplates.DefaultLicense, fmt.Sprintf("The license whose notice is expected in the built-in templates; one of %s", strings.Join(boilerplatetemplates.LicenseNames(), ", ")))
+	fs.Var(o.authors, "author", fmt.Sprintf("The expected author for files, which will be substituted for the %q marker in templates. Can be repeated or given a comma-separated list to also accept other authors; the first is used when adding boilerplate", boilersuite.AuthorMarkerRegex))
+	fs.StringVar(&o.authorRegex, "author-regex", "", fmt.Sprintf("If set, headers naming any author which matches the given regular expression in place of the %q marker are accepted, e.g. \"cert-manager|Jetstack\". The first --author is still used when adding boilerplate", boilersuite.AuthorMarkerRegex))
+	fs.BoolVar(&o.anyYear, "any-year", false, "If set, any plausible copyright year or range of years is accepted, such as \"1999\", \"2019-present\" or \"2019, 2021\", rather than only a single year or a range like \"2019-2026\" from 2000 onwards")
+	fs.BoolVar(&o.ignoreYear, "ignore-year", false, "If set, anything is accepted in place of the copyright year, and only the rest of each header is compared; for teams which enforce the year separately")
+	fs.StringVar(&o.extraCopyrightHolders, "extra-copyright-holders", string(boilersuite.ExtraCopyrightForbidden), "Whether headers may have copyright lines for other holders stacked next to the expected copyright line, e.g. for forked code which retains its upstream copyright; one of \"forbidden\", \"allowed\" or \"required\"")
+	fs.StringVar(&o.logLevel, "log-level", "info", "The minimum level of logs to print; one of \"debug\", \"info\", \"warn\" or \"error\". Logs are written to stderr")
+	fs.StringVar(&o.logFormat, "log-format", logFormatText, "The format of logs; either \"text\" or \"json\"")
+	fs.BoolVar(&o.verbose, "verbose", false, "Deprecated: use --log-level=debug")
+	fs.StringVar(&o.color, "color", colorAuto, "Whether to colorize results; one of \"auto\", \"always\" or \"never\". When \"auto\", results are colorized if they're written to a terminal and NO_COLOR isn't set")
+	fs.StringVar(&o.cpuProfile, "cpuprofile", "", "If set, writes CPU profiling information to the given filename")
+	fs.StringVar(&o.memProfile, "memprofile", "", "If set, writes a heap profile to the given filename when the run finishes")
+	fs.StringVar(&o.trace, "trace", "", "If set, writes an execution trace to the given filename, for use with \"go tool trace\"")
+	fs.DurationVar(&o.timeout, "timeout", 0, "If set, the run is stopped after the given duration, e.g. \"5m\", and boilersuite exits with code 124")
+	fs.BoolVar(&o.noDeprecationWarnings, "no-deprecation-warnings", false, "If set, doesn't print warnings about deprecated flags")
+	fs.StringVar(&o.generatorSuffixes, "generator-suffixes", "", "Space-separated list of suffixes of templates used by generators, e.g. \".gotmpl\". Such templates are validated as the file they generate, and their copyright year must match the generated file's")
+	fs.StringVar(&o.alternatives, "alternatives", "", "Space-separated list of \"<template>=<alternative>,...\" entries listing other templates which are also acceptable for files matching a template, e.g. \"go=go-spdx\"")
+	fs.StringVar(&o.extAliases, "ext-alias", "", "Comma-separated list of \"<alias>=<template>\" entries which check files matching an alias against an existing template, e.g. \"bats=bash,zsh=sh\" checks *.bats files against the bash template")
+	fs.Var(&o.generatedPatterns, "generated-pattern", "A regular expression identifying generated files, which aren't checked, in addition to the default \"DO NOT EDIT.\" comment. Can be given multiple times")
+	fs.IntVar(&o.generatedMaxLines, "generated-max-lines", 0, "If set, only the first N lines of each file are searched for patterns identifying generated files")
+	fs.BoolVar(&o.checkGenerated, "check-generated", false, "If set, generated files are validated like any other file rather than being skipped")
+	fs.BoolVar(&o.exemptFrontMatter, "exempt-front-matter", false, "If set, Markdown files which start with YAML front matter aren't checked. By default, boilerplate is expected after the front matter")
+	fs.StringVar(&o.templateRules, "template-rules", "", "Space-separated list of \"<glob>=<template>\" rules which choose a template for matching files before any other resolution strategy, e.g. \"*.yaml.tmpl=yaml\"")
+	fs.StringVar(&o.resolutionOrder, "resolution-order", "", "Space-separated list of strategies used for choosing a template for a file, tried in order. Defaults to \"filename multi-suffix extension basename shebang\"")
+	fs.BoolVar(&o.detectLanguage, "detect-language", false, "If set, the language of files which no other strategy finds a template for is guessed from their contents, using any shebang, editor modeline or typical keywords")
+	fs.StringVar(&o.defaultCommentPrefix, "default-comment-prefix", "", "If set, text files which no template matches are checked against the generic \"default\" template, with each of its lines commented using the given prefix, e.g. \"#\" or \"//\"")
+
+	return o
+}
+
+// environment holds everything derived from the global options which commands need
+type environment struct {
+	logger *slog.Logger
+
+	colorMode string
+
+	templates  boilersuite.TemplateMap
+	baseConfig effectiveConfig
+	resolver   templateResolver
+
+	// ctx is cancelled if the run is interrupted or times out
+	ctx context.Context
+
+	// closers are called in reverse order when the environment is closed
+	closers []func()
+}
+
+// load applies the global options, exiting if any are invalid. The returned environment must be
+// closed before the command returns.
+func (o *globalOptions) load(fs *flag.FlagSet) *environment {
+	if o.verbose {
+		o.logLevel = "debug"
+	}
+
+	logger, err := newLogger(os.Stderr, o.logLevel, o.logFormat)
+	if err != nil {
+		fatal(defaultLogger(), err.Error())
+	}
+
+	newDeprecationWarner(logger, o.noDeprecationWarnings).checkFlags(fs, deprecatedFlags)
+
+	if err := validColorMode(o.color); err != nil {
+		fatal(logger, err.Error())
+	}
+
+	env := &environment{
+		logger:    logger,
+		colorMode: o.color,
+	}
+
+	ctx, cancel := newRunContext(o.timeout)
+
+	env.ctx = ctx
+	env.closers = append(env.closers, cancel)
+
+	env.startProfiling(o.cpuProfile, o.memProfile, o.trace)
+
+	// commands only defer closing the environment once it's loaded, so profiles have to be written
+	// here if loading the rest of it fails
+	loaded := false
+
+	defer func() {
+		if !loaded {
+			env.close()
+		}
+	}()
+
+	rules, err := parseResolutionRules(o.templateRules, o.resolutionOrder)
+	if err != nil {
+		fatal(logger, "invalid template resolution rules", "err", err)
+	}
+
+	if o.detectLanguage {
+		rules = append(rules, boilersuite.ResolutionRule{Strategy: boilersuite.ResolveContent})
+	}
+
+	if o.defaultCommentPrefix != "" {
+		// files which never need boilerplate can't be given a header by the default template either
+		rules = append(rules, boilersuite.ResolutionRule{Strategy: boilersuite.ResolveDefault, Exclude: defaultUnknownAllowlist})
+	}
+
+	notice, err := boilerplatetemplates.LicenseNotice(o.license)
+	if err != nil {
+		fatal(logger, "invalid --license", "err", err)
+	}
+
+	author, additionalAuthors := o.authors.values[0], o.authors.values[1:]
+
+	templates, err := boilersuite.LoadTemplatesForLicense(boilerplatetemplates.FS, author, notice)
+	if err != nil {
+		fatal(logger, "failed to load templates", "err", err)
+	}
+
+	if o.anyYear {
+		templates = templates.WithAnyYear()
+	}
+
+	if o.ignoreYear {
+		templates = templates.WithIgnoreYear()
+	}
+
+	extraCopyright, err := boilersuite.ParseExtraCopyrightPolicy(o.extraCopyrightHolders)
+	if err != nil {
+		fatal(logger, "invalid --extra-copyright-holders", "err", err)
+	}
+
+	templates = templates.WithExtraCopyright(extraCopyright)
+
+	alternatives, err := parseAlternatives(o.alternatives)
+	if err != nil {
+		fatal(logger, "invalid --alternatives", "err", err)
+	}
+
+	templates, err = templates.WithAlternatives(alternatives)
+	if err != nil {
+		fatal(logger, "invalid --alternatives", "err", err)
+	}
+
+	extAliases, err := parseExtAliases(o.extAliases)
+	if err != nil {
+		fatal(logger, "invalid --ext-alias", "err", err)
+	}
+
+	templates, err = templates.WithAliases(extAliases)
+	if err != nil {
+		fatal(logger, "invalid --ext-alias", "err", err)
+	}
+
+	if o.defaultCommentPrefix != "" {
+		templates, err = templates.WithDefaultCommentPrefix(o.defaultCommentPrefix)
+		if err != nil {
+			fatal(logger, "invalid --default-comment-prefix", "err", err)
+		}
+	}
+
+	env.baseConfig = effectiveConfig{
+		author:            author,
+		additionalAuthors: additionalAuthors,
+		authorRegex:       o.authorRegex,
+		license:           strings.ToLower(o.license),
+		alternatives:      alternatives,
+		extAliases:        extAliases,
+		generatedPatterns: o.generatedPatterns,
+		generatedMaxLines: o.generatedMaxLines,
+		checkGenerated:    o.checkGenerated,
+		exemptFrontMatter: o.exemptFrontMatter,
+		anyYear:           o.anyYear,
+		ignoreYear:        o.ignoreYear,
+		extraCopyright:    extraCopyright,
+
+		defaultCommentPrefix: o.defaultCommentPrefix,
+	}
+
+	generatedMatcher, err := env.baseConfig.generatedMatcher()
+	if err != nil {
+		fatal(logger, "invalid generated file patterns", "err", err)
+	}
+
+	licensePolicy, err := env.baseConfig.licensePolicy()
+	if err != nil {
+		fatal(logger, "invalid --license", "err", err)
+	}
+
+	env.templates = templates.WithGeneratedMatcher(generatedMatcher).WithLicensePolicy(licensePolicy)
+
+	if o.exemptFrontMatter {
+		env.templates = env.templates.WithFrontMatterExempt()
+	}
+
+	if o.authorRegex != "" {
+		authorRegex, err := regexp.Compile(o.authorRegex)
+		if err != nil {
+			fatal(logger, "invalid --author-regex", "err", err)
+		}
+
+		env.templates, err = env.templates.WithAuthorRegex(authorRegex)
+		if err != nil {
+			fatal(logger, "invalid --author-regex", "err", err)
+		}
+	}
+
+	// files under other authors are accepted by checking them against the same templates, loaded
+	// in the same way as for a config file's additionalAuthors
+	baseConfigs := &dirConfigs{builtins: boilerplatetemplates.FS}
+
+	for _, additionalAuthor := range additionalAuthors {
+		authorTemplates, err := baseConfigs.loadTemplates(env.baseConfig, additionalAuthor)
+		if err != nil {
+			fatal(logger, "failed to load templates", "author", additionalAuthor, "err", err)
+		}
+
+		for name, tmpl := range env.templates {
+			env.templates[name] = tmpl.WithAlternatives(authorTemplates[name])
+		}
+	}
+
+	env.resolver = templateResolver{
+		templates:         env.templates,
+		generatorSuffixes: strings.Fields(o.generatorSuffixes),
+		rules:             rules,
+	}
+
+	loaded = true
+
+	return env
+}
+
+// close stops any profiling and releases the environment's resources
+func (env *environment) close() {
+	for i := len(env.closers) - 1; i >= 0; i-- {
+		env.closers[i]()
+	}
+
+	env.closers = nil
+}
+
+// exitIfCancelled exits if the run was interrupted or timed out, after closing the environment
+func (env *environment) exitIfCancelled() {
+	if env.ctx.Err() == nil {
+		return
+	}
+
+	cause := context.Cause(env.ctx)
+
+	env.logger.Error("run cancelled before every file was checked", "reason", cause)
+	env.exit(exitCodeFor(cause))
+}
+
+// exit exits with the given code in the same way as fatal, once deferred calls such as the one
+// which closes the environment have run
+func (env *environment) exit(code int) {
+	panic(exitCode(code))
+}
+
+// palette returns the palette to use for results written to f
+func (env *environment) palette(f *os.File) palette {
+	return palette{enabled: useColor(env.colorMode, f)}
+}
+
+// useConfigsFrom makes the environment's resolver use per-directory configuration found in root
+// and its subdirectories
+func (env *environment) useConfigsFrom(root string) {
+	configs, err := newDirConfigs(root, env.baseConfig, boilerplatetemplates.FS, env.templates)
+	if err != nil {
+		fatal(env.logger, "failed to load config", "err", err)
+	}
+
+	env.resolver.configs = configs
+}
+
+// targetOptions holds the flags which select the files a command operates on
+type targetOptions struct {
+	skip           string
+	files          bool
+	filesFrom      string
+	stdin          bool
+	stdinFilename  string
+	changedOnly    string
+	noGitignore    bool
+	followSymlinks bool
+	sample         string
+	sampleSeed     int64
+	maxFileSize    string
+
+	// unknownAllowlist holds globs for files which never need boilerplate
+	unknownAllowlist string
+
+	// incrementalState and fullScanEvery are only registered by commands which record state
+	incrementalState string
+	fullScanEvery    int
+}
+
+func addTargetFlags(fs *flag.FlagSet) *targetOptions {
+	o := &targetOptions{}
+
+	fs.StringVar(&o.skip, "skip", "", "Space-separated list of prefixes for paths which shouldn't be checked. Spaces in prefixes not supported.")
+	fs.BoolVar(&o.files, "files", false, "If set, every argument is treated as a file to check; no directories are walked and files without a template produce a warning")
+	fs.StringVar(&o.filesFrom, "files-from", "", "If set, reads a NUL-delimited list of files to check from the given file, or from stdin if \"-\". Implies --files")
+	fs.BoolVar(&o.stdin, "stdin", false, "If set, reads file contents from stdin rather than files on disk. Requires --filename")
+	fs.StringVar(&o.stdinFilename, "filename", "", "The name of the file whose contents are read from stdin when --stdin is set, used for selecting a template")
+	fs.StringVar(&o.changedOnly, "changed-only", "", "If set, only checks files which were changed relative to the given git ref, e.g. \"origin/main\"")
+	fs.BoolVar(&o.noGitignore, "no-gitignore", false, "If set, files ignored by git are checked too. By default, files matched by .gitignore, $GIT_DIR/info/exclude or core.excludesFile are skipped unless they're tracked")
+	fs.BoolVar(&o.followSymlinks, "follow-symlinks", false, "If set, symlinks to directories are walked and symlinks pointing outside of the target directory are checked. By default, both are skipped")
+	fs.StringVar(&o.sample, "sample", "", "If set, uses only a random subset of the target files of the given size, e.g. \"5%\"")
+	fs.Int64Var(&o.sampleSeed, "sample-seed", 0, "The seed used for selecting files when --sample is set. If 0, a random seed is chosen and logged")
+	fs.StringVar(&o.maxFileSize, "max-file-size", "", "If set, files larger than the given size, e.g. \"10M\", are skipped with a warning")
+	fs.StringVar(&o.unknownAllowlist, "unknown-allowlist", "", "Space-separated list of globs for files which never need boilerplate, so they aren't reported by --strict-unknown and aren't checked against the default template, e.g. \"*.tmpl docs/**\". Globs match against the file name, or against the path relative to the target if they contain a \"/\"")
+
+	return o
+}
+
+func (o *targetOptions) addIncrementalFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.incrementalState, "incremental-state", "", "If set, files in directories which are unchanged since the last successful run recorded in the given file are skipped")
+	fs.IntVar(&o.fullScanEvery, "full-scan-every", 10, "When using --incremental-state, every Nth run checks all files regardless of whether their directories changed")
+}
+
+// checkArgs exits with a usage message if the positional arguments don't suit the chosen mode
+func (o *targetOptions) checkArgs(fs *flag.FlagSet, logger *slog.Logger, command string) {
+	if o.filesFrom != "" {
+		o.files = true
+	}
+
+	exclusiveModes := 0
+
+	for _, enabled := range []bool{o.stdin, o.files, o.changedOnly != ""} {
+		if enabled {
+			exclusiveModes++
+		}
+	}
+
+	if exclusiveModes > 1 {
+		fatal(logger, "at most one of --stdin, --files and --changed-only can be given")
+	}
+
+	if o.stdin {
+		if fs.NArg() != 0 || o.stdinFilename == "" {
+			fatal(logger, fmt.Sprintf("usage: %s --stdin --filename <name> [flags]", command))
+		}
+	} else if o.files {
+		if fs.NArg() == 0 && o.filesFrom == "" {
+			fatal(logger, fmt.Sprintf("usage: %s --files [flags] <file>...", command))
+		}
+	} else if fs.NArg() != 1 {
+		fatal(logger, fmt.Sprintf("usage: %s [flags] <path-to-validate>", command))
+	}
+}
+
+// targetSet is the result of selecting files to operate on
+type targetSet struct {
+	// base is the directory or file which was given, or the filename given for stdin
+	base string
+
+	// dir is true if base is a directory
+	dir bool
+
+	targets []target
+
+	// incremental is set if --incremental-state was given
+	incremental *incrementalState
+
+	// unknown holds files which were found but have no template, if the environment's resolver
+	// was set up to collect them
+	unknown []string
+
+	// sample and sampleSeed record the size of the sample given with --sample and the seed used to
+	// select it, if targets were sampled
+	sample     string
+	sampleSeed int64
+}
+
+// repoDir returns the directory which should be used for running git commands
+func (s targetSet) repoDir() string {
+	if s.dir {
+		return s.base
+	}
+
+	return filepath.Dir(s.base)
+}
+
+// find selects the files to operate on, exiting if that fails. It also configures the
+// environment's resolver to use per-directory configuration.
+func (o *targetOptions) find(fs *flag.FlagSet, env *environment) targetSet {
+	logger := env.logger
+
+	var skippedDirs []string
+
+	if len(o.skip) > 0 {
+		skippedDirs = strings.Fields(o.skip)
+	}
+
+	var sampleFraction float64
+
+	if o.sample != "" {
+		var err error
+
+		sampleFraction, err = parseSamplePercentage(o.sample)
+		if err != nil {
+			fatal(logger, "invalid --sample", "err", err)
+		}
+	}
+
+	var maxFileSize int64
+
+	if o.maxFileSize != "" {
+		var err error
+
+		maxFileSize, err = parseByteSize(o.maxFileSize)
+		if err != nil {
+			fatal(logger, "invalid --max-file-size", "err", err)
+		}
+	}
+
+	set := targetSet{base: fs.Arg(0)}
+
+	var err error
+
+	if o.stdin {
+		set.base = o.stdinFilename
+	} else if o.files {
+		// files are given relative to the working directory
+		set.base, set.dir = ".", true
+	} else {
+		set.dir, err = isDir(set.base)
+		if err != nil {
+			// couldn't check if the base was a dir or not
+			fatal(logger, "target invalid", "err", err)
+		}
+	}
+
+	env.useConfigsFrom(set.repoDir())
+
+	env.resolver.rules = excludeFromDefaultTemplate(env.resolver.rules, set.repoDir(), strings.Fields(o.unknownAllowlist))
+
+	resolver := env.resolver
+
+	if o.stdin {
+		tmpl, ok := resolver.templateFor(set.base)
+		if err := resolver.err(); err != nil {
+			fatal(logger, "failed to load config", "err", err)
+		} else if !ok {
+			fatal(logger, "no template matches the filename", "filename", set.base)
+		}
+
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fatal(logger, "failed to read stdin", "err", err)
+		}
+
+		if isSkippedFile(set.base, set.base) {
+			logger.Debug("skipping file", "path", set.base)
+		} else {
+			set.targets = []target{{path: set.base, tmpl: tmpl, contents: contents}}
+		}
+	} else if o.files {
+		paths := fs.Args()
+
+		if o.filesFrom != "" {
+			listed, err := readFileListFrom(o.filesFrom)
+			if err != nil {
+				fatal(logger, "failed to read file list", "path", o.filesFrom, "err", err)
+			}
+
+			paths = append(paths, listed...)
+		}
+
+		set.targets, err = fileListTargets(env.ctx, paths, resolver, skippedDirs, logger)
+		if err != nil {
+			env.exitIfCancelled()
+			fatal(logger, "invalid file list", "err", err)
+		}
+	} else if o.changedOnly != "" {
+		if !set.dir {
+			fatal(logger, "--changed-only requires a directory to be given as the target")
+		}
+
+		changed, err := gitChangedFiles(set.base, o.changedOnly)
+		if err != nil {
+			fatal(logger, "failed to list changed files", "ref", o.changedOnly, "err", err)
+		}
+
+		set.targets, err = filterTargets(env.ctx, set.base, changed, resolver, skippedDirs, logger)
+		if err != nil {
+			env.exitIfCancelled()
+			fatal(logger, "failed to filter changed files", "dir", set.base, "err", err)
+		}
+	} else if set.dir {
+		if o.incrementalState != "" {
+			set.incremental, err = loadIncrementalState(o.incrementalState, env.baseConfig.author+"/"+version.AppVersion, o.fullScanEvery)
+			if err != nil {
+				fatal(logger, "failed to load incremental state", "path", o.incrementalState, "err", err)
+			}
+		}
+
+		set.targets, err = getTargets(env.ctx, set.base, resolver, skippedDirs, set.incremental, o.followSymlinks, logger)
+		if err != nil {
+			env.exitIfCancelled()
+			fatal(logger, "failed to list targets", "dir", set.base, "err", err)
+		}
+	} else {
+		tmpl, ok := resolver.templateFor(set.base)
+		if err := resolver.err(); err != nil {
+			fatal(logger, "failed to load config", "err", err)
+		} else if !ok {
+			fatal(logger, "no template matches the file", "path", set.base)
+		}
+
+		set.targets = []target{{path: set.base, tmpl: tmpl}}
+	}
+
+	if err := resolver.err(); err != nil {
+		fatal(logger, "failed to load config", "err", err)
+	}
+
+	if resolver.unknown != nil {
+		set.unknown = resolver.unknown.paths
+	}
+
+	// a single file given explicitly is always checked, even if git ignores it
+	if set.dir && !o.noGitignore {
+		set.targets, err = filterGitIgnored(set.base, set.targets, logger)
+		if err != nil {
+			fatal(logger, "failed to apply gitignore rules (use --no-gitignore to disable them)", "err", err)
+		}
+
+		set.unknown, err = filterGitIgnoredPaths(set.base, set.unknown, logger)
+		if err != nil {
+			fatal(logger, "failed to apply gitignore rules (use --no-gitignore to disable them)", "err", err)
+		}
+	}
+
+	if o.maxFileSize != "" {
+		set.targets, err = filterLargeFiles(set.targets, maxFileSize, logger)
+		if err != nil {
+			fatal(logger, "failed to check file sizes", "err", err)
+		}
+	}
+
+	if sampleFraction > 0 {
+		seed := o.sampleSeed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+
+		totalTargets := len(set.targets)
+		set.targets = sampleTargets(set.targets, sampleFraction, seed)
+		set.sample, set.sampleSeed = o.sample, seed
+
+		logger.Info("sampled files; reproduce with --sample and --sample-seed", "sampled", len(set.targets), "total", totalTargets, "sample", o.sample, "seed", seed)
+	}
+
+	env.exitIfCancelled()
+
+	return set
+}
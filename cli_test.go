@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func Test_globalOptionsAuthors(t *testing.T) {
+	const header = "# Copyright 2020 The %s Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n# you may not use this file except in compliance with the License.\n# You may obtain a copy of the License at\n#\n#     http://www.apache.org/licenses/LICENSE-2.0\n#\n# Unless required by applicable law or agreed to in writing, software\n# distributed under the License is distributed on an \"AS IS\" BASIS,\n# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.\n# See the License for the specific language governing permissions and\n# limitations under the License.\n\necho hello\n"
+
+	tests := map[string]struct {
+		args []string
+
+		expectAuthor   string
+		expectAccepted []string
+		expectRejected []string
+	}{
+		"default": {
+			expectAuthor:   "cert-manager",
+			expectAccepted: []string{"cert-manager"},
+			expectRejected: []string{"Jetstack"},
+		},
+		"comma-separated": {
+			args:           []string{"--author", "cert-manager, Jetstack"},
+			expectAuthor:   "cert-manager",
+			expectAccepted: []string{"cert-manager", "Jetstack"},
+			expectRejected: []string{"Kubernetes"},
+		},
+		"repeated": {
+			args:           []string{"--author", "Jetstack", "--author", "cert-manager,Kubernetes"},
+			expectAuthor:   "Jetstack",
+			expectAccepted: []string{"cert-manager", "Jetstack", "Kubernetes"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			global := addGlobalFlags(flags)
+
+			if err := flags.Parse(test.args); err != nil {
+				t.Fatalf("failed to parse flags: %s", err)
+			}
+
+			env := global.load(flags)
+			defer env.close()
+
+			if env.baseConfig.author != test.expectAuthor {
+				t.Errorf("got author %q, wanted %q", env.baseConfig.author, test.expectAuthor)
+			}
+
+			if len(test.args) > 0 && !reflect.DeepEqual(append([]string{env.baseConfig.author}, env.baseConfig.additionalAuthors...), global.authors.values) {
+				t.Errorf("expected every author to be recorded, got %q and %q", env.baseConfig.author, env.baseConfig.additionalAuthors)
+			}
+
+			tmpl := env.templates["sh"]
+
+			for _, author := range test.expectAccepted {
+				if err := tmpl.Validate(fmt.Sprintf(header, author)); err != nil {
+					t.Errorf("expected header naming %q to be valid, got %s", author, err)
+				}
+			}
+
+			for _, author := range test.expectRejected {
+				if err := tmpl.Validate(fmt.Sprintf(header, author)); err == nil {
+					t.Errorf("expected header naming %q to be invalid", author)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+func validColorMode(mode string) error {
+	switch mode {
+	case colorAuto, colorAlways, colorNever:
+		return nil
+
+	default:
+		return fmt.Errorf("invalid --color %q; must be %q, %q or %q", mode, colorAuto, colorAlways, colorNever)
+	}
+}
+
+// useColor returns true if output written to f should be colorized. In "auto" mode, output is only
+// colorized for terminals, and never if the NO_COLOR environment variable is set or TERM is "dumb".
+func useColor(mode string, f *os.File) bool {
+	switch mode {
+	case colorAlways:
+		return true
+
+	case colorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return isTerminal(f)
+}
+
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// palette colorizes text if it's enabled, and otherwise returns it unchanged
+type palette struct {
+	enabled bool
+}
+
+func (p palette) wrap(code string, s string) string {
+	if !p.enabled {
+		return s
+	}
+
+	return code + s + ansiReset
+}
+
+func (p palette) failure(s string) string {
+	return p.wrap(ansiRed, s)
+}
+
+func (p palette) warning(s string) string {
+	return p.wrap(ansiYellow, s)
+}
+
+func (p palette) success(s string) string {
+	return p.wrap(ansiGreen, s)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_useColor(t *testing.T) {
+	tests := map[string]struct {
+		mode     string
+		noColor  string
+		expected bool
+	}{
+		"always": {
+			mode:     colorAlways,
+			expected: true,
+		},
+		"always ignores NO_COLOR": {
+			mode:     colorAlways,
+			noColor:  "1",
+			expected: true,
+		},
+		"never": {
+			mode:     colorNever,
+			expected: false,
+		},
+		"auto with a regular file": {
+			mode:     colorAuto,
+			expected: false,
+		},
+	}
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", test.noColor)
+
+			if got := useColor(test.mode, f); got != test.expected {
+				t.Errorf("got %v, wanted %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func Test_palette(t *testing.T) {
+	if got := (palette{}).failure("invalid"); got != "invalid" {
+		t.Errorf("expected disabled palette to leave text unchanged but got %q", got)
+	}
+
+	if got := (palette{enabled: true}).failure("invalid"); got != "\x1b[31minvalid\x1b[0m" {
+		t.Errorf("expected enabled palette to colorize text but got %q", got)
+	}
+}
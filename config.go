@@ -0,0 +1,604 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// dirConfigFilename is the name of the file which configures the directory containing it, along
+// with all of its subdirectories
+const dirConfigFilename = ".boilersuite.json"
+
+// noTemplate can be given as the template of a rule to stop matching files from being checked
+const noTemplate = "none"
+
+// dirConfig is the contents of a config file. Fields which aren't set are inherited from the
+// config of the parent directory.
+type dirConfig struct {
+	// Author overrides the expected author
+	Author string `json:"author,omitempty"`
+
+	// TemplateDir is a directory of templates, relative to the config file, which take precedence
+	// over the built-in templates with the same name
+	TemplateDir string `json:"templateDir,omitempty"`
+
+	// GoTemplates processes the templates in TemplateDir using text/template before they're used
+	GoTemplates *bool `json:"goTemplates,omitempty"`
+
+	// FirstYear is the year in which the project was started, available to templates processed
+	// using text/template as .FirstYear
+	FirstYear int `json:"firstYear,omitempty"`
+
+	// License chooses the license notice used in the built-in templates, e.g. "mit"
+	License string `json:"license,omitempty"`
+
+	// Exempt stops files from being checked at all. Setting it to false re-enables checks for a
+	// subdirectory of an exempt directory.
+	Exempt *bool `json:"exempt,omitempty"`
+
+	// AdditionalAuthors lists other authors which are accepted as well as the expected author,
+	// e.g. ["Kubernetes"] for code forked from Kubernetes. Setting it replaces any inherited list.
+	AdditionalAuthors []string `json:"additionalAuthors,omitempty"`
+
+	// GeneratedPatterns are regular expressions which identify generated files, in addition to
+	// those inherited from the parent directory and the command line
+	GeneratedPatterns []string `json:"generatedPatterns,omitempty"`
+
+	// GeneratedMaxLines limits the search for generated file patterns to the first lines of each
+	// file. Zero searches the whole file.
+	GeneratedMaxLines *int `json:"generatedMaxLines,omitempty"`
+
+	// CheckGenerated validates generated files like any other file, rather than skipping them
+	CheckGenerated *bool `json:"checkGenerated,omitempty"`
+
+	// ExemptFrontMatter stops Markdown files which start with YAML front matter from being
+	// checked, rather than expecting boilerplate after the front matter
+	ExemptFrontMatter *bool `json:"exemptFrontMatter,omitempty"`
+
+	// ExtraCopyrightHolders decides whether copyright lines for other holders may be stacked next
+	// to the expected copyright line; one of "forbidden", "allowed" or "required"
+	ExtraCopyrightHolders string `json:"extraCopyrightHolders,omitempty"`
+
+	// Alternatives lists other templates which are also acceptable for files matching each named
+	// template, e.g. {"go": ["go-spdx"]}. Entries replace those inherited for the same template.
+	Alternatives map[string][]string `json:"alternatives,omitempty"`
+
+	// ExtAliases maps other names, usually file extensions, onto existing templates, e.g.
+	// {"bats": "bash"}. Entries replace those inherited for the same alias.
+	ExtAliases map[string]string `json:"extAliases,omitempty"`
+
+	// Rules choose the template for files matching a glob, relative to the config file, before
+	// any other resolution strategy is tried
+	Rules []dirConfigRule `json:"rules,omitempty"`
+}
+
+// dirConfigRule maps files matching Pattern to the template named Template, or to no template
+// at all if Template is "none"
+type dirConfigRule struct {
+	Pattern  string `json:"pattern"`
+	Template string `json:"template"`
+}
+
+// pathRule is a dirConfigRule along with the location of the config file which declared it
+type pathRule struct {
+	dirConfigRule
+
+	dir    string
+	source string
+}
+
+// matches reports whether the rule applies to the file at the given absolute path
+func (r pathRule) matches(absPath string) bool {
+	rel, err := filepath.Rel(r.dir, absPath)
+	if err != nil {
+		return false
+	}
+
+	return boilersuite.MatchGlob(r.Pattern, filepath.ToSlash(rel))
+}
+
+// effectiveConfig is the result of merging every config from the root of a run down to a directory
+type effectiveConfig struct {
+	author      string
+	templateDir string
+	goTemplates bool
+	firstYear   int
+	license     string
+	exempt      bool
+
+	additionalAuthors []string
+	authorRegex       string
+	alternatives      map[string][]string
+	extAliases        map[string]string
+
+	generatedPatterns []string
+	generatedMaxLines int
+	checkGenerated    bool
+
+	exemptFrontMatter bool
+	anyYear           bool
+	ignoreYear        bool
+	extraCopyright    boilersuite.ExtraCopyrightPolicy
+
+	// defaultCommentPrefix is used to comment the default template, if it's enabled
+	defaultCommentPrefix string
+
+	// rules holds the rules from every config which applies, with rules from the most deeply
+	// nested config first so that they take precedence
+	rules []pathRule
+}
+
+// templateKey identifies the set of templates used under a config
+type templateKey struct {
+	author            string
+	additionalAuthors string
+	authorRegex       string
+	templateDir       string
+	goTemplates       bool
+	firstYear         int
+	license           string
+	alternatives      string
+	extAliases        string
+	generated         string
+	exemptFrontMatter bool
+	anyYear           bool
+	ignoreYear        bool
+	extraCopyright    boilersuite.ExtraCopyrightPolicy
+
+	defaultCommentPrefix string
+}
+
+func (cfg effectiveConfig) templateKey() templateKey {
+	names := make([]string, 0, len(cfg.alternatives))
+	for name := range cfg.alternatives {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var alternatives strings.Builder
+
+	for _, name := range names {
+		fmt.Fprintf(&alternatives, "%s=%s;", name, strings.Join(cfg.alternatives[name], ","))
+	}
+
+	aliases := make([]string, 0, len(cfg.extAliases))
+	for alias, target := range cfg.extAliases {
+		aliases = append(aliases, alias+"="+target)
+	}
+
+	sort.Strings(aliases)
+
+	return templateKey{
+		author:            cfg.author,
+		additionalAuthors: strings.Join(cfg.additionalAuthors, "\x00"),
+		authorRegex:       cfg.authorRegex,
+		templateDir:       cfg.templateDir,
+		goTemplates:       cfg.goTemplates,
+		firstYear:         cfg.firstYear,
+		license:           cfg.license,
+		alternatives:      alternatives.String(),
+		extAliases:        strings.Join(aliases, ","),
+		generated:         fmt.Sprintf("%t:%d:%s", cfg.checkGenerated, cfg.generatedMaxLines, strings.Join(cfg.generatedPatterns, "\x00")),
+		exemptFrontMatter: cfg.exemptFrontMatter,
+		anyYear:           cfg.anyYear,
+		ignoreYear:        cfg.ignoreYear,
+		extraCopyright:    cfg.extraCopyright,
+
+		defaultCommentPrefix: cfg.defaultCommentPrefix,
+	}
+}
+
+// generatedMatcher returns the matcher which identifies generated files under the config
+func (cfg effectiveConfig) generatedMatcher() (boilersuite.GeneratedMatcher, error) {
+	if cfg.checkGenerated {
+		// a matcher without patterns never considers a file to be generated
+		return boilersuite.GeneratedMatcher{}, nil
+	}
+
+	return boilersuite.NewGeneratedMatcher(cfg.generatedPatterns, cfg.generatedMaxLines)
+}
+
+// licensePolicy returns the policy which decides the licenses that files under the config may declare
+func (cfg effectiveConfig) licensePolicy() (boilersuite.LicensePolicy, error) {
+	if cfg.license == "" {
+		return boilersuite.LicensePolicyFor(boilerplatetemplates.DefaultLicense)
+	}
+
+	return boilersuite.LicensePolicyFor(cfg.license)
+}
+
+// loadTemplateDir loads the templates in the config's template dir, which must be set, expecting
+// the given author
+func (cfg effectiveConfig) loadTemplateDir(author string) (boilersuite.TemplateMap, error) {
+	var templates boilersuite.TemplateMap
+	var err error
+
+	if cfg.goTemplates {
+		license := cfg.license
+		if license == "" {
+			license = boilerplatetemplates.DefaultLicense
+		}
+
+		templates, err = boilersuite.LoadGoTemplates(os.DirFS(cfg.templateDir), author, boilersuite.GoTemplateData{
+			License:   license,
+			FirstYear: cfg.firstYear,
+		})
+	} else {
+		templates, err = boilersuite.LoadTemplates(os.DirFS(cfg.templateDir), author)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates from %q: %w", cfg.templateDir, err)
+	}
+
+	return templates, nil
+}
+
+// ruleFor returns the first rule which matches the file at path
+func (cfg effectiveConfig) ruleFor(path string) (pathRule, bool) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return pathRule{}, false
+	}
+
+	for _, rule := range cfg.rules {
+		if rule.matches(absPath) {
+			return rule, true
+		}
+	}
+
+	return pathRule{}, false
+}
+
+// dirConfigs finds and merges config files hierarchically, in the same way that git merges
+// .gitignore files. Only directories inside root are searched for config files.
+type dirConfigs struct {
+	root     string
+	base     effectiveConfig
+	builtins fs.FS
+
+	byDir     map[string]effectiveConfig
+	templates map[templateKey]boilersuite.TemplateMap
+
+	// err holds the first error encountered while loading configs or templates
+	err error
+}
+
+// newDirConfigs creates a dirConfigs rooted at root. The base config holds the values given on the
+// command line, and builtinTemplates must have been loaded using them.
+func newDirConfigs(root string, base effectiveConfig, builtins fs.FS, builtinTemplates boilersuite.TemplateMap) (*dirConfigs, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dirConfigs{
+		root:     absRoot,
+		base:     base,
+		builtins: builtins,
+
+		byDir: make(map[string]effectiveConfig),
+		templates: map[templateKey]boilersuite.TemplateMap{
+			base.templateKey(): builtinTemplates,
+		},
+	}, nil
+}
+
+// forDir returns the merged config which applies to files in dir
+func (c *dirConfigs) forDir(dir string) (effectiveConfig, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return effectiveConfig{}, err
+	}
+
+	return c.forAbsDir(absDir)
+}
+
+func (c *dirConfigs) forAbsDir(absDir string) (effectiveConfig, error) {
+	if cfg, ok := c.byDir[absDir]; ok {
+		return cfg, nil
+	}
+
+	parent := c.base
+
+	if rel, err := filepath.Rel(c.root, absDir); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		// config files outside of the root aren't considered
+		return c.base, nil
+	} else if rel != "." {
+		parent, err = c.forAbsDir(filepath.Dir(absDir))
+		if err != nil {
+			return effectiveConfig{}, err
+		}
+	}
+
+	cfg, err := mergeDirConfig(parent, absDir)
+	if err != nil {
+		return effectiveConfig{}, err
+	}
+
+	c.byDir[absDir] = cfg
+
+	return cfg, nil
+}
+
+// mergeDirConfig applies the config file in dir, if there is one, on top of the parent config
+func mergeDirConfig(parent effectiveConfig, dir string) (effectiveConfig, error) {
+	path := filepath.Join(dir, dirConfigFilename)
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return parent, nil
+	} else if err != nil {
+		return effectiveConfig{}, err
+	}
+
+	var raw dirConfig
+
+	decoder := json.NewDecoder(strings.NewReader(string(contents)))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&raw); err != nil {
+		return effectiveConfig{}, fmt.Errorf("invalid config %q: %w", path, err)
+	}
+
+	cfg := parent
+
+	if raw.Author != "" {
+		cfg.author = raw.Author
+	}
+
+	if raw.TemplateDir != "" {
+		cfg.templateDir = filepath.Join(dir, raw.TemplateDir)
+	}
+
+	if raw.GoTemplates != nil {
+		cfg.goTemplates = *raw.GoTemplates
+	}
+
+	if raw.FirstYear != 0 {
+		cfg.firstYear = raw.FirstYear
+	}
+
+	if raw.License != "" {
+		if _, err := boilerplatetemplates.LicenseNotice(raw.License); err != nil {
+			return effectiveConfig{}, fmt.Errorf("invalid config %q: %w", path, err)
+		}
+
+		cfg.license = strings.ToLower(raw.License)
+	}
+
+	if raw.Exempt != nil {
+		cfg.exempt = *raw.Exempt
+	}
+
+	if len(raw.AdditionalAuthors) > 0 {
+		cfg.additionalAuthors = raw.AdditionalAuthors
+	}
+
+	if len(raw.GeneratedPatterns) > 0 {
+		cfg.generatedPatterns = append(append([]string(nil), parent.generatedPatterns...), raw.GeneratedPatterns...)
+	}
+
+	if raw.GeneratedMaxLines != nil {
+		cfg.generatedMaxLines = *raw.GeneratedMaxLines
+	}
+
+	if raw.CheckGenerated != nil {
+		cfg.checkGenerated = *raw.CheckGenerated
+	}
+
+	if raw.ExemptFrontMatter != nil {
+		cfg.exemptFrontMatter = *raw.ExemptFrontMatter
+	}
+
+	if raw.ExtraCopyrightHolders != "" {
+		cfg.extraCopyright, err = boilersuite.ParseExtraCopyrightPolicy(raw.ExtraCopyrightHolders)
+		if err != nil {
+			return effectiveConfig{}, fmt.Errorf("invalid config %q: %w", path, err)
+		}
+	}
+
+	if len(raw.Alternatives) > 0 {
+		cfg.alternatives = make(map[string][]string, len(parent.alternatives)+len(raw.Alternatives))
+
+		for name, alternatives := range parent.alternatives {
+			cfg.alternatives[name] = alternatives
+		}
+
+		for name, alternatives := range raw.Alternatives {
+			cfg.alternatives[name] = alternatives
+		}
+	}
+
+	if len(raw.ExtAliases) > 0 {
+		cfg.extAliases = make(map[string]string, len(parent.extAliases)+len(raw.ExtAliases))
+
+		for alias, target := range parent.extAliases {
+			cfg.extAliases[alias] = target
+		}
+
+		for alias, target := range raw.ExtAliases {
+			cfg.extAliases[strings.TrimPrefix(alias, ".")] = strings.TrimPrefix(target, ".")
+		}
+	}
+
+	if len(raw.Rules) > 0 {
+		rules := make([]pathRule, 0, len(raw.Rules)+len(parent.rules))
+
+		for _, rule := range raw.Rules {
+			if rule.Pattern == "" || rule.Template == "" {
+				return effectiveConfig{}, fmt.Errorf("invalid config %q: rules must have both a pattern and a template", path)
+			}
+
+			// allow templates to be given by their filename, e.g. "boilerplate.go-old.boilertmpl"
+			rule.Template = strings.TrimPrefix(strings.TrimSuffix(rule.Template, ".boilertmpl"), "boilerplate.")
+
+			rules = append(rules, pathRule{dirConfigRule: rule, dir: dir, source: path})
+		}
+
+		cfg.rules = append(rules, parent.rules...)
+	}
+
+	return cfg, nil
+}
+
+// templatesFor returns the templates which apply under the given config, loading them if needed
+func (c *dirConfigs) templatesFor(cfg effectiveConfig) (boilersuite.TemplateMap, error) {
+	if templates, ok := c.templates[cfg.templateKey()]; ok {
+		return templates, nil
+	}
+
+	templates, err := c.loadTemplates(cfg, cfg.author)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher, err := cfg.generatedMatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	licensePolicy, err := cfg.licensePolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	templates = templates.WithGeneratedMatcher(matcher).WithLicensePolicy(licensePolicy)
+
+	if cfg.exemptFrontMatter {
+		templates = templates.WithFrontMatterExempt()
+	}
+
+	if cfg.authorRegex != "" {
+		authorRegex, err := regexp.Compile(cfg.authorRegex)
+		if err != nil {
+			return nil, err
+		}
+
+		templates, err = templates.WithAuthorRegex(authorRegex)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, author := range cfg.additionalAuthors {
+		authorTemplates, err := c.loadTemplates(cfg, author)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, tmpl := range templates {
+			templates[name] = tmpl.WithAlternatives(authorTemplates[name])
+		}
+	}
+
+	c.templates[cfg.templateKey()] = templates
+
+	return templates, nil
+}
+
+// loadTemplates loads the built-in templates and any in the config's template dir, expecting the
+// given author
+func (c *dirConfigs) loadTemplates(cfg effectiveConfig, author string) (boilersuite.TemplateMap, error) {
+	notice, err := boilerplatetemplates.LicenseNotice(cfg.license)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := boilersuite.LoadTemplatesForLicense(c.builtins, author, notice)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.templateDir != "" {
+		overrides, err := cfg.loadTemplateDir(author)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, tmpl := range overrides {
+			templates[name] = tmpl
+		}
+	}
+
+	if cfg.anyYear {
+		templates = templates.WithAnyYear()
+	}
+
+	if cfg.ignoreYear {
+		templates = templates.WithIgnoreYear()
+	}
+
+	templates = templates.WithExtraCopyright(cfg.extraCopyright)
+
+	templates, err = templates.WithAlternatives(cfg.alternatives)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err = templates.WithAliases(cfg.extAliases)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.defaultCommentPrefix == "" {
+		return templates, nil
+	}
+
+	return templates.WithDefaultCommentPrefix(cfg.defaultCommentPrefix)
+}
+
+// lookup returns the config and templates which apply to the file at path. Any error is recorded
+// so that it can be reported once all targets have been found.
+func (c *dirConfigs) lookup(path string) (effectiveConfig, boilersuite.TemplateMap, bool) {
+	cfg, err := c.forDir(filepath.Dir(path))
+	if err != nil {
+		c.recordErr(err)
+		return effectiveConfig{}, nil, false
+	}
+
+	if cfg.exempt {
+		return cfg, nil, true
+	}
+
+	templates, err := c.templatesFor(cfg)
+	if err != nil {
+		c.recordErr(err)
+		return effectiveConfig{}, nil, false
+	}
+
+	return cfg, templates, true
+}
+
+func (c *dirConfigs) recordErr(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
@@ -0,0 +1,496 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_dirConfigs(t *testing.T) {
+	root := t.TempDir()
+
+	configs := map[string]string{
+		"":                          `{"author": "root-author"}`,
+		"third_party":               `{"exempt": true}`,
+		"third_party/ours":          `{"exempt": false, "author": "ours"}`,
+		"third_party/ours/upstream": `{"templateDir": "../templates"}`,
+	}
+
+	for dir, contents := range configs {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(root, dir, dirConfigFilename), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := newDirConfigs(root, effectiveConfig{author: "flag-author"}, boilerplatetemplates.FS, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		dir      string
+		expected effectiveConfig
+	}{
+		"root config overrides flags": {
+			dir:      "pkg",
+			expected: effectiveConfig{author: "root-author"},
+		},
+		"exempt directory": {
+			dir:      "third_party/vendored/deep",
+			expected: effectiveConfig{author: "root-author", exempt: true},
+		},
+		"re-enabled subdirectory": {
+			dir:      "third_party/ours",
+			expected: effectiveConfig{author: "ours"},
+		},
+		"template dir is relative to config": {
+			dir:      "third_party/ours/upstream/pkg",
+			expected: effectiveConfig{author: "ours", templateDir: filepath.Join(root, "third_party/ours/templates")},
+		},
+		"outside of root": {
+			dir:      "..",
+			expected: effectiveConfig{author: "flag-author"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := c.forDir(filepath.Join(root, test.dir))
+			if err != nil {
+				t.Fatalf("failed to get config: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("got %+v, wanted %+v", got, test.expected)
+			}
+		})
+	}
+}
+
+func Test_dirConfigsInvalid(t *testing.T) {
+	root := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(root, dirConfigFilename), []byte(`{"auther": "typo"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := newDirConfigs(root, effectiveConfig{author: "flag-author"}, boilerplatetemplates.FS, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.forDir(root); err == nil {
+		t.Errorf("expected an error for a config with an unknown field")
+	}
+}
+
+func Test_templateResolverConfigRules(t *testing.T) {
+	root := t.TempDir()
+
+	mustWrite := func(path string, contents string) {
+		t.Helper()
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mustWrite(filepath.Join(root, dirConfigFilename), `{
+	"templateDir": "templates",
+	"rules": [
+		{"pattern": "cmd/legacy/**", "template": "go-old.boilertmpl"},
+		{"pattern": "docs/**", "template": "none"}
+	]
+}`)
+	mustWrite(filepath.Join(root, "templates", "boilerplate.go-old.boilertmpl"), "// Copyright <<YEAR>> <<AUTHOR>>\n\n")
+	mustWrite(filepath.Join(root, "cmd", "legacy", dirConfigFilename), `{"rules": [{"pattern": "keep/*.go", "template": "go"}]}`)
+
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, "cert-manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := templateResolver{templates: templates}
+
+	resolver.configs, err = newDirConfigs(root, effectiveConfig{author: "cert-manager"}, boilerplatetemplates.FS, templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// legacyFile is only valid when checked against the go-old template
+	const legacyFile = "// Copyright 2019 cert-manager\n\npackage main\n"
+
+	tests := map[string]struct {
+		path         string
+		expectLegacy bool
+		expectExempt bool
+	}{
+		"no rule matches": {
+			path: "pkg/main.go",
+		},
+		"rule in root config": {
+			path:         "cmd/legacy/tool/main.go",
+			expectLegacy: true,
+		},
+		"nested rule takes precedence": {
+			path: "cmd/legacy/keep/main.go",
+		},
+		"rule with no template": {
+			path:         "docs/conf.py",
+			expectExempt: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(root, test.path)
+
+			if exempt := resolver.exempt(path); exempt != test.expectExempt {
+				t.Fatalf("exempt=%v, expected %v", exempt, test.expectExempt)
+			}
+
+			tmpl, ok := resolver.templateFor(path)
+			if err := resolver.err(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if ok == test.expectExempt {
+				t.Fatalf("ok=%v, expected a template=%v", ok, !test.expectExempt)
+			}
+
+			if !ok {
+				return
+			}
+
+			if legacy := tmpl.Validate(legacyFile) == nil; legacy != test.expectLegacy {
+				t.Errorf("got legacy template=%v, expected %v", legacy, test.expectLegacy)
+			}
+		})
+	}
+}
+
+func Test_dirConfigsExtAliases(t *testing.T) {
+	root := t.TempDir()
+	tests := filepath.Join(root, "test")
+
+	if err := os.MkdirAll(tests, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tests, dirConfigFilename), []byte(`{"extAliases": {".bats": "bash"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, "cert-manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := effectiveConfig{author: "cert-manager", extAliases: map[string]string{"zsh": "sh"}}
+
+	templates, err = templates.WithAliases(base.extAliases)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := templateResolver{templates: templates}
+
+	resolver.configs, err = newDirConfigs(root, base, boilerplatetemplates.FS, templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		path     string
+		expectOK bool
+	}{
+		"alias from flags": {
+			path:     filepath.Join(root, "hack", "setup.zsh"),
+			expectOK: true,
+		},
+		"alias from config": {
+			path:     filepath.Join(tests, "unit.bats"),
+			expectOK: true,
+		},
+		"alias from config doesn't apply outside its directory": {
+			path: filepath.Join(root, "hack", "unit.bats"),
+		},
+		"alias from flags is inherited": {
+			path:     filepath.Join(tests, "setup.zsh"),
+			expectOK: true,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, ok := resolver.templateFor(test.path)
+			if err := resolver.err(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if ok != test.expectOK {
+				t.Errorf("ok=%v, expected %v", ok, test.expectOK)
+			}
+		})
+	}
+}
+
+func Test_dirConfigsLicense(t *testing.T) {
+	root := t.TempDir()
+	third := filepath.Join(root, "third_party")
+
+	if err := os.MkdirAll(third, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(third, dirConfigFilename), []byte(`{"license": "MIT"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, "cert-manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := templateResolver{templates: templates}
+
+	resolver.configs, err = newDirConfigs(root, effectiveConfig{author: "cert-manager"}, boilerplatetemplates.FS, templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const mitFile = "# Copyright 2025 The cert-manager Authors.\n#\n# Use of this source code is governed by the MIT license,\n# a copy of which can be found in the LICENSE file.\n#\n# SPDX-License-Identifier: MIT\n\necho hello\n"
+
+	cases := map[string]struct {
+		path      string
+		expectErr bool
+	}{
+		"license from config": {
+			path: filepath.Join(third, "run.sh"),
+		},
+		"default license outside the config's directory": {
+			path:      filepath.Join(root, "hack", "run.sh"),
+			expectErr: true,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			tmpl, ok := resolver.templateFor(test.path)
+			if err := resolver.err(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !ok {
+				t.Fatalf("expected a template")
+			}
+
+			if err := tmpl.Validate(mitFile); (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+		})
+	}
+}
+
+func Test_dirConfigsAdditionalAuthors(t *testing.T) {
+	root := t.TempDir()
+	forked := filepath.Join(root, "third_party", "forked")
+	customized := filepath.Join(root, "third_party", "customized")
+
+	for _, dir := range []string{forked, filepath.Join(customized, "tmpl")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(forked, dirConfigFilename), []byte(`{"additionalAuthors": ["Kubernetes"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(customized, dirConfigFilename), []byte(`{"templateDir": "tmpl", "additionalAuthors": ["Kubernetes"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, "cert-manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := templateResolver{templates: templates}
+
+	resolver.configs, err = newDirConfigs(root, effectiveConfig{author: "cert-manager"}, boilerplatetemplates.FS, templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const kubernetesFile = "# Copyright 2019 The Kubernetes Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n# you may not use this file except in compliance with the License.\n# You may obtain a copy of the License at\n#\n#     http://www.apache.org/licenses/LICENSE-2.0\n#\n# Unless required by applicable law or agreed to in writing, software\n# distributed under the License is distributed on an \"AS IS\" BASIS,\n# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.\n# See the License for the specific language governing permissions and\n# limitations under the License.\n\necho hello\n"
+
+	// the template dir's sh template has the same text as the built-in one
+	customTemplate := strings.NewReplacer("2019", "<<YEAR>>", "Kubernetes", "<<AUTHOR>>").Replace(strings.TrimSuffix(kubernetesFile, "echo hello\n"))
+	if err := os.WriteFile(filepath.Join(customized, "tmpl", "boilerplate.sh.boilertmpl"), []byte(customTemplate), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		path      string
+		expectErr bool
+	}{
+		"forked code": {
+			path: filepath.Join(forked, "pkg", "hack.sh"),
+		},
+		"forked code with a template dir": {
+			path: filepath.Join(customized, "pkg", "hack.sh"),
+		},
+		"other code": {
+			path:      filepath.Join(root, "hack", "hack.sh"),
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, ok := resolver.templateFor(test.path)
+			if !ok {
+				t.Fatalf("no template found: %v", resolver.err())
+			}
+
+			if err := tmpl.Validate(kubernetesFile); (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+		})
+	}
+}
+
+func Test_dirConfigsExtraCopyrightHolders(t *testing.T) {
+	root := t.TempDir()
+	forked := filepath.Join(root, "third_party", "forked")
+	invalid := filepath.Join(root, "invalid")
+
+	for dir, config := range map[string]string{
+		forked:  `{"extraCopyrightHolders": "required"}`,
+		invalid: `{"extraCopyrightHolders": "sometimes"}`,
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, dirConfigFilename), []byte(config), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, "cert-manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := templateResolver{templates: templates}
+
+	resolver.configs, err = newDirConfigs(root, effectiveConfig{author: "cert-manager"}, boilerplatetemplates.FS, templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const stackedFile = "# Copyright 2019 The Kubernetes Authors.\n# Copyright 2024 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n# you may not use this file except in compliance with the License.\n# You may obtain a copy of the License at\n#\n#     http://www.apache.org/licenses/LICENSE-2.0\n#\n# Unless required by applicable law or agreed to in writing, software\n# distributed under the License is distributed on an \"AS IS\" BASIS,\n# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.\n# See the License for the specific language governing permissions and\n# limitations under the License.\n\necho hello\n"
+
+	tests := map[string]struct {
+		path      string
+		expectErr bool
+	}{
+		"forked code": {
+			path: filepath.Join(forked, "pkg", "hack.sh"),
+		},
+		"other code": {
+			path:      filepath.Join(root, "hack", "hack.sh"),
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, ok := resolver.templateFor(test.path)
+			if !ok {
+				t.Fatalf("no template found: %v", resolver.err())
+			}
+
+			if err := tmpl.Validate(stackedFile); (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+		})
+	}
+
+	if _, err := resolver.configs.forDir(invalid); err == nil {
+		t.Errorf("expected an error for a config with an unknown extra copyright policy")
+	}
+}
+
+func Test_dirConfigsGoTemplates(t *testing.T) {
+	root := t.TempDir()
+	templateDir := filepath.Join(root, "hack", "boilerplate")
+
+	if err := os.MkdirAll(templateDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, dirConfigFilename), []byte(`{"templateDir": "hack/boilerplate", "goTemplates": true, "firstYear": 2019}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(templateDir, "boilerplate.sh.boilertmpl"), []byte("# Copyright {{ yearRange .FirstYear 2026 }} The <<AUTHOR>> Authors.\n# SPDX-License-Identifier: {{ .License }}\n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := newDirConfigs(root, effectiveConfig{author: "cert-manager"}, boilerplatetemplates.FS, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := c.forDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := c.templatesFor(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixed, err := templates["sh"].Fix("echo hello\n", boilersuite.FixOptions{Year: 2026})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expected = "# Copyright 2019-2026 The cert-manager Authors.\n# SPDX-License-Identifier: apache-2.0\n\necho hello\n"
+	if fixed != expected {
+		t.Errorf("expected fixed file to be %q, got %q", expected, fixed)
+	}
+}
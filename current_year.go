@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// errStaleYear is reported by --require-current-year for changed files whose copyright year wasn't updated
+var errStaleYear = errors.New("stale copyright year")
+
+// currentYearPolicy requires files which were changed relative to a git ref to carry the current
+// year, either alone or at the end of a range. Unchanged files can keep any year.
+type currentYearPolicy struct {
+	ref  string
+	year int
+
+	// changed holds the absolute paths of files which were changed relative to ref
+	changed map[string]struct{}
+}
+
+func newCurrentYearPolicy(dir string, ref string, year int) (*currentYearPolicy, error) {
+	paths, err := gitChangedFiles(dir, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		changed[path] = struct{}{}
+	}
+
+	return &currentYearPolicy{ref: ref, year: year, changed: changed}, nil
+}
+
+// check returns an error if the target was changed but its valid boilerplate doesn't carry the
+// current year. Files without valid boilerplate, along with generated and skipped files, pass.
+func (p *currentYearPolicy) check(t target, text string) error {
+	absPath, err := filepath.Abs(t.path)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := p.changed[absPath]; !ok {
+		return nil
+	}
+
+	if _, stale := t.tmpl.BumpYear(text, boilersuite.BumpYearOptions{Year: p.year, Extend: true}); !stale {
+		return nil
+	}
+
+	year, _ := boilersuite.ExtractYear(text)
+
+	return &fileError{
+		path: t.path,
+		err:  fmt.Errorf("%w: the file was changed since %s, so its copyright year %s must be updated to %d or extended to a range ending in it, e.g. with \"boilersuite bump-year --extend --changed-only %s\"", errStaleYear, p.ref, year, p.year, p.ref),
+	}
+}
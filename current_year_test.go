@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_currentYearPolicy(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	repo, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mustRunGit := func(args ...string) {
+		t.Helper()
+
+		if _, err := runGit(repo, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const header = "# Copyright %s The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n# you may not use this file except in compliance with the License.\n# You may obtain a copy of the License at\n#\n#     http://www.apache.org/licenses/LICENSE-2.0\n#\n# Unless required by applicable law or agreed to in writing, software\n# distributed under the License is distributed on an \"AS IS\" BASIS,\n# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.\n# See the License for the specific language governing permissions and\n# limitations under the License.\n\necho %s\n"
+
+	files := map[string]string{
+		"untouched.sh": fmt.Sprintf(header, "2019", "hello"),
+		"modified.sh":  fmt.Sprintf(header, "2019", "hello"),
+		"extended.sh":  fmt.Sprintf(header, "2019", "hello"),
+		"generated.sh": "# Code generated by a tool. DO NOT EDIT.\n\necho hello\n",
+	}
+
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mustRunGit("init", "-q")
+	mustRunGit("add", ".")
+	mustRunGit("commit", "-q", "-m", "initial")
+
+	files = map[string]string{
+		"modified.sh":  fmt.Sprintf(header, "2019", "changed"),
+		"extended.sh":  fmt.Sprintf(header, "2019-2026", "changed"),
+		"generated.sh": "# Code generated by a tool. DO NOT EDIT.\n\necho changed\n",
+		"new.sh":       fmt.Sprintf(header, "2026", "hello"),
+		"new-stale.sh": fmt.Sprintf(header, "2024", "hello"),
+	}
+
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := newCurrentYearPolicy(repo, "HEAD", 2026)
+	if err != nil {
+		t.Fatalf("failed to create policy: %s", err)
+	}
+
+	tests := map[string]struct {
+		expectErr bool
+	}{
+		"untouched.sh": {},
+		"modified.sh":  {expectErr: true},
+		"extended.sh":  {},
+		"generated.sh": {},
+		"new.sh":       {},
+		"new-stale.sh": {expectErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(repo, name)
+
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = policy.check(target{path: path, tmpl: templates["sh"]}, string(contents))
+			if (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if err != nil && !errors.Is(err, errStaleYear) {
+				t.Errorf("expected a stale year error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+)
+
+// deprecation describes something which still works but which will be removed in a future release,
+// along with what should be used instead
+type deprecation struct {
+	// Old is the deprecated usage, e.g. "--verbose"
+	Old string
+
+	// New is what should be used instead, e.g. "--log-level=debug"
+	New string
+
+	// Hint optionally gives extra detail on how to migrate
+	Hint string
+}
+
+// deprecatedFlags maps the names of deprecated flags to their replacements. Flags listed here
+// produce a warning when they're set.
+var deprecatedFlags = map[string]deprecation{
+	"verbose":           {Old: "--verbose", New: "--log-level=debug"},
+	"version":           {Old: "--version", New: "\"boilersuite version\""},
+	"list":              {Old: "--list", New: "\"boilersuite list\""},
+	"list-templates":    {Old: "--list-templates", New: "\"boilersuite templates\""},
+	"list-suppressions": {Old: "--list-suppressions", New: "\"boilersuite suppressions\""},
+}
+
+// deprecationWarner prints each deprecation warning at most once per run
+type deprecationWarner struct {
+	logger   *slog.Logger
+	silenced bool
+	warned   map[string]struct{}
+}
+
+func newDeprecationWarner(logger *slog.Logger, silenced bool) *deprecationWarner {
+	return &deprecationWarner{
+		logger:   logger,
+		silenced: silenced,
+		warned:   make(map[string]struct{}),
+	}
+}
+
+// warn prints a migration hint for the given deprecation, unless one was already printed for it
+func (w *deprecationWarner) warn(d deprecation) {
+	if w.silenced {
+		return
+	}
+
+	if _, ok := w.warned[d.Old]; ok {
+		return
+	}
+
+	w.warned[d.Old] = struct{}{}
+
+	msg := fmt.Sprintf("%s is deprecated and will be removed in a future release; use %s instead", d.Old, d.New)
+
+	if d.Hint != "" {
+		msg += fmt.Sprintf(" (%s)", d.Hint)
+	}
+
+	w.logger.Warn(msg)
+}
+
+// checkFlags warns about every deprecated flag which was set in the given flag set
+func (w *deprecationWarner) checkFlags(fs *flag.FlagSet, deprecated map[string]deprecation) {
+	fs.Visit(func(f *flag.Flag) {
+		if d, ok := deprecated[f.Name]; ok {
+			w.warn(d)
+		}
+	})
+}
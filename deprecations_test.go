@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func Test_deprecationWarner(t *testing.T) {
+	deprecated := map[string]deprecation{
+		"old":    {Old: "--old", New: "--new"},
+		"unused": {Old: "--unused", New: "--something-else"},
+	}
+
+	tests := map[string]struct {
+		args     []string
+		silenced bool
+		expected string
+	}{
+		"deprecated flag set": {
+			args:     []string{"--old", "x"},
+			expected: "level=WARN msg=\"--old is deprecated and will be removed in a future release; use --new instead\"\n",
+		},
+		"no deprecated flags set": {
+			args:     []string{"--current", "x"},
+			expected: "",
+		},
+		"silenced": {
+			args:     []string{"--old", "x"},
+			silenced: true,
+			expected: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.String("old", "", "")
+			fs.String("unused", "", "")
+			fs.String("current", "", "")
+
+			if err := fs.Parse(test.args); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+
+			logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{ReplaceAttr: dropTime}))
+
+			warner := newDeprecationWarner(logger, test.silenced)
+
+			// warnings should only be printed once per run
+			warner.checkFlags(fs, deprecated)
+			warner.checkFlags(fs, deprecated)
+
+			if out.String() != test.expected {
+				t.Errorf("got %q, wanted %q", out.String(), test.expected)
+			}
+		})
+	}
+}
+
+// dropTime removes timestamps from log output so that it can be compared
+func dropTime(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+
+	return a
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// explainResolution writes a description of how a template is chosen for each of the given paths,
+// listing every rule which was tried. It returns false if any path had no matching template.
+func explainResolution(w io.Writer, resolver templateResolver, paths []string) bool {
+	allMatched := true
+
+	rules := resolver.resolutionRules()
+
+	ruleNames := make([]string, len(rules))
+	for i, rule := range rules {
+		ruleNames[i] = rule.String()
+	}
+
+	fmt.Fprintf(w, "resolution rules, in order: %s\n", strings.Join(ruleNames, " "))
+
+	for _, path := range paths {
+		fmt.Fprintf(w, "\n%s:\n", path)
+
+		resolvePath := path
+
+		if output, ok := resolver.generatorOutput(path); ok {
+			fmt.Fprintf(w, "  generator template for %q\n", output)
+			resolvePath = output
+		}
+
+		templates, ok := resolver.templatesFor(resolvePath)
+		if !ok {
+			allMatched = false
+			fmt.Fprintf(w, "  => exempt by %s; the file won't be checked\n", dirConfigFilename)
+			continue
+		}
+
+		if rule, ok := resolver.configRule(resolvePath); ok {
+			if rule.Template == noTemplate {
+				allMatched = false
+				fmt.Fprintf(w, "  => rule %q in %s says the file has no template; it won't be checked\n", rule.Pattern, rule.source)
+				continue
+			}
+
+			if _, ok := templates[rule.Template]; !ok {
+				allMatched = false
+				fmt.Fprintf(w, "  => rule %q in %s refers to unknown template %q\n", rule.Pattern, rule.source, rule.Template)
+				continue
+			}
+
+			fmt.Fprintf(w, "  => template %q from rule %q in %s\n", rule.Template, rule.Pattern, rule.source)
+			continue
+		}
+
+		name, steps, ok := templates.Resolve(resolvePath, rules)
+
+		for _, step := range steps {
+			outcome := "no template"
+			if step.Matched {
+				outcome = "matched"
+			}
+
+			fmt.Fprintf(w, "  %s: %q: %s\n", step.Rule, step.Candidate, outcome)
+		}
+
+		if !ok {
+			allMatched = false
+			fmt.Fprintf(w, "  => no template; the file won't be checked\n")
+			continue
+		}
+
+		fmt.Fprintf(w, "  => template %q\n", name)
+	}
+
+	return allMatched
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+const (
+	trailingNewlinePreserve = "preserve"
+	trailingNewlineAdd      = "add"
+)
+
+// runFix adds boilerplate to the selected files which don't already have it
+func runFix(args []string) {
+	flags := flag.NewFlagSet("fix", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "fix [flags] <path-to-fix>", "Adds boilerplate to files which are missing it. Files with existing boilerplate which doesn't match the template must be fixed manually.")
+
+	global := addGlobalFlags(flags)
+	selection := addTargetFlags(flags)
+
+	year := flags.Int("year", time.Now().Year(), "The year substituted for the <<YEAR>> marker in added boilerplate")
+	trailingNewline := flags.String("trailing-newline", trailingNewlinePreserve, "Whether files which don't end with a newline are given one; either \"preserve\" (leave the end of each file as it was) or \"add\"")
+	normalizeCopyright := flags.Bool("normalize-copyright", false, "If set, copyright symbols are removed from existing valid boilerplate, e.g. changing \"Copyright (c) 2024\" or \"Copyright © 2024\" to \"Copyright 2024\"")
+	fixHeredocsFlag := flags.Bool("check-heredocs", false, "If set, also fixes boilerplate inside heredocs in shell scripts which write to files with a template, e.g. \"cat <<EOF > something.go\". Missing headers are added, and hard-coded years other than --year are replaced with $(date +%Y), or with --year if the heredoc's delimiter is quoted")
+	skipNonUTF8 := flags.Bool("skip-non-utf8", false, "If set, files which aren't valid UTF-8 (or UTF-16 with a byte order mark) are skipped with a warning rather than reported as failures. Such files are never changed either way")
+
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	logger := env.logger
+
+	selection.checkArgs(flags, logger, "boilersuite fix")
+
+	if *trailingNewline != trailingNewlinePreserve && *trailingNewline != trailingNewlineAdd {
+		fatal(logger, fmt.Sprintf("unknown --trailing-newline %q; must be %q or %q", *trailingNewline, trailingNewlinePreserve, trailingNewlineAdd))
+	}
+
+	targets := selection.find(flags, env).targets
+
+	opts := boilersuite.FixOptions{
+		Year:               *year,
+		IncludeGenerated:   global.checkGenerated,
+		AddTrailingNewline: *trailingNewline == trailingNewlineAdd,
+		NormalizeCopyright: *normalizeCopyright,
+	}
+
+	_, fixErrors := rewriteTargets(env, targets, rewriteOptions{stdin: selection.stdin, skipNonUTF8: *skipNonUTF8, message: "fixed file"}, func(t target, text string) (string, bool, error) {
+		fixed, err := t.tmpl.Fix(text, opts)
+		if err == nil && *fixHeredocsFlag && isShellScript(t.path) {
+			fixed = fixHeredocs(fixed, env.resolver, opts.Year)
+		}
+
+		return fixed, fixed != text, err
+	})
+
+	// with --stdin, stdout holds the fixed file so errors mustn't be mixed in with it
+	results := os.Stdout
+	if selection.stdin {
+		results = os.Stderr
+	}
+
+	printValidationErrors(results, env.palette(results), fixErrors, noDeviations)
+
+	env.exitIfCancelled()
+
+	if len(fixErrors) == 0 {
+		return
+	}
+
+	fatal(env.logger, "at least one file couldn't be fixed", "failed", len(fixErrors))
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_runFixDefaultTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"x.json":         "{\"a\": 1}\n",
+		"LICENSE":        "Permission is hereby granted, free of charge, to any person obtaining a copy\n",
+		"data.bin":       "\x1f\x8b\x08\x08compressed\x80",
+		"docs/notes.nim": "echo \"allowlisted\"\n",
+		"main.nim":       "echo \"hello\"\n",
+	}
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runFix([]string{"--default-comment-prefix", "#", "--unknown-allowlist", "docs/**", "--year", "2026", dir})
+
+	for name, contents := range files {
+		fixed, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if name == "main.nim" {
+			if !strings.HasPrefix(string(fixed), "# Copyright 2026 The cert-manager Authors.\n") {
+				t.Errorf("expected %s to be given a header, got %q", name, fixed)
+			}
+
+			continue
+		}
+
+		if string(fixed) != contents {
+			t.Errorf("expected %s to be left untouched, got %q", name, fixed)
+		}
+	}
+}
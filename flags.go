@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"strings"
+)
+
+// stringListFlag is a flag which can be given multiple times, collecting every value. It's used
+// for values which can contain spaces, such as regular expressions.
+type stringListFlag []string
+
+func (f *stringListFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringListFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// commaListFlag is a flag which can be given multiple times, each time with one value or a
+// comma-separated list of them. Values given on the command line replace the defaults.
+type commaListFlag struct {
+	values []string
+	set    bool
+}
+
+func newCommaListFlag(defaults ...string) *commaListFlag {
+	return &commaListFlag{values: defaults}
+}
+
+func (f *commaListFlag) String() string {
+	return strings.Join(f.values, ",")
+}
+
+func (f *commaListFlag) Set(value string) error {
+	if !f.set {
+		f.values = nil
+		f.set = true
+	}
+
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return errors.New("values can't be empty")
+		}
+
+		f.values = append(f.values, v)
+	}
+
+	return nil
+}
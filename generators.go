@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// checkGeneratorYears compares the copyright year of every generator template in targets against
+// the year in the file it generates. A mismatch means that regenerating the file would change its
+// year, which usually means that either the template or the generated file has a stale header.
+func checkGeneratorYears(targets []target, resolver templateResolver, logger *slog.Logger) []error {
+	var yearErrors []error
+
+	for _, t := range targets {
+		output, ok := resolver.generatorOutput(t.path)
+		if !ok {
+			continue
+		}
+
+		outputContents, err := os.ReadFile(output)
+		if errors.Is(err, fs.ErrNotExist) {
+			logger.Debug("not comparing years as the generated file doesn't exist", "path", t.path, "output", output)
+			continue
+		} else if err != nil {
+			yearErrors = append(yearErrors, fmt.Errorf("failed to read generated file %q: %w", output, err))
+			continue
+		}
+
+		outputText, _, err := boilersuite.DecodeText(outputContents)
+		if err != nil {
+			yearErrors = append(yearErrors, fmt.Errorf("failed to decode generated file %q: %w", output, err))
+			continue
+		}
+
+		inputContents, err := t.readText()
+		if err != nil {
+			yearErrors = append(yearErrors, fmt.Errorf("failed to read %q: %w", t.path, err))
+			continue
+		}
+
+		inputYear, inputOK := boilersuite.ExtractYear(inputContents)
+		outputYear, outputOK := boilersuite.ExtractYear(outputText)
+
+		if !inputOK || !outputOK || inputYear == outputYear {
+			// if either file lacks a year, validation will already have reported it
+			continue
+		}
+
+		yearErrors = append(yearErrors, fmt.Errorf("inconsistent boilerplate in %q: copyright year is %s but the file generated from it, %q, has %s; regenerating would change the generated file's header", t.path, inputYear, output, outputYear))
+	}
+
+	return yearErrors
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_checkGeneratorYears(t *testing.T) {
+	resolver := templateResolver{generatorSuffixes: []string{".gotmpl"}}
+
+	tests := map[string]struct {
+		inputYear  string
+		outputYear string
+		noOutput   bool
+		expectErr  bool
+	}{
+		"matching years": {
+			inputYear:  "2024",
+			outputYear: "2024",
+		},
+		"stale generated file": {
+			inputYear:  "2025",
+			outputYear: "2024",
+			expectErr:  true,
+		},
+		"generated file bumped by hand": {
+			inputYear:  "2024",
+			outputYear: "2025",
+			expectErr:  true,
+		},
+		"no generated file": {
+			inputYear: "2024",
+			noOutput:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			input := filepath.Join(dir, "crds.go.gotmpl")
+			output := filepath.Join(dir, "crds.go")
+
+			if err := os.WriteFile(input, []byte("// Copyright "+test.inputYear+" The cert-manager Authors.\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if !test.noOutput {
+				if err := os.WriteFile(output, []byte("// Copyright "+test.outputYear+" The cert-manager Authors.\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			targets := []target{{path: input}, {path: output}}
+
+			errs := checkGeneratorYears(targets, resolver, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if (len(errs) > 0) != test.expectErr {
+				t.Errorf("errs=%v, expectErr=%v", errs, test.expectErr)
+			}
+		})
+	}
+}
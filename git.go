@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// runGit runs git with the given arguments in the given directory and returns its trimmed stdout
+func runGit(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// gitCommitFiles stages and commits the files at the given paths, which are relative to the working
+// directory, in the git repository containing dir
+func gitCommitFiles(dir string, paths []string, message string) error {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	// git resolves paths relative to the directory it's run in rather than the working directory
+	relPaths := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(absDir, absPath)
+		if err != nil {
+			return err
+		}
+
+		relPaths = append(relPaths, relPath)
+	}
+
+	if _, err := runGit(dir, append([]string{"add", "--"}, relPaths...)...); err != nil {
+		return fmt.Errorf("failed to stage files: %w", err)
+	}
+
+	if _, err := runGit(dir, append([]string{"commit", "--quiet", "--message", message, "--"}, relPaths...)...); err != nil {
+		return fmt.Errorf("failed to commit files: %w", err)
+	}
+
+	return nil
+}
+
+// gitHeadCommit returns the commit checked out in the git repository containing dir
+func gitHeadCommit(dir string) (string, error) {
+	return runGit(dir, "rev-parse", "HEAD")
+}
+
+// gitChangedFiles returns the paths of files in the git repository containing dir which were added, copied, modified
+// or renamed relative to the merge base of the given ref and HEAD, including uncommitted and untracked files.
+// Returned paths are absolute.
+func gitChangedFiles(dir string, ref string) ([]string, error) {
+	toplevel, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+
+	diffed, err := runGit(toplevel, "diff", "--name-only", "-z", "--diff-filter=ACMR", "--merge-base", ref)
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := runGit(toplevel, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+
+	for _, path := range strings.Split(diffed+"\x00"+untracked, "\x00") {
+		if path == "" {
+			continue
+		}
+
+		changed = append(changed, filepath.Join(toplevel, path))
+	}
+
+	return changed, nil
+}
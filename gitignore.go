@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// gitIgnoredPaths returns the subset of the given paths which are ignored by git in the repository
+// containing dir.
+// Matching is delegated to "git check-ignore" so that .gitignore files, $GIT_DIR/info/exclude and
+// the user's core.excludesFile are all honoured exactly as git would, including the rule that files
+// which are tracked are never ignored.
+func gitIgnoredPaths(dir string, paths []string) (map[string]struct{}, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// paths are passed relative to dir so that symlinks in the path to the repository don't cause
+	// git to consider them to be outside of it
+	relToPath := make(map[string]string, len(paths))
+
+	var stdin bytes.Buffer
+
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+
+		rel, err := filepath.Rel(absDir, absPath)
+		if err != nil {
+			return nil, err
+		}
+
+		relToPath[rel] = path
+
+		stdin.WriteString(rel)
+		stdin.WriteByte(0)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("git", "-C", absDir, "check-ignore", "-z", "--stdin")
+	cmd.Stdin = &stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// check-ignore exits with 1 when none of the paths are ignored
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			return nil, fmt.Errorf("git check-ignore: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	ignored := make(map[string]struct{})
+
+	for _, rel := range strings.Split(stdout.String(), "\x00") {
+		if rel == "" {
+			continue
+		}
+
+		if path, ok := relToPath[rel]; ok {
+			ignored[path] = struct{}{}
+		}
+	}
+
+	return ignored, nil
+}
+
+// filterGitIgnoredPaths removes paths which are ignored by git, in the same way as filterGitIgnored
+func filterGitIgnoredPaths(dir string, paths []string, logger *slog.Logger) ([]string, error) {
+	targets := make([]target, len(paths))
+	for i, path := range paths {
+		targets[i] = target{path: path}
+	}
+
+	targets, err := filterGitIgnored(dir, targets, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]string, len(targets))
+	for i, t := range targets {
+		filtered[i] = t.path
+	}
+
+	return filtered, nil
+}
+
+// filterGitIgnored removes targets which are ignored by git. If dir isn't inside a git work tree,
+// targets are returned unchanged.
+func filterGitIgnored(dir string, targets []target, logger *slog.Logger) ([]target, error) {
+	if len(targets) == 0 {
+		return targets, nil
+	}
+
+	if _, err := runGit(dir, "rev-parse", "--is-inside-work-tree"); err != nil {
+		logger.Debug("not in a git work tree, so gitignore rules won't be applied", "dir", dir)
+		return targets, nil
+	}
+
+	paths := make([]string, len(targets))
+	for i, t := range targets {
+		paths[i] = t.path
+	}
+
+	ignored, err := gitIgnoredPaths(dir, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]target, 0, len(targets))
+
+	for _, t := range targets {
+		if _, ok := ignored[t.path]; ok {
+			logger.Debug("skipping file because it's ignored by git", "path", t.path)
+			continue
+		}
+
+		filtered = append(filtered, t)
+	}
+
+	return filtered, nil
+}
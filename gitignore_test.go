@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func Test_gitIgnoredPaths(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+
+	repo := t.TempDir()
+	globalExcludes := filepath.Join(t.TempDir(), "excludes")
+
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	mustRunGit := func(args ...string) {
+		t.Helper()
+
+		if _, err := runGit(repo, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mustWrite := func(path string, contents string) {
+		t.Helper()
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mustRunGit("init", "-q")
+	mustRunGit("config", "--global", "core.excludesFile", globalExcludes)
+
+	mustWrite(filepath.Join(repo, ".gitignore"), "*.gitignored\n")
+	mustWrite(filepath.Join(repo, ".git", "info", "exclude"), "*.excluded\n")
+	mustWrite(globalExcludes, "*.global\n")
+
+	files := []string{"a.go", "b.gitignored", "c.excluded", "d.global", "tracked.gitignored"}
+	for _, name := range files {
+		mustWrite(filepath.Join(repo, name), "")
+	}
+
+	mustRunGit("add", "--force", "tracked.gitignored")
+
+	var paths []string
+	for _, name := range files {
+		paths = append(paths, filepath.Join(repo, name))
+	}
+
+	ignored, err := gitIgnoredPaths(repo, paths)
+	if err != nil {
+		t.Fatalf("failed to check ignored paths: %s", err)
+	}
+
+	var got []string
+	for path := range ignored {
+		got = append(got, filepath.Base(path))
+	}
+
+	sort.Strings(got)
+
+	expected := []string{"b.gitignored", "c.excluded", "d.global"}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, wanted %v", got, expected)
+	}
+}
@@ -28,7 +28,7 @@ logsfile=$(mktemp)
 
 trap 'rm -f -- $logsfile' EXIT
 
-$BOILERSUITE $FIXTURE_PATH &>$logsfile && exitcode=$? || exitcode=$?
+$BOILERSUITE check $FIXTURE_PATH &>$logsfile && exitcode=$? || exitcode=$?
 
 if [[ $exitcode -eq 0 ]]; then
 	echo "ERROR: expected boilersuite to fail but got a successful exit code"
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// isShellScript returns true for files which can contain heredocs
+func isShellScript(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".sh" || ext == ".bash"
+}
+
+// checkHeredocs validates the boilerplate embedded in heredocs in the given shell script which
+// write files that have a template. Since these headers are stamped into every generated file,
+// a hard-coded year other than the current year is reported as stale.
+func checkHeredocs(path string, contents string, resolver templateResolver, currentYear int) []error {
+	var heredocErrors []error
+
+	for _, heredoc := range boilersuite.FindHeredocs(contents) {
+		tmpl, ok := resolver.templateFor(heredoc.Target)
+		if !ok {
+			continue
+		}
+
+		location := fmt.Sprintf("heredoc at %s:%d writing %q", path, heredoc.Line, heredoc.Target)
+
+		body := heredoc.Body
+
+		if boilersuite.ShellYearRegex.MatchString(body) {
+			if heredoc.QuotedDelimiter {
+				heredocErrors = append(heredocErrors, fmt.Errorf("invalid boilerplate in %s: the copyright year is computed by the shell but won't be expanded because the heredoc delimiter is quoted", location))
+				continue
+			}
+
+			// the year will be filled in when the script runs, so it can't be stale
+			body = boilersuite.ShellYearRegex.ReplaceAllString(body, "Copyright "+strconv.Itoa(currentYear))
+		}
+
+		if err := tmpl.Validate(body); err != nil {
+			heredocErrors = append(heredocErrors, fmt.Errorf("invalid boilerplate in %s: %w", location, err))
+			continue
+		}
+
+		year, ok := boilersuite.ExtractYear(body)
+		if ok && year != strconv.Itoa(currentYear) {
+			heredocErrors = append(heredocErrors, fmt.Errorf("stale boilerplate in %s: the copyright year %s is hard-coded, so every generated file will carry it; use $(date +%%Y) instead", location, year))
+		}
+	}
+
+	return heredocErrors
+}
+
+// fixHeredocs adds boilerplate to heredocs in the given shell script which write files that have a
+// template, and replaces hard-coded years other than the current year. In heredocs with an unquoted
+// delimiter, the year of any header which is changed becomes $(date +%Y) so that it can't go stale
+// again. Headers which don't match their template are left for checkHeredocs to report.
+func fixHeredocs(contents string, resolver templateResolver, currentYear int) string {
+	heredocs := boilersuite.FindHeredocs(contents)
+
+	// heredocs are replaced from the end of the script so that earlier ones don't move
+	for i := len(heredocs) - 1; i >= 0; i-- {
+		heredoc := heredocs[i]
+
+		tmpl, ok := resolver.templateFor(heredoc.Target)
+		if !ok || boilersuite.ShellYearRegex.MatchString(heredoc.Body) {
+			continue
+		}
+
+		fixed, err := tmpl.Fix(heredoc.Body, boilersuite.FixOptions{Year: currentYear})
+		if err != nil {
+			continue
+		}
+
+		if year, ok := boilersuite.ExtractYear(fixed); ok && (fixed != heredoc.Body || year != strconv.Itoa(currentYear)) {
+			replacement := strconv.Itoa(currentYear)
+			if !heredoc.QuotedDelimiter {
+				replacement = "$(date +%Y)"
+			}
+
+			fixed = strings.Replace(fixed, "Copyright "+year, "Copyright "+replacement, 1)
+		}
+
+		if fixed != heredoc.Body {
+			contents = boilersuite.ReplaceHeredocBody(contents, heredoc, fixed)
+		}
+	}
+
+	return contents
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+const heredocGoHeader = `/*
+Copyright YEAR The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generated
+`
+
+func Test_checkHeredocs(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	resolver := templateResolver{templates: templates}
+
+	tests := map[string]struct {
+		delimiter string
+		year      string
+		errorLike string
+	}{
+		"current year":               {delimiter: "EOF", year: "2026"},
+		"computed year":              {delimiter: "EOF", year: "$(date +%Y)"},
+		"stale year":                 {delimiter: "EOF", year: "2019", errorLike: "stale boilerplate"},
+		"computed year in quoted":    {delimiter: "'EOF'", year: "$(date +%Y)", errorLike: "won't be expanded"},
+		"literal year in quoted":     {delimiter: "'EOF'", year: "2026"},
+		"missing header":             {delimiter: "EOF", year: "", errorLike: "invalid boilerplate"},
+		"computed year from a shell": {delimiter: "EOF", year: "${YEAR}"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			body := strings.Replace(heredocGoHeader, "YEAR", test.year, 1)
+			if test.year == "" {
+				body = "package generated\n"
+			}
+
+			script := "#!/usr/bin/env bash\n\ncat <<" + test.delimiter + " > out/generated.go\n" + body + "EOF\n"
+
+			errs := checkHeredocs("gen.sh", script, resolver, 2026)
+
+			if test.errorLike == "" {
+				if len(errs) != 0 {
+					t.Errorf("expected no errors but got %v", errs)
+				}
+
+				return
+			}
+
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), test.errorLike) {
+				t.Errorf("expected one error like %q but got %v", test.errorLike, errs)
+			}
+		})
+	}
+}
+
+func Test_fixHeredocs(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	resolver := templateResolver{templates: templates}
+
+	script := func(delimiter string, body string) string {
+		return "#!/usr/bin/env bash\n\ncat <<" + delimiter + " > out/generated.go\n" + body + "EOF\n"
+	}
+
+	header := func(year string) string {
+		return strings.Replace(heredocGoHeader, "YEAR", year, 1)
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected string
+	}{
+		"stale year": {
+			input:    script("EOF", header("2019")),
+			expected: script("EOF", header("$(date +%Y)")),
+		},
+		"stale year in quoted": {
+			input:    script("'EOF'", header("2019")),
+			expected: script("'EOF'", header("2026")),
+		},
+		"missing header": {
+			input:    script("EOF", "package generated\n"),
+			expected: script("EOF", header("$(date +%Y)")),
+		},
+		"current year": {
+			input:    script("EOF", header("2026")),
+			expected: script("EOF", header("2026")),
+		},
+		"computed year": {
+			input:    script("EOF", header("$(date +%Y)")),
+			expected: script("EOF", header("$(date +%Y)")),
+		},
+		"header for another author": {
+			input:    script("EOF", strings.Replace(header("2019"), "cert-manager", "example", 1)),
+			expected: script("EOF", strings.Replace(header("2019"), "cert-manager", "example", 1)),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixed := fixHeredocs(test.input, resolver, 2026)
+			if fixed != test.expected {
+				t.Fatalf("got:\n%s\nwanted:\n%s", fixed, test.expected)
+			}
+
+			if fixed != test.input {
+				if errs := checkHeredocs("gen.sh", fixed, resolver, 2026); len(errs) != 0 {
+					t.Errorf("expected fixed script to pass, got %v", errs)
+				}
+			}
+		})
+	}
+}
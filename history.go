@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// runSummary holds the outcome of a single run, as recorded in the history file
+type runSummary struct {
+	Timestamp time.Time `json:"timestamp"`
+	Commit    string    `json:"commit"`
+	Target    string    `json:"target"`
+	Checked   int       `json:"checked"`
+	Failed    int       `json:"failed"`
+
+	// Sample and Seed are set if only a sample of the files was checked
+	Sample string `json:"sample,omitempty"`
+	Seed   int64  `json:"seed,omitempty"`
+}
+
+var historyCSVHeader = []string{"timestamp", "commit", "target", "checked", "failed", "sample", "seed"}
+
+func (s runSummary) csvRecord() []string {
+	seed := ""
+	if s.Sample != "" {
+		seed = strconv.FormatInt(s.Seed, 10)
+	}
+
+	return []string{
+		s.Timestamp.Format(time.RFC3339),
+		s.Commit,
+		s.Target,
+		strconv.Itoa(s.Checked),
+		strconv.Itoa(s.Failed),
+		s.Sample,
+		seed,
+	}
+}
+
+// appendHistory appends the given summary to the history file at path, creating it if needed.
+// Files with a ".csv" extension are written as CSV; anything else is written as JSON lines.
+func appendHistory(path string, summary runSummary) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	if !strings.EqualFold(filepath.Ext(path), ".csv") {
+		return json.NewEncoder(f).Encode(summary)
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(f)
+
+	if stat.Size() == 0 {
+		if err := w.Write(historyCSVHeader); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Write(summary.csvRecord()); err != nil {
+		return err
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return nil
+}
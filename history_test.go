@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_appendHistory(t *testing.T) {
+	summary := runSummary{
+		Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Commit:    "abc123",
+		Target:    ".",
+		Checked:   10,
+		Failed:    2,
+	}
+
+	sampled := summary
+	sampled.Sample = "5%"
+	sampled.Seed = 42
+
+	tests := map[string]struct {
+		filename string
+		summary  runSummary
+		expected string
+	}{
+		"csv": {
+			filename: "history.csv",
+			summary:  summary,
+			expected: "timestamp,commit,target,checked,failed,sample,seed\n" +
+				"2026-01-02T03:04:05Z,abc123,.,10,2,,\n" +
+				"2026-01-02T03:04:05Z,abc123,.,10,2,,\n",
+		},
+		"jsonl": {
+			filename: "history.jsonl",
+			summary:  summary,
+			expected: `{"timestamp":"2026-01-02T03:04:05Z","commit":"abc123","target":".","checked":10,"failed":2}` + "\n" +
+				`{"timestamp":"2026-01-02T03:04:05Z","commit":"abc123","target":".","checked":10,"failed":2}` + "\n",
+		},
+		"sampled csv": {
+			filename: "history.csv",
+			summary:  sampled,
+			expected: "timestamp,commit,target,checked,failed,sample,seed\n" +
+				"2026-01-02T03:04:05Z,abc123,.,10,2,5%,42\n" +
+				"2026-01-02T03:04:05Z,abc123,.,10,2,5%,42\n",
+		},
+		"sampled jsonl": {
+			filename: "history.jsonl",
+			summary:  sampled,
+			expected: `{"timestamp":"2026-01-02T03:04:05Z","commit":"abc123","target":".","checked":10,"failed":2,"sample":"5%","seed":42}` + "\n" +
+				`{"timestamp":"2026-01-02T03:04:05Z","commit":"abc123","target":".","checked":10,"failed":2,"sample":"5%","seed":42}` + "\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), test.filename)
+
+			for i := 0; i < 2; i++ {
+				if err := appendHistory(path, test.summary); err != nil {
+					t.Fatalf("failed to append history: %s", err)
+				}
+			}
+
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read history: %s", err)
+			}
+
+			if string(contents) != test.expected {
+				t.Errorf("got:\n%s\nwanted:\n%s", contents, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// incrementalState records the modification times of directories as of the last successful run, so
+// that files in directories which haven't changed since can be skipped.
+//
+// A directory's modification time changes when entries are added, removed or renamed in it, but not
+// when an existing file is edited in place. Periodic full scans catch any such edits.
+type incrementalState struct {
+	// Fingerprint identifies the settings used for the run; if they change, a full scan is needed
+	Fingerprint string `json:"fingerprint"`
+
+	RunsSinceFullScan int `json:"runsSinceFullScan"`
+
+	Dirs map[string]int64 `json:"dirs"`
+
+	path      string
+	fullScan  bool
+	observed  map[string]int64
+	unchanged map[string]struct{}
+}
+
+// loadIncrementalState reads the state at path, if any, and determines whether this run needs
+// to be a full scan
+func loadIncrementalState(path string, fingerprint string, fullScanEvery int) (*incrementalState, error) {
+	state := &incrementalState{
+		path:      path,
+		observed:  make(map[string]int64),
+		unchanged: make(map[string]struct{}),
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if err == nil {
+		if err := json.Unmarshal(contents, state); err != nil {
+			return nil, err
+		}
+	}
+
+	state.fullScan = state.Fingerprint != fingerprint || len(state.Dirs) == 0 || state.RunsSinceFullScan+1 >= fullScanEvery
+	state.Fingerprint = fingerprint
+
+	return state, nil
+}
+
+// observeDir records the modification time of the given directory, and returns true if the
+// directory is unchanged since the last successful run
+func (s *incrementalState) observeDir(path string, d fs.DirEntry) (bool, error) {
+	info, err := d.Info()
+	if err != nil {
+		return false, err
+	}
+
+	mtime := info.ModTime().UnixNano()
+	s.observed[path] = mtime
+
+	if s.fullScan {
+		return false, nil
+	}
+
+	previous, ok := s.Dirs[path]
+	if !ok || previous != mtime {
+		return false, nil
+	}
+
+	s.unchanged[path] = struct{}{}
+
+	return true, nil
+}
+
+// isUnchanged returns true if the given directory was unchanged since the last successful run
+func (s *incrementalState) isUnchanged(dir string) bool {
+	_, ok := s.unchanged[dir]
+	return ok
+}
+
+// save records the directories observed in this run, which should only be called after a
+// successful run
+func (s *incrementalState) save() error {
+	if s.fullScan {
+		s.RunsSinceFullScan = 0
+	} else {
+		s.RunsSinceFullScan++
+	}
+
+	s.Dirs = s.observed
+
+	contents, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, contents, 0o644)
+}
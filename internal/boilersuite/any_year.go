@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"strconv"
+	"strings"
+)
+
+// earliestPlausibleYear is the earliest copyright year accepted by templates which accept any year
+const earliestPlausibleYear = 1970
+
+// WithAnyYear returns a copy of the template, and of each of its alternatives, which accepts any
+// plausible copyright year or range of years, e.g. "1999", "2019-present" or "2019, 2021". Years
+// are plausible if they're no earlier than 1970 and not in the future.
+func (t BoilerplateTemplate) WithAnyYear() BoilerplateTemplate {
+	t.anyYear = true
+
+	alternatives := make([]BoilerplateTemplate, len(t.alternatives))
+	for i, alternative := range t.alternatives {
+		alternatives[i] = alternative.WithAnyYear()
+	}
+
+	t.alternatives = alternatives
+
+	return t
+}
+
+// WithAnyYear returns a copy of the map in which every template accepts any plausible copyright
+// year or range of years
+func (tm TemplateMap) WithAnyYear() TemplateMap {
+	out := make(TemplateMap, len(tm))
+
+	for name, tmpl := range tm {
+		out[name] = tmpl.WithAnyYear()
+	}
+
+	return out
+}
+
+// normalizeYears replaces each copyright year in s with the year marker. Unless the template
+// accepts any year, only years matched by DateRegex are replaced.
+func (t BoilerplateTemplate) normalizeYears(s string) string {
+	if !t.anyYear {
+		return DateRegex.ReplaceAllString(s, "Copyright "+YearMarkerRegex.String())
+	}
+
+	return AnyYearRegex.ReplaceAllStringFunc(s, func(match string) string {
+		if !plausibleYears(match) {
+			return match
+		}
+
+		return "Copyright " + YearMarkerRegex.String()
+	})
+}
+
+// plausibleYears returns true if every year in the given copyright line is no earlier than
+// earliestPlausibleYear and not in the future
+func plausibleYears(match string) bool {
+	isNotDigit := func(r rune) bool { return r < '0' || r > '9' }
+
+	for _, field := range strings.FieldsFunc(match, isNotDigit) {
+		year, err := strconv.Atoi(field)
+		if err != nil || year < earliestPlausibleYear || year > now().Year() {
+			return false
+		}
+	}
+
+	return true
+}
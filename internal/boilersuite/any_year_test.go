@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_AnyYear(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+
+	now = func() time.Time {
+		return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	const rest = " The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n"
+
+	tests := map[string]struct {
+		years           string
+		expectErr       bool
+		expectAnyYearOK bool
+	}{
+		"single year": {
+			years:           "2019",
+			expectAnyYearOK: true,
+		},
+		"range": {
+			years:           "2019-2026",
+			expectAnyYearOK: true,
+		},
+		"twentieth century": {
+			years:           "1999",
+			expectErr:       true,
+			expectAnyYearOK: true,
+		},
+		"open range": {
+			years:           "2019 - present",
+			expectErr:       true,
+			expectAnyYearOK: true,
+		},
+		"list of years": {
+			years:           "2019, 2021-2024",
+			expectErr:       true,
+			expectAnyYearOK: true,
+		},
+		"future year": {
+			years:     "2030",
+			expectErr: false,
+		},
+		"implausibly early year": {
+			years:     "1950",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := "# Copyright " + test.years + rest
+
+			if err := mustTestTemplate(t).Validate(input); (err != nil) != test.expectErr {
+				t.Errorf("without --any-year: err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if err := mustTestTemplate(t).WithAnyYear().Validate(input); (err == nil) != test.expectAnyYearOK {
+				t.Errorf("with --any-year: err=%v, expectAnyYearOK=%v", err, test.expectAnyYearOK)
+			}
+		})
+	}
+}
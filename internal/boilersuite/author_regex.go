@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WithAuthorRegex returns a copy of the template which accepts any author matching the given
+// regular expression in place of the <<AUTHOR>> marker, rather than only the expected author. The
+// expected author is still used when adding boilerplate to files.
+func (t BoilerplateTemplate) WithAuthorRegex(authorRegex *regexp.Regexp) (BoilerplateTemplate, error) {
+	templateLines := strings.Split(t.raw, "\n")
+	authorLines := make(map[int]*regexp.Regexp)
+
+	for i, line := range templateLines {
+		if !AuthorMarkerRegex.MatchString(line) {
+			continue
+		}
+
+		if t.kind == TemplateKindLine {
+			line = strings.TrimSpace(line)
+		}
+
+		parts := AuthorMarkerRegex.Split(line, -1)
+		for j, part := range parts {
+			parts[j] = regexp.QuoteMeta(part)
+		}
+
+		re, err := regexp.Compile("^" + strings.Join(parts, "(?:"+authorRegex.String()+")") + "$")
+		if err != nil {
+			return BoilerplateTemplate{}, fmt.Errorf("invalid author regex %q: %w", authorRegex, err)
+		}
+
+		authorLines[i] = re
+	}
+
+	t.authorRegex = authorRegex
+	t.authorLines = authorLines
+
+	return t, nil
+}
+
+// WithAuthorRegex returns a copy of the map in which every template accepts any author matching
+// the given regular expression
+func (tm TemplateMap) WithAuthorRegex(authorRegex *regexp.Regexp) (TemplateMap, error) {
+	out := make(TemplateMap, len(tm))
+
+	for name, tmpl := range tm {
+		withRegex, err := tmpl.WithAuthorRegex(authorRegex)
+		if err != nil {
+			return nil, err
+		}
+
+		out[name] = withRegex
+	}
+
+	return out, nil
+}
+
+// normalizeAuthor replaces each line of the normalized contents which names an author matching the
+// template's author regex with the same line naming the expected author, so that it matches the
+// template. Lines are matched against the template line in the same position.
+func (t BoilerplateTemplate) normalizeAuthor(contents string) string {
+	if len(t.authorLines) == 0 {
+		return contents
+	}
+
+	lines := strings.Split(contents, "\n")
+	replacedLines := strings.Split(t.replaced, "\n")
+
+	for i, re := range t.authorLines {
+		if i < len(lines) && i < len(replacedLines) && re.MatchString(lines[i]) {
+			lines[i] = replacedLines[i]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// matchesAuthorLine returns true if the trimmed line of a file matches the template's one-line
+// boilerplate with any author which matches the template's author regex
+func (t BoilerplateTemplate) matchesAuthorLine(line string) bool {
+	for _, re := range t.authorLines {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"regexp"
+	"testing"
+)
+
+func Test_AuthorRegex(t *testing.T) {
+	tests := map[string]struct {
+		template    string
+		authorRegex string
+		input       string
+		expectErr   bool
+	}{
+		"block template with the expected author": {
+			template:    testTemplate,
+			authorRegex: "cert-manager|Jetstack",
+			input:       "# Copyright 2020 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+		},
+		"block template with another matching author": {
+			template:    testTemplate,
+			authorRegex: "cert-manager|Jetstack",
+			input:       "# Copyright 2020 The Jetstack Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+		},
+		"block template with an author which doesn't match": {
+			template:    testTemplate,
+			authorRegex: "cert-manager|Jetstack",
+			input:       "# Copyright 2020 The Kubernetes Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+			expectErr:   true,
+		},
+		"template whose author is the whole holder": {
+			template:    "# Copyright <<YEAR>> <<AUTHOR>>\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\n",
+			authorRegex: "The .* Authors",
+			input:       "# Copyright 2020 The Kubernetes Authors\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+		},
+		"line template with another matching author": {
+			template:    "# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n",
+			authorRegex: "cert-manager|Jetstack",
+			input:       "[section]\n  # Copyright 2020 The Jetstack Authors.\n",
+		},
+		"line template with an author which doesn't match": {
+			template:    "# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n",
+			authorRegex: "cert-manager|Jetstack",
+			input:       "# Copyright 2020 The Kubernetes Authors.\n",
+			expectErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := NewBoilerplateTemplate(test.template, BoilerplateTemplateConfiguration{ExpectedAuthor: "cert-manager"})
+			if err != nil {
+				t.Fatalf("failed to create template: %s", err)
+			}
+
+			tmpl, err = tmpl.WithAuthorRegex(regexp.MustCompile(test.authorRegex))
+			if err != nil {
+				t.Fatalf("failed to add author regex: %s", err)
+			}
+
+			err = tmpl.Validate(test.input)
+			if (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+		})
+	}
+}
+
+func Test_AuthorRegexFixUsesExpectedAuthor(t *testing.T) {
+	tmpl, err := mustTestTemplate(t).WithAuthorRegex(regexp.MustCompile(".*"))
+	if err != nil {
+		t.Fatalf("failed to add author regex: %s", err)
+	}
+
+	fixed, err := tmpl.Fix("echo hello\n", FixOptions{Year: 2026})
+	if err != nil {
+		t.Fatalf("failed to fix file: %s", err)
+	}
+
+	expected := "# Copyright 2026 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n"
+	if fixed != expected {
+		t.Errorf("got %q, wanted %q", fixed, expected)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"strconv"
+	"strings"
+)
+
+// BumpYearOptions configures how BumpYear changes the year in boilerplate
+type BumpYearOptions struct {
+	// Year is the year which the boilerplate should carry
+	Year int
+
+	// Extend turns the existing year into a range ending in Year, e.g. "2019-2026", rather than
+	// replacing it with Year
+	Extend bool
+}
+
+// BumpYear returns raw with the copyright year in its boilerplate updated to opts.Year, or extended
+// to a range ending in it, along with whether anything changed. Only files whose boilerplate is
+// valid are changed; generated and skipped files, and files whose year is already opts.Year or
+// later, are returned unchanged.
+func (t BoilerplateTemplate) BumpYear(raw string, opts BumpYearOptions) (string, bool) {
+	if trimmed, ok := trimByteOrderMark(raw); ok {
+		bumped, changed := t.BumpYear(trimmed, opts)
+		return byteOrderMark + bumped, changed
+	}
+
+	loc, ok := t.headerYear(raw)
+	if !ok {
+		return raw, false
+	}
+
+	prefix, years := splitCopyrightYears(raw[loc[0]:loc[1]])
+
+	replacement, ok := bumpedYears(years, opts)
+	if !ok {
+		return raw, false
+	}
+
+	bumped := raw[:loc[0]] + prefix + replacement + raw[loc[1]:]
+
+	// the year may not have been the one in the boilerplate, e.g. in a preamble
+	if t.validateContents(bumped) != nil {
+		return raw, false
+	}
+
+	return bumped, true
+}
+
+// bumpedYears returns the years which replace the given year or range of years, or false if they
+// don't need changing
+func bumpedYears(years string, opts BumpYearOptions) (string, bool) {
+	first, last, _ := strings.Cut(years, "-")
+	if last == "" {
+		last = first
+	}
+
+	lastYear, err := strconv.Atoi(last)
+	if err != nil || lastYear >= opts.Year {
+		return "", false
+	}
+
+	target := strconv.Itoa(opts.Year)
+
+	if opts.Extend {
+		return first + "-" + target, true
+	}
+
+	return target, true
+}
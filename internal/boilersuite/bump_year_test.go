@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"testing"
+)
+
+func Test_BumpYear(t *testing.T) {
+	tmpl := mustTestTemplate(t)
+
+	const rest = " The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n"
+
+	tests := map[string]struct {
+		input         string
+		extend        bool
+		expected      string
+		expectChanged bool
+	}{
+		"replace year": {
+			input:         "#!/bin/sh\n\n# Copyright 2019" + rest,
+			expected:      "#!/bin/sh\n\n# Copyright 2026" + rest,
+			expectChanged: true,
+		},
+		"replace range": {
+			input:         "# Copyright 2019-2024" + rest,
+			expected:      "# Copyright 2026" + rest,
+			expectChanged: true,
+		},
+		"extend year": {
+			input:         "# Copyright 2019" + rest,
+			extend:        true,
+			expected:      "# Copyright 2019-2026" + rest,
+			expectChanged: true,
+		},
+		"extend range": {
+			input:         "\ufeff# Copyright 2019-2024" + rest,
+			extend:        true,
+			expected:      "\ufeff# Copyright 2019-2026" + rest,
+			expectChanged: true,
+		},
+		"keep copyright symbol": {
+			input:         "# Copyright (c) 2019" + rest,
+			extend:        true,
+			expected:      "# Copyright (c) 2019-2026" + rest,
+			expectChanged: true,
+		},
+		"already current": {
+			input:    "# Copyright 2019-2026" + rest,
+			extend:   true,
+			expected: "# Copyright 2019-2026" + rest,
+		},
+		"invalid boilerplate": {
+			input:    "# Copyright 2019 Someone Else.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+			expected: "# Copyright 2019 Someone Else.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+		},
+		"skipped file": {
+			input:    "# +skip_license_check\n# Copyright 2019 Someone Else.\n",
+			expected: "# +skip_license_check\n# Copyright 2019 Someone Else.\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, changed := tmpl.BumpYear(test.input, BumpYearOptions{Year: 2026, Extend: test.extend})
+
+			if got != test.expected {
+				t.Errorf("got %q, wanted %q", got, test.expected)
+			}
+
+			if changed != test.expectChanged {
+				t.Errorf("changed=%t, wanted %t", changed, test.expectChanged)
+			}
+
+			if err := tmpl.Validate(got); test.expectChanged && err != nil {
+				t.Errorf("expected updated file to be valid, got %s", err)
+			}
+		})
+	}
+}
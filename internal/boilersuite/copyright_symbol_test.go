@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"testing"
+)
+
+func Test_CopyrightSymbols(t *testing.T) {
+	const rest = " The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n"
+
+	tests := map[string]struct {
+		copyright string
+
+		expectYear       string
+		expectNormalized string
+	}{
+		"no symbol": {
+			copyright:        "Copyright 2024",
+			expectYear:       "2024",
+			expectNormalized: "Copyright 2024",
+		},
+		"lowercase c": {
+			copyright:        "Copyright (c) 2024",
+			expectYear:       "2024",
+			expectNormalized: "Copyright 2024",
+		},
+		"uppercase C": {
+			copyright:        "Copyright (C) 2019-2024",
+			expectYear:       "2019-2024",
+			expectNormalized: "Copyright 2019-2024",
+		},
+		"symbol": {
+			copyright:        "Copyright © 2024",
+			expectYear:       "2024",
+			expectNormalized: "Copyright 2024",
+		},
+	}
+
+	tmpl := mustTestTemplate(t)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := "# " + test.copyright + rest
+
+			if err := tmpl.Validate(input); err != nil {
+				t.Errorf("expected file to be valid, got %s", err)
+			}
+
+			if year, ok := ExtractYear(input); !ok || year != test.expectYear {
+				t.Errorf("got year %q (found=%t), wanted %q", year, ok, test.expectYear)
+			}
+
+			fixed, err := tmpl.Fix(input, FixOptions{Year: 2026, NormalizeCopyright: true})
+			if err != nil {
+				t.Fatalf("failed to fix file: %s", err)
+			}
+
+			if expected := "# " + test.expectNormalized + rest; fixed != expected {
+				t.Errorf("got %q, wanted %q", fixed, expected)
+			}
+
+			if unchanged, err := tmpl.Fix(input, FixOptions{Year: 2026}); err != nil || unchanged != input {
+				t.Errorf("expected file to be unchanged without NormalizeCopyright, got %q (err=%v)", unchanged, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// detectionLimit is the most bytes of a file which are read when guessing its language
+const detectionLimit = 4096
+
+// modelineSearchLines is the number of lines at the start of a file which are searched for an
+// editor modeline naming its language
+const modelineSearchLines = 5
+
+// minKeywordScore is the fewest lines which must look like a language for a file to be guessed
+// to be written in it
+const minKeywordScore = 2
+
+// editorModes maps the names which editors use for languages to the built-in template for that
+// language, where the two have different names
+var editorModes = map[string]string{
+	"python":       "py",
+	"shell-script": "sh",
+	"ruby":         "rb",
+	"javascript":   "js",
+	"typescript":   "ts",
+	"makefile":     "Makefile",
+	"make":         "Makefile",
+	"dockerfile":   "Dockerfile",
+	"markdown":     "md",
+}
+
+// magicPrefixes maps content which can only start a file in a particular language to the
+// template for that language
+var magicPrefixes = []struct {
+	prefix   string
+	template string
+}{
+	{prefix: "<?php", template: "php"},
+	{prefix: "<?xml", template: "xml"},
+	{prefix: "<!doctype html", template: "html"},
+	{prefix: "<html", template: "html"},
+	{prefix: "%yaml", template: "yaml"},
+}
+
+// languageKeywords holds patterns matching lines which are typical of each language, used for
+// scoring how likely it is that a file is written in that language
+var languageKeywords = map[string][]*regexp.Regexp{
+	"go": {
+		regexp.MustCompile(`^package \w+$`),
+		regexp.MustCompile(`^import \($`),
+		regexp.MustCompile(`^func `),
+	},
+	"py": {
+		regexp.MustCompile(`^def \w+\(`),
+		regexp.MustCompile(`^(?:import \w|from [\w.]+ import )`),
+		regexp.MustCompile(`^if __name__ == `),
+	},
+	"sh": {
+		regexp.MustCompile(`^set -[euxo]`),
+		regexp.MustCompile(`^(?:echo|export|source) `),
+		regexp.MustCompile(`^(?:fi|done|esac)$`),
+		regexp.MustCompile(`^\w+\(\) \{$`),
+	},
+	"Makefile": {
+		regexp.MustCompile(`^\.PHONY:`),
+		regexp.MustCompile(`^\w+ [:?+]= `),
+		regexp.MustCompile(`^\t@?\$\(`),
+	},
+	"js": {
+		regexp.MustCompile(`^(?:const|let|var) \w+ = require\(`),
+		regexp.MustCompile(`^import .* from ['"]`),
+		regexp.MustCompile(`^(?:export (?:default|function|const) |module\.exports)`),
+	},
+	"rb": {
+		regexp.MustCompile(`^require(?:_relative)? ['"]`),
+		regexp.MustCompile(`^(?:module|class) [A-Z]`),
+		regexp.MustCompile(`^end$`),
+	},
+}
+
+// DetectLanguage guesses the language of a file from the given text at its start, returning the
+// names of templates which could apply in order of likelihood. The guess is based on any shebang,
+// editor modeline or content which can only start a file in one language, and otherwise on how
+// many lines look like each language. Binary files have no language.
+func DetectLanguage(head string) []string {
+	if IsBinary([]byte(head)) {
+		return nil
+	}
+
+	head, _ = trimByteOrderMark(head)
+	head = strings.ReplaceAll(head, "\r", "")
+
+	lines := strings.Split(head, "\n")
+
+	var candidates []string
+
+	if strings.HasPrefix(head, "#!") {
+		candidates = append(candidates, interpreterCandidates(lines[0])...)
+	}
+
+	candidates = append(candidates, modelineCandidates(lines)...)
+
+	lowerHead := strings.ToLower(strings.TrimLeft(head, " \t\n"))
+
+	for _, magic := range magicPrefixes {
+		if strings.HasPrefix(lowerHead, magic.prefix) {
+			candidates = append(candidates, magic.template)
+		}
+	}
+
+	candidates = append(candidates, keywordCandidates(lines)...)
+
+	return dedupe(candidates)
+}
+
+// modelineCandidates returns the languages named by editor modelines in the first few lines
+func modelineCandidates(lines []string) []string {
+	var candidates []string
+
+	for i, line := range lines {
+		if i == modelineSearchLines {
+			break
+		}
+
+		var mode string
+
+		if match := EmacsModeRegex.FindStringSubmatch(line); match != nil {
+			mode = match[1] + match[2]
+		} else if match := VimFiletypeRegex.FindStringSubmatch(line); match != nil {
+			mode = match[1]
+		} else {
+			continue
+		}
+
+		mode = strings.TrimSuffix(strings.ToLower(mode), "-mode")
+
+		candidates = append(candidates, mode)
+
+		if template, ok := editorModes[mode]; ok {
+			candidates = append(candidates, template)
+		}
+	}
+
+	return candidates
+}
+
+// keywordCandidates returns the languages which enough of the given lines look like, most likely
+// first
+func keywordCandidates(lines []string) []string {
+	scores := make(map[string]int)
+
+	for _, line := range lines {
+		for language, patterns := range languageKeywords {
+			for _, pattern := range patterns {
+				if pattern.MatchString(line) {
+					scores[language]++
+					break
+				}
+			}
+		}
+	}
+
+	var candidates []string
+
+	for language, score := range scores {
+		if score >= minKeywordScore {
+			candidates = append(candidates, language)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if scores[candidates[i]] != scores[candidates[j]] {
+			return scores[candidates[i]] > scores[candidates[j]]
+		}
+
+		return candidates[i] < candidates[j]
+	})
+
+	return candidates
+}
+
+// dedupe returns the given names without any repeats, keeping the first of each
+func dedupe(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	out := names[:0]
+
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		out = append(out, name)
+	}
+
+	return out
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_DetectLanguage(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected []string
+	}{
+		"shebang": {
+			input:    "#!/usr/bin/env python3\nprint('hello')\n",
+			expected: []string{"python3", "py"},
+		},
+		"emacs mode line": {
+			input:    "# -*- mode: ruby -*-\nputs 'hello'\n",
+			expected: []string{"ruby", "rb"},
+		},
+		"short emacs mode line": {
+			input:    "# -*- Makefile -*-\nall:\n",
+			expected: []string{"makefile", "Makefile"},
+		},
+		"vim modeline": {
+			input:    "# vim: set ft=sh:\necho hello\n",
+			expected: []string{"sh"},
+		},
+		"magic prefix": {
+			input:    "<?xml version=\"1.0\"?>\n<root/>\n",
+			expected: []string{"xml"},
+		},
+		"go keywords": {
+			input:    "package main\n\nimport (\n\t\"fmt\"\n)\n\nfunc main() {}\n",
+			expected: []string{"go"},
+		},
+		"shell keywords": {
+			input:    "set -eu\n\nif true; then\n  echo yes\nfi\n",
+			expected: []string{"sh"},
+		},
+		"python keywords beat makefile": {
+			input:    "import os\nfrom sys import argv\n\nx = 1\n\ndef main():\n    pass\n",
+			expected: []string{"py"},
+		},
+		"too few keywords": {
+			input: "echo hello\n",
+		},
+		"plain text": {
+			input: "Some notes about this directory.\n",
+		},
+		"binary": {
+			input: "\x00\x01\x02",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := DetectLanguage(test.input)
+
+			if len(got) == 0 && len(test.expected) == 0 {
+				return
+			}
+
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("got %q, wanted %q", got, test.expected)
+			}
+		})
+	}
+}
+
+func Test_ResolveContent(t *testing.T) {
+	tm := TemplateMap{"py": {}, "sh": {}}
+
+	path := filepath.Join(t.TempDir(), "codegen.in")
+
+	if err := os.WriteFile(path, []byte("# -*- mode: python -*-\nimport os\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, ok := tm.Resolve(path, DefaultResolutionRules); ok {
+		t.Errorf("expected content detection to be off by default")
+	}
+
+	rules := append(append([]ResolutionRule(nil), DefaultResolutionRules...), ResolutionRule{Strategy: ResolveContent})
+
+	if got, _, _ := tm.Resolve(path, rules); got != "py" {
+		t.Errorf("got template %q, wanted %q", got, "py")
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// DeviationKind classifies how a header which nearly matches its template differs from it
+type DeviationKind string
+
+const (
+	// DeviationWhitespace is for headers which only differ from the template in whitespace
+	DeviationWhitespace DeviationKind = "whitespace"
+
+	// DeviationCase is for headers which only differ from the template in letter case
+	DeviationCase DeviationKind = "case"
+
+	// DeviationPunctuation is for headers which only differ from the template in punctuation
+	DeviationPunctuation DeviationKind = "punctuation"
+
+	// DeviationLicenseURL is for headers which only differ from the template in a URL, such as
+	// the license URL
+	DeviationLicenseURL DeviationKind = "license-url"
+
+	// DeviationMissingText is for headers which are missing some of the template's text
+	DeviationMissingText DeviationKind = "missing-text"
+
+	// DeviationExtraText is for headers which have text which isn't in the template
+	DeviationExtraText DeviationKind = "extra-text"
+
+	// DeviationWording is for headers which differ from the template in any other way
+	DeviationWording DeviationKind = "wording"
+)
+
+// Deviation describes how a header differs from its template
+type Deviation struct {
+	Kind DeviationKind
+
+	// Found and Expected hold the first text which differs between the header and the template.
+	// Either can be empty if text was added or removed.
+	Found    string
+	Expected string
+
+	// Differences is the number of separate places in which the header differs from the template
+	Differences int
+}
+
+// Description returns a human readable description of the deviation, such as "only the license
+// URL differs"
+func (d Deviation) Description() string {
+	var description string
+
+	switch d.Kind {
+	case DeviationWhitespace:
+		return "header differs only in whitespace"
+
+	case DeviationCase:
+		description = fmt.Sprintf("header differs only in letter case: %q where %q was expected", d.Found, d.Expected)
+
+	case DeviationPunctuation:
+		description = fmt.Sprintf("header differs only in punctuation: %q where %q was expected", d.Found, d.Expected)
+
+	case DeviationLicenseURL:
+		description = fmt.Sprintf("only the license URL differs: %q where %q was expected", d.Found, d.Expected)
+
+	case DeviationMissingText:
+		description = fmt.Sprintf("header is missing %q", d.Expected)
+
+	case DeviationExtraText:
+		description = fmt.Sprintf("header has unexpected %q", d.Found)
+
+	default:
+		description = fmt.Sprintf("header has %q where %q was expected", d.Found, d.Expected)
+	}
+
+	if d.Differences > 1 {
+		description += fmt.Sprintf(" (and %d other differences)", d.Differences-1)
+	}
+
+	return description
+}
+
+// Deviation classifies how the header differs from its template using a word-level diff. It
+// returns false if the header wasn't compared against a template, or if its similarity to the
+// template is below threshold.
+func (e *ValidationError) Deviation(threshold float64) (Deviation, bool) {
+	if e.expectedText == "" || e.Similarity < threshold {
+		return Deviation{}, false
+	}
+
+	// the template usually ends with blank lines, which in the file are followed by its contents
+	// rather than more of the header
+	expected := strings.TrimRightFunc(e.expectedText, unicode.IsSpace)
+	found := firstLines(e.foundText, strings.Count(expected, "\n")+1)
+
+	hunks := wordDiff(strings.Fields(expected), strings.Fields(found))
+	if len(hunks) == 0 {
+		return Deviation{Kind: DeviationWhitespace}, true
+	}
+
+	deviation := Deviation{
+		Kind:        DeviationWording,
+		Found:       hunks[0].foundText(),
+		Expected:    hunks[0].expectedText(),
+		Differences: len(hunks),
+	}
+
+	// the kinds are checked from the most to the least trivial, and a kind only applies if every
+	// difference is of that kind
+	kinds := []struct {
+		kind  DeviationKind
+		match func(h diffHunk) bool
+	}{
+		{DeviationCase, diffHunk.onlyCase},
+		{DeviationPunctuation, diffHunk.onlyPunctuation},
+		{DeviationLicenseURL, diffHunk.onlyURL},
+		{DeviationMissingText, diffHunk.onlyMissing},
+		{DeviationExtraText, diffHunk.onlyExtra},
+	}
+
+	for _, k := range kinds {
+		if allHunks(hunks, k.match) {
+			deviation.Kind = k.kind
+			break
+		}
+	}
+
+	return deviation, true
+}
+
+func allHunks(hunks []diffHunk, match func(h diffHunk) bool) bool {
+	for _, h := range hunks {
+		if !match(h) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// diffHunk is a run of words which differ between the template and a header
+type diffHunk struct {
+	expected []string
+	found    []string
+}
+
+func (h diffHunk) expectedText() string {
+	return strings.Join(h.expected, " ")
+}
+
+func (h diffHunk) foundText() string {
+	return strings.Join(h.found, " ")
+}
+
+func (h diffHunk) onlyCase() bool {
+	return strings.EqualFold(h.foundText(), h.expectedText())
+}
+
+func (h diffHunk) onlyPunctuation() bool {
+	return withoutPunctuation(h.foundText()) == withoutPunctuation(h.expectedText())
+}
+
+func (h diffHunk) onlyURL() bool {
+	return allURLs(h.found) && allURLs(h.expected)
+}
+
+func (h diffHunk) onlyMissing() bool {
+	return len(h.found) == 0
+}
+
+func (h diffHunk) onlyExtra() bool {
+	return len(h.expected) == 0
+}
+
+// wordDiff returns the runs of words which differ between expected and found, using their longest
+// common subsequence. Any words in found after the last word of expected are the rest of the file
+// rather than part of the header, and so are ignored.
+func wordDiff(expected []string, found []string) []diffHunk {
+	// lcs[i][j] is the length of the longest common subsequence of expected[i:] and found[j:]
+	lcs := make([][]int, len(expected)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(found)+1)
+	}
+
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(found) - 1; j >= 0; j-- {
+			if expected[i] == found[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var hunks []diffHunk
+
+	var current diffHunk
+
+	flush := func() {
+		if len(current.expected) > 0 || len(current.found) > 0 {
+			hunks = append(hunks, current)
+		}
+
+		current = diffHunk{}
+	}
+
+	i, j := 0, 0
+
+	for i < len(expected) && j < len(found) {
+		switch {
+		case expected[i] == found[j]:
+			flush()
+			i++
+			j++
+
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			current.expected = append(current.expected, expected[i])
+			i++
+
+		default:
+			current.found = append(current.found, found[j])
+			j++
+		}
+	}
+
+	current.expected = append(current.expected, expected[i:]...)
+
+	// once all of the template has been matched, the remaining words are the rest of the file
+	if len(current.expected) > 0 {
+		current.found = append(current.found, found[j:]...)
+	}
+
+	flush()
+
+	return hunks
+}
+
+// withoutPunctuation returns s with everything other than letters, digits and spaces removed
+func withoutPunctuation(s string) string {
+	return strings.Join(strings.Fields(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			return r
+		}
+
+		return ' '
+	}, s)), " ")
+}
+
+// allURLs returns true if there's at least one word and every word is a URL
+func allURLs(words []string) bool {
+	if len(words) == 0 {
+		return false
+	}
+
+	for _, word := range words {
+		if !strings.Contains(word, "://") && !strings.HasPrefix(word, "www.") {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_ValidationErrorDeviation(t *testing.T) {
+	const urlTemplate = "# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n# You may obtain a copy of the License at\n#\n#     http://www.apache.org/licenses/LICENSE-2.0\n\n"
+
+	tmpl, err := NewBoilerplateTemplate(urlTemplate, BoilerplateTemplateConfiguration{
+		ExpectedAuthor:    "cert-manager",
+		NormalizationFunc: normalizeShebang,
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	const (
+		copyrightLine = "# Copyright 2024 The cert-manager Authors.\n#\n"
+		licenseLine   = "# Licensed under the Apache License, Version 2.0 (the \"License\");\n"
+		obtainLine    = "# You may obtain a copy of the License at\n#\n"
+		urlLine       = "#     http://www.apache.org/licenses/LICENSE-2.0\n\n"
+	)
+
+	tests := map[string]struct {
+		input             string
+		threshold         float64
+		expectOK          bool
+		expectKind        DeviationKind
+		expectDescription string
+	}{
+		"trailing whitespace": {
+			input:             copyrightLine + "# Licensed under the Apache License, Version 2.0 (the \"License\");  \n" + obtainLine + urlLine + "echo hello\n",
+			threshold:         NearMissSimilarity,
+			expectOK:          true,
+			expectKind:        DeviationWhitespace,
+			expectDescription: "header differs only in whitespace",
+		},
+		"different URL": {
+			input:             copyrightLine + licenseLine + obtainLine + "#     https://www.apache.org/licenses/LICENSE-2.0\n\n" + "echo hello\n",
+			threshold:         NearMissSimilarity,
+			expectOK:          true,
+			expectKind:        DeviationLicenseURL,
+			expectDescription: `only the license URL differs: "https://www.apache.org/licenses/LICENSE-2.0" where "http://www.apache.org/licenses/LICENSE-2.0" was expected`,
+		},
+		"lower case": {
+			input:             copyrightLine + "# licensed under the apache license, Version 2.0 (the \"License\");\n" + obtainLine + urlLine + "echo hello\n",
+			threshold:         NearMissSimilarity,
+			expectOK:          true,
+			expectKind:        DeviationCase,
+			expectDescription: `header differs only in letter case: "licensed" where "Licensed" was expected (and 1 other differences)`,
+		},
+		"missing punctuation": {
+			input:             copyrightLine + "# Licensed under the Apache License Version 2.0 (the \"License\")\n" + obtainLine + urlLine + "echo hello\n",
+			threshold:         NearMissSimilarity,
+			expectOK:          true,
+			expectKind:        DeviationPunctuation,
+			expectDescription: `header differs only in punctuation: "License" where "License," was expected (and 1 other differences)`,
+		},
+		"missing words": {
+			input:             copyrightLine + "# Licensed under the Apache License, Version 2.0\n" + obtainLine + urlLine + "echo hello\n",
+			threshold:         NearMissSimilarity,
+			expectOK:          true,
+			expectKind:        DeviationMissingText,
+			expectDescription: `header is missing "(the \"License\");"`,
+		},
+		"reworded": {
+			input:             copyrightLine + "# Licensed under the Apache License, Version 3.0 (the \"License\");\n" + obtainLine + urlLine + "echo hello\n",
+			threshold:         NearMissSimilarity,
+			expectOK:          true,
+			expectKind:        DeviationWording,
+			expectDescription: `header has "3.0" where "2.0" was expected`,
+		},
+		"below threshold": {
+			input:     copyrightLine + "# Licensed under the Apache License, Version 3.0 (the \"License\");\n" + obtainLine + urlLine + "echo hello\n",
+			threshold: 1,
+			expectOK:  false,
+		},
+		"no header": {
+			input:     "echo hello\necho hello\necho hello\necho hello\necho hello\necho hello\necho hello\n",
+			threshold: NearMissSimilarity,
+			expectOK:  false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tmpl.Validate(test.input)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a *ValidationError but got %v", err)
+			}
+
+			deviation, ok := validationErr.Deviation(test.threshold)
+			if ok != test.expectOK {
+				t.Fatalf("ok=%v, expected %v (similarity %f)", ok, test.expectOK, validationErr.Similarity)
+			}
+
+			if !ok {
+				return
+			}
+
+			if deviation.Kind != test.expectKind {
+				t.Errorf("Kind=%q, expected %q", deviation.Kind, test.expectKind)
+			}
+
+			if deviation.Description() != test.expectDescription {
+				t.Errorf("Description()=%q, expected %q", deviation.Description(), test.expectDescription)
+			}
+		})
+	}
+}
+
+func Test_wordDiff(t *testing.T) {
+	hunks := wordDiff([]string{"a", "b", "c", "d"}, []string{"a", "x", "c", "d", "e", "f"})
+
+	if len(hunks) != 1 {
+		t.Fatalf("expected 1 hunk but got %d: %v", len(hunks), hunks)
+	}
+
+	if hunks[0].expectedText() != "b" || hunks[0].foundText() != "x" {
+		t.Errorf("got hunk %q -> %q, expected \"b\" -> \"x\"", hunks[0].expectedText(), hunks[0].foundText())
+	}
+}
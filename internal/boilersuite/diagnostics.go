@@ -0,0 +1,290 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NearMissSimilarity is the similarity at or above which a header is considered close enough to
+// the template that a suggestion for fixing it is useful
+const NearMissSimilarity = 0.8
+
+// ValidationErrorKind categorizes why a file failed validation, so that tools can decide how to
+// handle each failure
+type ValidationErrorKind string
+
+const (
+	// ErrorKindMissingBoilerplate is for files which don't appear to have any boilerplate
+	ErrorKindMissingBoilerplate ValidationErrorKind = "missing-boilerplate"
+
+	// ErrorKindWrongAuthor is for boilerplate which only differs from the template in its author
+	ErrorKindWrongAuthor ValidationErrorKind = "wrong-author"
+
+	// ErrorKindWrongYear is for boilerplate whose copyright year isn't in an accepted form
+	ErrorKindWrongYear ValidationErrorKind = "wrong-year"
+
+	// ErrorKindMalformedHeader is for boilerplate which differs from the template in some other way
+	ErrorKindMalformedHeader ValidationErrorKind = "malformed-header"
+
+	// ErrorKindTooShort is for files which start with boilerplate but end before all of it
+	ErrorKindTooShort ValidationErrorKind = "too-short"
+
+	// ErrorKindMisplaced is for valid boilerplate which isn't at the start of the file
+	ErrorKindMisplaced ValidationErrorKind = "misplaced"
+)
+
+// ValidationError describes why a file failed validation
+type ValidationError struct {
+	// Reason is a human readable description of the failure
+	Reason string
+
+	// Kind categorizes the failure
+	Kind ValidationErrorKind
+
+	// Similarity scores how closely the start of the file matched the template, from 0 (nothing in
+	// common) to 1 (an exact match). It's only set when a file was compared against the template.
+	Similarity float64
+
+	// Line is the line of the template, starting from 1, on which the first difference was found.
+	// It's 0 if no comparison was made.
+	Line int
+
+	// Found and Expected hold the words which differ on the first line which didn't match
+	Found    string
+	Expected string
+
+	// Misplaced is true if valid boilerplate was found, but not at the start of the file
+	Misplaced bool
+
+	// headerStart is the index of the first line of misplaced boilerplate, and template is the
+	// template it matched
+	headerStart int
+	template    *BoilerplateTemplate
+
+	// foundText and expectedText are the normalized start of the file and the template text it
+	// was compared against, if a comparison was made
+	foundText    string
+	expectedText string
+}
+
+// Error implements error
+func (e *ValidationError) Error() string {
+	return e.Reason
+}
+
+// IsNearMiss returns true if the header was close enough to the template that it's likely a
+// small mistake which Suggestion can describe
+func (e *ValidationError) IsNearMiss() bool {
+	return e.Line > 0 && e.Similarity >= NearMissSimilarity
+}
+
+// Suggestion describes the first difference between the header and the template
+func (e *ValidationError) Suggestion() string {
+	if e.Line == 0 {
+		return ""
+	}
+
+	if e.Found == "" {
+		return fmt.Sprintf("line %d is missing %q", e.Line, e.Expected)
+	}
+
+	if e.Expected == "" {
+		return fmt.Sprintf("line %d has unexpected %q", e.Line, e.Found)
+	}
+
+	return fmt.Sprintf("line %d has %q where %q was expected", e.Line, e.Found, e.Expected)
+}
+
+// yearPlaceholder stands in for copyright years in the text of diagnostics, where the template's
+// year marker would be confusing
+const yearPlaceholder = "YEAR"
+
+// newMismatchError compares the normalized start of a file against the expected template text and
+// describes how they differ
+func newMismatchError(found string, expected string) *ValidationError {
+	validationErr := &ValidationError{
+		Reason:     "does not start with expected template type",
+		Similarity: similarity(found, expected),
+	}
+
+	// both texts have their years replaced by the marker, which users never write themselves
+	found = YearMarkerRegex.ReplaceAllLiteralString(found, yearPlaceholder)
+	expected = YearMarkerRegex.ReplaceAllLiteralString(expected, yearPlaceholder)
+
+	validationErr.foundText, validationErr.expectedText = found, expected
+
+	foundLines := strings.Split(found, "\n")
+	expectedLines := strings.Split(expected, "\n")
+
+	for i, expectedLine := range expectedLines {
+		var foundLine string
+		if i < len(foundLines) {
+			foundLine = foundLines[i]
+		}
+
+		if foundLine == expectedLine {
+			continue
+		}
+
+		validationErr.Line = i + 1
+		validationErr.Found, validationErr.Expected = differingWords(foundLine, expectedLine)
+
+		break
+	}
+
+	return validationErr
+}
+
+// mismatchKind returns the kind of failure for the normalized start of a file which didn't match
+// the template, where mismatch describes how they differ
+func (t BoilerplateTemplate) mismatchKind(contents string, mismatch *ValidationError) ValidationErrorKind {
+	if !REUSECopyrightRegex.MatchString(contents) && mismatch.Similarity < NearMissSimilarity {
+		return ErrorKindMissingBoilerplate
+	}
+
+	if mismatch.Line == 0 {
+		return ErrorKindMalformedHeader
+	}
+
+	foundLines := strings.Split(contents, "\n")
+	if mismatch.Line > len(foundLines) {
+		return ErrorKindMalformedHeader
+	}
+
+	return t.lineMismatchKind(foundLines[mismatch.Line-1], mismatch.Line-1)
+}
+
+// lineMismatchKind returns the kind of failure for a line of a file which should have matched the
+// given line of the template, or the only line of a one-line template. The line must already have
+// had any accepted years normalized.
+func (t BoilerplateTemplate) lineMismatchKind(found string, index int) ValidationErrorKind {
+	rawLines := strings.Split(t.raw, "\n")
+	replacedLines := strings.Split(t.replaced, "\n")
+
+	if t.kind == TemplateKindLine {
+		found, rawLines, replacedLines = strings.TrimSpace(found), []string{strings.TrimSpace(t.raw)}, []string{strings.TrimSpace(t.replaced)}
+	}
+
+	if index >= len(rawLines) || index >= len(replacedLines) {
+		return ErrorKindMalformedHeader
+	}
+
+	rawLine, replacedLine := rawLines[index], replacedLines[index]
+
+	if AuthorMarkerRegex.MatchString(rawLine) && wildcardMarkers(rawLine, AuthorMarkerRegex).MatchString(found) {
+		return ErrorKindWrongAuthor
+	}
+
+	if YearMarkerRegex.MatchString(replacedLine) && wildcardMarkers(replacedLine, YearMarkerRegex).MatchString(found) {
+		return ErrorKindWrongYear
+	}
+
+	return ErrorKindMalformedHeader
+}
+
+// wildcardMarkers returns a regular expression which matches the given line of a template with
+// anything in place of each marker matching marker
+func wildcardMarkers(line string, marker *regexp.Regexp) *regexp.Regexp {
+	parts := marker.Split(line, -1)
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	return regexp.MustCompile("^" + strings.Join(parts, ".+") + "$")
+}
+
+// similarity returns a score from 0 to 1 based on the edit distance between a and b
+func similarity(a string, b string) float64 {
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+// levenshtein returns the number of single byte insertions, deletions or substitutions needed to
+// change a into b
+func levenshtein(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(first int, rest ...int) int {
+	for _, n := range rest {
+		if n < first {
+			first = n
+		}
+	}
+
+	return first
+}
+
+// differingWords returns the parts of found and expected which differ, widened to whole words so
+// that they make sense when read in isolation
+func differingWords(found string, expected string) (string, string) {
+	prefix := 0
+	for prefix < len(found) && prefix < len(expected) && found[prefix] == expected[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(found)-prefix && suffix < len(expected)-prefix && found[len(found)-1-suffix] == expected[len(expected)-1-suffix] {
+		suffix++
+	}
+
+	// widen to the start of the word containing the first difference
+	start := strings.LastIndex(found[:prefix], " ") + 1
+
+	// widen to the end of the word containing the last difference; the suffix is shared
+	foundEnd, expectedEnd := len(found)-suffix, len(expected)-suffix
+
+	widen := suffix
+	if i := strings.Index(found[foundEnd:], " "); i >= 0 {
+		widen = i
+	}
+
+	return found[start : foundEnd+widen], expected[start : expectedEnd+widen]
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_ValidationErrorSuggestions(t *testing.T) {
+	tmpl := mustTestTemplate(t)
+
+	tests := map[string]struct {
+		input            string
+		expectNearMiss   bool
+		expectLine       int
+		expectFound      string
+		expectExpected   string
+		expectSuggestion string
+	}{
+		"misspelled author": {
+			input:            "# Copyright 2024 The cert manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+			expectNearMiss:   true,
+			expectLine:       1,
+			expectFound:      "cert manager",
+			expectExpected:   "cert-manager",
+			expectSuggestion: `line 1 has "cert manager" where "cert-manager" was expected`,
+		},
+		"wrong licence version": {
+			input:            "# Copyright 2024 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 3.0 (the \"License\");\n\necho hello\n",
+			expectNearMiss:   true,
+			expectLine:       3,
+			expectFound:      "3.0",
+			expectExpected:   "2.0",
+			expectSuggestion: `line 3 has "3.0" where "2.0" was expected`,
+		},
+		"missing line": {
+			input:          "# Copyright 2024 The cert-manager Authors.\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+			expectNearMiss: true,
+			expectLine:     2,
+			expectFound:    "# Licensed under the Apache License, Version 2.0 (the \"License\");",
+			expectExpected: "#",
+		},
+		"no header at all": {
+			input:          "echo hello\necho hello\necho hello\necho hello\n",
+			expectNearMiss: false,
+			expectLine:     1,
+			expectFound:    "echo hello",
+			expectExpected: "# Copyright YEAR The cert-manager Authors.",
+		},
+		"copyright line without a holder": {
+			input:            "# Copyright 2024\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+			expectNearMiss:   false,
+			expectLine:       1,
+			expectFound:      "YEAR",
+			expectExpected:   "YEAR The cert-manager Authors.",
+			expectSuggestion: `line 1 has "YEAR" where "YEAR The cert-manager Authors." was expected`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tmpl.Validate(test.input)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a *ValidationError but got %v", err)
+			}
+
+			if validationErr.IsNearMiss() != test.expectNearMiss {
+				t.Errorf("IsNearMiss()=%v, expected %v (similarity %f)", validationErr.IsNearMiss(), test.expectNearMiss, validationErr.Similarity)
+			}
+
+			if validationErr.Line != test.expectLine {
+				t.Errorf("Line=%d, expected %d", validationErr.Line, test.expectLine)
+			}
+
+			if validationErr.Found != test.expectFound || validationErr.Expected != test.expectExpected {
+				t.Errorf("Found=%q Expected=%q, expected %q and %q", validationErr.Found, validationErr.Expected, test.expectFound, test.expectExpected)
+			}
+
+			if test.expectSuggestion != "" && validationErr.Suggestion() != test.expectSuggestion {
+				t.Errorf("Suggestion()=%q, expected %q", validationErr.Suggestion(), test.expectSuggestion)
+			}
+
+			deviation, _ := validationErr.Deviation(0)
+			for _, text := range []string{validationErr.Found, validationErr.Expected, validationErr.Suggestion(), deviation.Description()} {
+				if strings.Contains(text, "<<") {
+					t.Errorf("expected no template markers to be shown to users, got %q", text)
+				}
+			}
+		})
+	}
+}
+
+func Test_ValidationErrorKind(t *testing.T) {
+	lineTemplate, err := NewBoilerplateTemplate("# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n", BoilerplateTemplateConfiguration{ExpectedAuthor: "cert-manager"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		tmpl  BoilerplateTemplate
+		input string
+
+		expectKind ValidationErrorKind
+	}{
+		"no header at all": {
+			tmpl:       mustTestTemplate(t),
+			input:      "echo hello\necho hello\necho hello\necho hello\n",
+			expectKind: ErrorKindMissingBoilerplate,
+		},
+		"wrong author": {
+			tmpl:       mustTestTemplate(t),
+			input:      "# Copyright 2024 The Kubernetes Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+			expectKind: ErrorKindWrongAuthor,
+		},
+		"wrong year": {
+			tmpl:       mustTestTemplate(t),
+			input:      "# Copyright 1999 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+			expectKind: ErrorKindWrongYear,
+		},
+		"malformed header": {
+			tmpl:       mustTestTemplate(t),
+			input:      "# Copyright 2024 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 3.0 (the \"License\");\n\necho hello\n",
+			expectKind: ErrorKindMalformedHeader,
+		},
+		"too short": {
+			tmpl:       mustTestTemplate(t),
+			input:      "# Copyright 2024 The cert-manager Authors.\n",
+			expectKind: ErrorKindTooShort,
+		},
+		"too short without a header": {
+			tmpl:       mustTestTemplate(t),
+			input:      "echo hello\n",
+			expectKind: ErrorKindMissingBoilerplate,
+		},
+		"misplaced": {
+			tmpl:       mustTestTemplate(t),
+			input:      "echo hello\n# Copyright 2024 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+			expectKind: ErrorKindMisplaced,
+		},
+		"one-line template with wrong author": {
+			tmpl:       lineTemplate,
+			input:      "root = true\n# Copyright 2024 The Kubernetes Authors.\n",
+			expectKind: ErrorKindWrongAuthor,
+		},
+		"one-line template without a header": {
+			tmpl:       lineTemplate,
+			input:      "root = true\n",
+			expectKind: ErrorKindMissingBoilerplate,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.tmpl.Validate(test.input)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a *ValidationError but got %v", err)
+			}
+
+			if validationErr.Kind != test.expectKind {
+				t.Errorf("Kind=%q, expected %q", validationErr.Kind, test.expectKind)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// byteOrderMark is the UTF-8 encoding of U+FEFF, which some editors (notably on Windows) write at
+// the start of UTF-8 files
+const byteOrderMark = "\ufeff"
+
+var (
+	utf8BOM    = []byte(byteOrderMark)
+	utf16LEBOM = []byte{0xff, 0xfe}
+	utf16BEBOM = []byte{0xfe, 0xff}
+)
+
+// TextEncoding is the encoding of a file, so that changes to it can be written back in the
+// encoding it already used
+type TextEncoding int
+
+const (
+	// EncodingUTF8 is UTF-8 without a byte order mark
+	EncodingUTF8 TextEncoding = iota
+
+	// EncodingUTF8BOM is UTF-8 starting with a byte order mark
+	EncodingUTF8BOM
+
+	// EncodingUTF16LE is little-endian UTF-16 starting with a byte order mark
+	EncodingUTF16LE
+
+	// EncodingUTF16BE is big-endian UTF-16 starting with a byte order mark
+	EncodingUTF16BE
+)
+
+func (e TextEncoding) String() string {
+	switch e {
+	case EncodingUTF8BOM:
+		return "UTF-8 with BOM"
+
+	case EncodingUTF16LE:
+		return "UTF-16LE"
+
+	case EncodingUTF16BE:
+		return "UTF-16BE"
+
+	default:
+		return "UTF-8"
+	}
+}
+
+// EncodingError is returned for files which aren't valid in the encoding they appear to use, such as
+// files containing invalid UTF-8 or which mix encodings. Such files can't be checked reliably, and
+// fixing them could corrupt them.
+type EncodingError struct {
+	// Encoding is the encoding the file appeared to use
+	Encoding TextEncoding
+
+	// Line is the line, starting from 1, on which the first invalid sequence was found. It's 0 if
+	// the problem isn't with a particular line.
+	Line int
+
+	// Reason describes what's invalid, if Line isn't set
+	Reason string
+}
+
+// Error implements error
+func (e *EncodingError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("file isn't valid %s: invalid byte sequence on line %d", e.Encoding, e.Line)
+	}
+
+	return fmt.Sprintf("file isn't valid %s: %s", e.Encoding, e.Reason)
+}
+
+// IsUTF16 returns true if the given contents start with a UTF-16 byte order mark
+func IsUTF16(contents []byte) bool {
+	return bytes.HasPrefix(contents, utf16LEBOM) || bytes.HasPrefix(contents, utf16BEBOM)
+}
+
+// DecodeText returns the given file contents as UTF-8 without any byte order mark, along with the
+// encoding they were in. UTF-16 is only recognised if the contents start with a byte order mark.
+// An *EncodingError is returned if the contents aren't valid in their encoding, since fixing such a
+// file could corrupt it.
+func DecodeText(contents []byte) (string, TextEncoding, error) {
+	var byteOrder binary.ByteOrder
+
+	switch {
+	case bytes.HasPrefix(contents, utf8BOM):
+		body := contents[len(utf8BOM):]
+		if err := validateUTF8(body, EncodingUTF8BOM); err != nil {
+			return "", EncodingUTF8BOM, err
+		}
+
+		return string(body), EncodingUTF8BOM, nil
+
+	case bytes.HasPrefix(contents, utf16LEBOM):
+		byteOrder = binary.LittleEndian
+
+	case bytes.HasPrefix(contents, utf16BEBOM):
+		byteOrder = binary.BigEndian
+
+	default:
+		if err := validateUTF8(contents, EncodingUTF8); err != nil {
+			return "", EncodingUTF8, err
+		}
+
+		return string(contents), EncodingUTF8, nil
+	}
+
+	encoding := EncodingUTF16LE
+	if byteOrder == binary.BigEndian {
+		encoding = EncodingUTF16BE
+	}
+
+	body := contents[2:]
+	if len(body)%2 != 0 {
+		return "", encoding, &EncodingError{Encoding: encoding, Reason: "it has an odd number of bytes"}
+	}
+
+	units := make([]uint16, len(body)/2)
+	for i := range units {
+		units[i] = byteOrder.Uint16(body[i*2:])
+	}
+
+	text := string(utf16.Decode(units))
+
+	// unpaired surrogates are decoded as U+FFFD, which would change the file if it were written back
+	if !bytes.Equal(EncodeText(text, encoding), contents) {
+		return "", encoding, &EncodingError{Encoding: encoding, Reason: "it contains unpaired surrogates"}
+	}
+
+	return text, encoding, nil
+}
+
+// validateUTF8 returns an *EncodingError locating the first invalid sequence in contents, if any
+func validateUTF8(contents []byte, encoding TextEncoding) error {
+	if utf8.Valid(contents) {
+		return nil
+	}
+
+	for i := 0; i < len(contents); {
+		r, size := utf8.DecodeRune(contents[i:])
+		if r == utf8.RuneError && size <= 1 {
+			return &EncodingError{Encoding: encoding, Line: bytes.Count(contents[:i], []byte("\n")) + 1}
+		}
+
+		i += size
+	}
+
+	return nil
+}
+
+// EncodeText encodes the given UTF-8 text using the given encoding, adding a byte order mark if
+// the encoding uses one
+func EncodeText(text string, encoding TextEncoding) []byte {
+	var byteOrder binary.ByteOrder
+
+	switch encoding {
+	case EncodingUTF8BOM:
+		return []byte(byteOrderMark + text)
+
+	case EncodingUTF16LE:
+		byteOrder = binary.LittleEndian
+
+	case EncodingUTF16BE:
+		byteOrder = binary.BigEndian
+
+	default:
+		return []byte(text)
+	}
+
+	units := utf16.Encode([]rune(text))
+
+	encoded := make([]byte, 2+len(units)*2)
+	byteOrder.PutUint16(encoded, 0xfeff)
+
+	for i, unit := range units {
+		byteOrder.PutUint16(encoded[2+i*2:], unit)
+	}
+
+	return encoded
+}
+
+// trimByteOrderMark removes a UTF-8 byte order mark from the start of raw, returning whether there was one
+func trimByteOrderMark(raw string) (string, bool) {
+	if !strings.HasPrefix(raw, byteOrderMark) {
+		return raw, false
+	}
+
+	return raw[len(byteOrderMark):], true
+}
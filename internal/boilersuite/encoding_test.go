@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func Test_DecodeText(t *testing.T) {
+	tests := map[string]struct {
+		contents         []byte
+		expectedText     string
+		expectedEncoding TextEncoding
+		expectErr        bool
+		expectedLine     int
+	}{
+		"UTF-8": {
+			contents:         []byte("# héllo\n"),
+			expectedText:     "# héllo\n",
+			expectedEncoding: EncodingUTF8,
+		},
+		"UTF-8 with BOM": {
+			contents:         []byte("\xef\xbb\xbf# hello\n"),
+			expectedText:     "# hello\n",
+			expectedEncoding: EncodingUTF8BOM,
+		},
+		"invalid UTF-8": {
+			contents:     []byte("# hello\n# h\xe9llo\n"),
+			expectErr:    true,
+			expectedLine: 2,
+		},
+		"invalid UTF-8 with BOM": {
+			contents:     []byte("\xef\xbb\xbf# h\xe9llo\n"),
+			expectErr:    true,
+			expectedLine: 1,
+		},
+		"UTF-16LE": {
+			contents:         []byte{0xff, 0xfe, '#', 0, ' ', 0, 0xe9, 0, '\n', 0},
+			expectedText:     "# é\n",
+			expectedEncoding: EncodingUTF16LE,
+		},
+		"UTF-16BE": {
+			contents:         []byte{0xfe, 0xff, 0, '#', 0, ' ', 0, 0xe9, 0, '\n'},
+			expectedText:     "# é\n",
+			expectedEncoding: EncodingUTF16BE,
+		},
+		"UTF-16 with odd length": {
+			contents:  []byte{0xff, 0xfe, '#', 0, ' '},
+			expectErr: true,
+		},
+		"UTF-16 with unpaired surrogate": {
+			contents:  []byte{0xff, 0xfe, '#', 0, 0x00, 0xd8, '\n', 0},
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			text, encoding, err := DecodeText(test.contents)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if test.expectErr {
+				var encodingErr *EncodingError
+				if !errors.As(err, &encodingErr) {
+					t.Fatalf("expected an *EncodingError but got %T", err)
+				}
+
+				if encodingErr.Line != test.expectedLine {
+					t.Errorf("got line %d, wanted %d", encodingErr.Line, test.expectedLine)
+				}
+
+				return
+			}
+
+			if text != test.expectedText {
+				t.Errorf("got text %q, wanted %q", text, test.expectedText)
+			}
+
+			if encoding != test.expectedEncoding {
+				t.Errorf("got encoding %s, wanted %s", encoding, test.expectedEncoding)
+			}
+
+			if encoded := EncodeText(text, encoding); !bytes.Equal(encoded, test.contents) {
+				t.Errorf("encoding the text again gave %v, wanted %v", encoded, test.contents)
+			}
+		})
+	}
+}
+
+func Test_ByteOrderMark(t *testing.T) {
+	tmpl := mustTestTemplate(t)
+
+	valid := "\ufeff# Copyright 2026 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n"
+	if err := tmpl.Validate(valid); err != nil {
+		t.Errorf("expected file with a byte order mark to be valid but got: %s", err)
+	}
+
+	fixed, err := tmpl.Fix("\ufeffecho hello\n", FixOptions{Year: 2026})
+	if err != nil {
+		t.Fatalf("failed to fix file: %s", err)
+	}
+
+	if fixed != valid {
+		t.Errorf("expected byte order mark to be kept at the start of the fixed file, got %q", fixed)
+	}
+}
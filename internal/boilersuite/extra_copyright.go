@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExtraCopyrightPolicy decides whether a header may have copyright lines for other holders next
+// to the template's own copyright line, e.g. for files with several contributors or for forked
+// code which retains its upstream copyright
+type ExtraCopyrightPolicy string
+
+const (
+	// ExtraCopyrightForbidden rejects headers with extra copyright lines. This is the default.
+	ExtraCopyrightForbidden ExtraCopyrightPolicy = "forbidden"
+
+	// ExtraCopyrightAllowed accepts headers with or without extra copyright lines
+	ExtraCopyrightAllowed ExtraCopyrightPolicy = "allowed"
+
+	// ExtraCopyrightRequired only accepts headers with at least one extra copyright line
+	ExtraCopyrightRequired ExtraCopyrightPolicy = "required"
+)
+
+// ParseExtraCopyrightPolicy returns the policy with the given name. An empty name is the default
+// policy, ExtraCopyrightForbidden.
+func ParseExtraCopyrightPolicy(name string) (ExtraCopyrightPolicy, error) {
+	switch policy := ExtraCopyrightPolicy(name); policy {
+	case "":
+		return ExtraCopyrightForbidden, nil
+
+	case ExtraCopyrightForbidden, ExtraCopyrightAllowed, ExtraCopyrightRequired:
+		return policy, nil
+
+	default:
+		return "", fmt.Errorf("unknown extra copyright policy %q; expected one of %q, %q or %q", name, ExtraCopyrightForbidden, ExtraCopyrightAllowed, ExtraCopyrightRequired)
+	}
+}
+
+// WithExtraCopyright returns a copy of the template, and of each of its alternatives, which
+// applies the given policy to copyright lines stacked above or below its own copyright line
+func (t BoilerplateTemplate) WithExtraCopyright(policy ExtraCopyrightPolicy) BoilerplateTemplate {
+	t.extraCopyright = policy
+
+	alternatives := make([]BoilerplateTemplate, len(t.alternatives))
+	for i, alternative := range t.alternatives {
+		alternatives[i] = alternative.WithExtraCopyright(policy)
+	}
+
+	t.alternatives = alternatives
+
+	return t
+}
+
+// WithExtraCopyright returns a copy of the map in which every template applies the given policy to
+// extra copyright lines
+func (tm TemplateMap) WithExtraCopyright(policy ExtraCopyrightPolicy) TemplateMap {
+	out := make(TemplateMap, len(tm))
+
+	for name, tmpl := range tm {
+		out[name] = tmpl.WithExtraCopyright(policy)
+	}
+
+	return out
+}
+
+// copyrightLine returns the index of the template's copyright line and whatever comes before the
+// word "Copyright" on it, e.g. a comment prefix
+func (t BoilerplateTemplate) copyrightLine() (int, string, bool) {
+	for i, line := range strings.Split(t.replaced, "\n") {
+		if j := strings.Index(line, "Copyright "); j >= 0 {
+			return i, line[:j], true
+		}
+	}
+
+	return 0, "", false
+}
+
+// removeExtraCopyright removes any copyright lines stacked above or below the template's own
+// copyright line from the normalized contents of a file, returning what's left and how many lines
+// were removed. Nothing is removed unless the template allows or requires extra copyright lines,
+// or if none of the stacked lines is the template's own.
+func (t BoilerplateTemplate) removeExtraCopyright(contents string) (string, int) {
+	if t.extraCopyright != ExtraCopyrightAllowed && t.extraCopyright != ExtraCopyrightRequired {
+		return contents, 0
+	}
+
+	index, prefix, ok := t.copyrightLine()
+	if !ok {
+		return contents, 0
+	}
+
+	lines := strings.Split(contents, "\n")
+	expected := strings.Split(t.replaced, "\n")[index]
+
+	end := index
+	for end < len(lines) && strings.HasPrefix(lines[end], prefix+"Copyright ") {
+		end++
+	}
+
+	own := -1
+	for i := index; i < end; i++ {
+		if lines[i] == expected || (t.authorLines[index] != nil && t.authorLines[index].MatchString(lines[i])) || t.matchesIgnoringYear(lines[i], index) {
+			own = i
+			break
+		}
+	}
+
+	if own < 0 {
+		return contents, 0
+	}
+
+	out := append(append(append([]string(nil), lines[:index]...), lines[own]), lines[end:]...)
+
+	return strings.Join(out, "\n"), end - index - 1
+}
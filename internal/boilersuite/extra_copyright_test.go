@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"testing"
+)
+
+func Test_ExtraCopyright(t *testing.T) {
+	const (
+		own      = "# Copyright 2024 The cert-manager Authors.\n"
+		upstream = "# Copyright 2019 The Kubernetes Authors.\n"
+		rest     = "#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n"
+	)
+
+	tests := map[string]struct {
+		input string
+
+		expectForbiddenErr bool
+		expectAllowedErr   bool
+		expectRequiredErr  bool
+	}{
+		"only the expected copyright": {
+			input:             own + rest,
+			expectRequiredErr: true,
+		},
+		"upstream copyright first": {
+			input:              upstream + own + rest,
+			expectForbiddenErr: true,
+		},
+		"upstream copyright last": {
+			input:              own + upstream + rest,
+			expectForbiddenErr: true,
+		},
+		"several extra copyrights": {
+			input:              upstream + own + "# Copyright 2021 Jetstack Ltd.\n" + rest,
+			expectForbiddenErr: true,
+		},
+		"no expected copyright": {
+			input:              upstream + rest,
+			expectForbiddenErr: true,
+			expectAllowedErr:   true,
+			expectRequiredErr:  true,
+		},
+		"extra copyright separated from the expected copyright": {
+			input:              own + "#\n" + upstream + rest,
+			expectForbiddenErr: true,
+			expectAllowedErr:   true,
+			expectRequiredErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for policy, expectErr := range map[ExtraCopyrightPolicy]bool{
+				ExtraCopyrightForbidden: test.expectForbiddenErr,
+				ExtraCopyrightAllowed:   test.expectAllowedErr,
+				ExtraCopyrightRequired:  test.expectRequiredErr,
+			} {
+				if err := mustTestTemplate(t).WithExtraCopyright(policy).Validate(test.input); (err != nil) != expectErr {
+					t.Errorf("%s: err=%v, expectErr=%v", policy, err, expectErr)
+				}
+			}
+		})
+	}
+}
+
+func Test_ParseExtraCopyrightPolicy(t *testing.T) {
+	if policy, err := ParseExtraCopyrightPolicy(""); err != nil || policy != ExtraCopyrightForbidden {
+		t.Errorf("expected empty policy to be %q, got %q (err=%v)", ExtraCopyrightForbidden, policy, err)
+	}
+
+	if _, err := ParseExtraCopyrightPolicy("sometimes"); err == nil {
+		t.Errorf("expected unknown policy to be rejected")
+	}
+}
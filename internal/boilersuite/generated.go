@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// GeneratedMatcher identifies generated files, which aren't validated
+type GeneratedMatcher struct {
+	// Patterns are searched for in files; a file matching any of them is generated
+	Patterns []*regexp.Regexp
+
+	// MaxLines limits the search to the first MaxLines lines of a file. If zero, the whole file is
+	// searched.
+	MaxLines int
+}
+
+// DefaultGeneratedMatcher detects files generated by Kubernetes code generators
+var DefaultGeneratedMatcher = GeneratedMatcher{
+	Patterns: []*regexp.Regexp{GeneratedRegex},
+}
+
+// NewGeneratedMatcher returns a matcher which recognises files matching either GeneratedRegex or
+// any of the given extra patterns. Patterns are compiled in multi-line mode, so that "^" and "$"
+// match at the start and end of each line.
+func NewGeneratedMatcher(extraPatterns []string, maxLines int) (GeneratedMatcher, error) {
+	matcher := GeneratedMatcher{
+		Patterns: []*regexp.Regexp{GeneratedRegex},
+		MaxLines: maxLines,
+	}
+
+	for _, pattern := range extraPatterns {
+		compiled, err := regexp.Compile("(?m)" + pattern)
+		if err != nil {
+			return GeneratedMatcher{}, fmt.Errorf("invalid generated file pattern %q: %w", pattern, err)
+		}
+
+		matcher.Patterns = append(matcher.Patterns, compiled)
+	}
+
+	return matcher, nil
+}
+
+// IsGenerated returns true if the given file was generated
+func (m GeneratedMatcher) IsGenerated(raw string) bool {
+	if m.MaxLines > 0 {
+		raw = firstLines(raw, m.MaxLines)
+	}
+
+	for _, pattern := range m.Patterns {
+		if pattern.MatchString(raw) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// firstLines returns the first n lines of raw, including their line endings
+func firstLines(raw string, n int) string {
+	end := 0
+
+	for i := 0; i < n; i++ {
+		next := strings.IndexByte(raw[end:], '\n')
+		if next < 0 {
+			return raw
+		}
+
+		end += next + 1
+	}
+
+	return raw[:end]
+}
+
+// WithGeneratedMatcher returns a copy of the template which uses the given matcher to decide
+// whether files are generated
+func (t BoilerplateTemplate) WithGeneratedMatcher(matcher GeneratedMatcher) BoilerplateTemplate {
+	t.generated = &matcher
+	return t
+}
+
+// WithGeneratedMatcher returns a copy of the map in which every template uses the given matcher
+// to decide whether files are generated
+func (tm TemplateMap) WithGeneratedMatcher(matcher GeneratedMatcher) TemplateMap {
+	out := make(TemplateMap, len(tm))
+
+	for name, tmpl := range tm {
+		out[name] = tmpl.WithGeneratedMatcher(matcher)
+	}
+
+	return out
+}
+
+func (t BoilerplateTemplate) isGenerated(raw string) bool {
+	if t.generated == nil {
+		return DefaultGeneratedMatcher.IsGenerated(raw)
+	}
+
+	return t.generated.IsGenerated(raw)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"testing"
+)
+
+func Test_GeneratedMatcher(t *testing.T) {
+	tests := map[string]struct {
+		patterns        []string
+		maxLines        int
+		input           string
+		expectGenerated bool
+	}{
+		"default pattern": {
+			input:           "// Code generated by controller-gen. DO NOT EDIT.\n\npackage x\n",
+			expectGenerated: true,
+		},
+		"extra pattern": {
+			patterns:        []string{`@generated`},
+			input:           "# @generated by a tool\nfoo = 1\n",
+			expectGenerated: true,
+		},
+		"extra pattern with anchors": {
+			patterns:        []string{`^// Code generated by protoc-gen-go`},
+			input:           "package x\n// Code generated by protoc-gen-go. Edit at your own risk\n",
+			expectGenerated: true,
+		},
+		"extra pattern not present": {
+			patterns:        []string{`@generated`},
+			input:           "package x\n",
+			expectGenerated: false,
+		},
+		"pattern within max lines": {
+			patterns:        []string{`@generated`},
+			maxLines:        2,
+			input:           "line one\n// @generated\nline three\n",
+			expectGenerated: true,
+		},
+		"pattern beyond max lines": {
+			patterns:        []string{`@generated`},
+			maxLines:        2,
+			input:           "line one\nline two\nconst marker = \"@generated\"\n",
+			expectGenerated: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matcher, err := NewGeneratedMatcher(test.patterns, test.maxLines)
+			if err != nil {
+				t.Fatalf("failed to create matcher: %s", err)
+			}
+
+			if generated := matcher.IsGenerated(test.input); generated != test.expectGenerated {
+				t.Errorf("generated=%v, expected %v", generated, test.expectGenerated)
+			}
+		})
+	}
+
+	if _, err := NewGeneratedMatcher([]string{`(unclosed`}, 0); err == nil {
+		t.Errorf("expected an error for an invalid pattern")
+	}
+}
+
+func Test_CheckGenerated(t *testing.T) {
+	tmpl := mustTestTemplate(t)
+
+	const generated = "# Code generated by a tool. DO NOT EDIT.\n\necho hello\necho hello\necho hello\n"
+
+	if err := tmpl.Validate(generated); err != nil {
+		t.Errorf("expected generated file to be skipped by default, got %v", err)
+	}
+
+	if err := tmpl.WithGeneratedMatcher(GeneratedMatcher{}).Validate(generated); err == nil {
+		t.Errorf("expected generated file to be validated with a matcher which has no patterns")
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"text/template"
+)
+
+// GoTemplateData holds the values available to templates processed by LoadGoTemplates
+type GoTemplateData struct {
+	// License is the name of the license whose notice is expected, e.g. "apache-2.0"
+	License string
+
+	// FirstYear is the year in which the project was started
+	FirstYear int
+
+	// CurrentYear is the year in which boilersuite is running
+	CurrentYear int
+}
+
+// goTemplateFuncs are the functions available to templates processed by LoadGoTemplates
+var goTemplateFuncs = template.FuncMap{
+	"yearRange": yearRange,
+}
+
+// LoadGoTemplates is like LoadTemplates, but first processes each template using text/template with
+// the given data, e.g. so that a header can contain {{ yearRange .FirstYear .CurrentYear }} or
+// content which depends on {{ if eq .License "mit" }}. Markers such as <<AUTHOR>> can still be used
+// in the output. Any copyright year which the output contains is used when adding boilerplate, but
+// files are accepted with any year, as they would be with the <<YEAR>> marker.
+func LoadGoTemplates(templateDir fs.FS, expectedAuthor string, data GoTemplateData) (TemplateMap, error) {
+	if data.CurrentYear == 0 {
+		data.CurrentYear = now().Year()
+	}
+
+	if data.FirstYear == 0 {
+		data.FirstYear = data.CurrentYear
+	}
+
+	return loadTemplates(templateDir, expectedAuthor, nil, func(raw string) (string, error) {
+		return renderGoTemplate(raw, data)
+	})
+}
+
+// renderGoTemplate processes raw as a text/template using the given data
+func renderGoTemplate(raw string, data GoTemplateData) (string, error) {
+	tmpl, err := template.New("boilerplate").Funcs(goTemplateFuncs).Option("missingkey=error").Parse(raw)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// yearRange returns a range of years such as "2019-2026", or a single year if first and last are
+// the same
+func yearRange(first int, last int) (string, error) {
+	switch {
+	case first > last:
+		return "", fmt.Errorf("invalid year range: %d is after %d", first, last)
+
+	case first == last:
+		return fmt.Sprint(first), nil
+
+	default:
+		return fmt.Sprintf("%d-%d", first, last), nil
+	}
+}
+
+// literalYears replaces the first literal copyright year or range of years in raw with the year
+// marker, returning the replaced years along with any copyright symbol before them
+func literalYears(raw string) (string, string) {
+	loc := DateRegex.FindStringIndex(raw)
+	if loc == nil {
+		return raw, ""
+	}
+
+	prefix, years := splitCopyrightYears(raw[loc[0]:loc[1]])
+
+	return raw[:loc[0]] + "Copyright " + YearMarkerRegex.String() + raw[loc[1]:], strings.TrimPrefix(prefix, "Copyright ") + years
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func Test_LoadGoTemplates(t *testing.T) {
+	templateDir := fstest.MapFS{
+		"boilerplate.sh.boilertmpl": {
+			Data: []byte("# Copyright {{ yearRange .FirstYear .CurrentYear }} The <<AUTHOR>> Authors.\n{{ if eq .License \"mit\" }}# SPDX-License-Identifier: MIT{{ else }}# SPDX-License-Identifier: Apache-2.0{{ end }}\n\n"),
+		},
+	}
+
+	tests := map[string]struct {
+		data GoTemplateData
+
+		expectFixed string
+	}{
+		"range of years": {
+			data:        GoTemplateData{License: "apache-2.0", FirstYear: 2019, CurrentYear: 2026},
+			expectFixed: "# Copyright 2019-2026 The cert-manager Authors.\n# SPDX-License-Identifier: Apache-2.0\n\necho hello\n",
+		},
+		"single year": {
+			data:        GoTemplateData{License: "mit", CurrentYear: 2026},
+			expectFixed: "# Copyright 2026 The cert-manager Authors.\n# SPDX-License-Identifier: MIT\n\necho hello\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			templates, err := LoadGoTemplates(templateDir, "cert-manager", test.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tmpl := templates["sh"]
+
+			fixed, err := tmpl.Fix("echo hello\n", FixOptions{Year: 2030})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if fixed != test.expectFixed {
+				t.Errorf("expected fixed file to be %q, got %q", test.expectFixed, fixed)
+			}
+
+			// files with any other year are still valid
+			if err := tmpl.Validate(DateRegex.ReplaceAllString(fixed, "Copyright 2021")); err != nil {
+				t.Errorf("expected a file with a different year to be valid, got %v", err)
+			}
+		})
+	}
+}
+
+func Test_LoadGoTemplatesInvalid(t *testing.T) {
+	tests := map[string]string{
+		"syntax error":         "# Copyright {{ yearRange .FirstYear }",
+		"unknown field":        "# Copyright {{ .LastYear }} <<AUTHOR>>\n",
+		"backwards year range": "# Copyright {{ yearRange 2026 2019 }} <<AUTHOR>>\n",
+	}
+
+	for name, contents := range tests {
+		t.Run(name, func(t *testing.T) {
+			templateDir := fstest.MapFS{
+				"boilerplate.sh.boilertmpl": {Data: []byte(contents)},
+			}
+
+			if _, err := LoadGoTemplates(templateDir, "cert-manager", GoTemplateData{}); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
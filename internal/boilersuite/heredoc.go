@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"strings"
+)
+
+// Heredoc is a here-document in a shell script which writes its contents to a file
+type Heredoc struct {
+	// Line is the line number in the script on which the heredoc starts
+	Line int
+
+	// Target is the path the heredoc is written to, as it appears in the script
+	Target string
+
+	// Body holds the contents of the heredoc
+	Body string
+
+	// QuotedDelimiter is true if the delimiter was quoted (e.g. <<'EOF'), which means that
+	// variables and command substitutions in the body aren't expanded
+	QuotedDelimiter bool
+
+	// bodyStart and bodyEnd are the indexes of the first line of the body and of the line holding
+	// the closing delimiter
+	bodyStart int
+	bodyEnd   int
+
+	// indent is the leading tabs of the first line of the body if they're stripped (e.g. <<-EOF)
+	indent string
+}
+
+// FindHeredocs returns every heredoc in the given shell script which is redirected to a file,
+// such as those started with `cat <<EOF > something.go`
+func FindHeredocs(raw string) []Heredoc {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r", ""), "\n")
+
+	var heredocs []Heredoc
+
+	for i := 0; i < len(lines); i++ {
+		start := HeredocStartRegex.FindStringSubmatch(lines[i])
+		if start == nil {
+			continue
+		}
+
+		stripTabs, quote, delimiter := start[1] == "-", start[2], start[3]
+
+		// find the end of the heredoc even if it's not redirected to a file, so its body isn't
+		// searched for more heredocs
+		end := i + 1
+		for ; end < len(lines); end++ {
+			line := lines[end]
+			if stripTabs {
+				line = strings.TrimLeft(line, "\t")
+			}
+
+			if line == delimiter {
+				break
+			}
+		}
+
+		redirect := HeredocRedirectRegex.FindStringSubmatch(HeredocStartRegex.ReplaceAllString(lines[i], ""))
+		if redirect != nil {
+			body := make([]string, end-(i+1))
+			copy(body, lines[i+1:end])
+
+			var indent string
+
+			if stripTabs {
+				if len(body) > 0 {
+					indent = body[0][:len(body[0])-len(strings.TrimLeft(body[0], "\t"))]
+				}
+
+				for j := range body {
+					body[j] = strings.TrimLeft(body[j], "\t")
+				}
+			}
+
+			heredocs = append(heredocs, Heredoc{
+				Line:            i + 1,
+				Target:          strings.Trim(redirect[1], `"'`),
+				Body:            strings.Join(body, "\n") + "\n",
+				QuotedDelimiter: quote != "",
+
+				bodyStart: i + 1,
+				bodyEnd:   end,
+				indent:    indent,
+			})
+		}
+
+		i = end
+	}
+
+	return heredocs
+}
+
+// ReplaceHeredocBody returns the script with the body of the given heredoc, which must have been
+// found in it by FindHeredocs, replaced by body. In a heredoc whose leading tabs are stripped, each
+// line of the new body is indented like the first line of the old one.
+func ReplaceHeredocBody(raw string, heredoc Heredoc, body string) string {
+	lines := strings.Split(raw, "\n")
+
+	// lines keep any carriage return, which the new lines need too
+	carriageReturn := strings.TrimSuffix(lineEnding(raw), "\n")
+
+	replaced := make([]string, 0, len(lines))
+	replaced = append(replaced, lines[:heredoc.bodyStart]...)
+
+	for _, line := range strings.Split(strings.TrimSuffix(body, "\n"), "\n") {
+		if line != "" {
+			line = heredoc.indent + line
+		}
+
+		replaced = append(replaced, line+carriageReturn)
+	}
+
+	replaced = append(replaced, lines[heredoc.bodyEnd:]...)
+
+	return strings.Join(replaced, "\n")
+}
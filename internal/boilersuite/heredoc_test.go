@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_FindHeredocs(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected []Heredoc
+	}{
+		"redirect after heredoc": {
+			input: "#!/bin/sh\ncat <<EOF > out/file.go\npackage main\nEOF\necho done\n",
+			expected: []Heredoc{
+				{Line: 2, Target: "out/file.go", Body: "package main\n", bodyStart: 2, bodyEnd: 3},
+			},
+		},
+		"redirect before heredoc with quoted path and delimiter": {
+			input: "cat >> \"${OUT}/file.yaml\" <<'EOF'\na: b\nEOF\n",
+			expected: []Heredoc{
+				{Line: 1, Target: "${OUT}/file.yaml", Body: "a: b\n", QuotedDelimiter: true, bodyStart: 1, bodyEnd: 2},
+			},
+		},
+		"tab stripping": {
+			input: "\tcat <<-EOF >file.sh\n\t\techo hi\n\tEOF\n",
+			expected: []Heredoc{
+				{Line: 1, Target: "file.sh", Body: "echo hi\n", bodyStart: 1, bodyEnd: 2, indent: "\t\t"},
+			},
+		},
+		"heredoc piped rather than written to a file": {
+			input:    "cat <<EOF | kubectl apply -f -\nkind: Secret\nEOF\n",
+			expected: nil,
+		},
+		"heredocs inside another heredoc's body are ignored": {
+			input: "cat <<OUTER > gen.sh\ncat <<EOF > inner.go\nOUTER\n",
+			expected: []Heredoc{
+				{Line: 1, Target: "gen.sh", Body: "cat <<EOF > inner.go\n", bodyStart: 1, bodyEnd: 2},
+			},
+		},
+		"stderr redirect isn't a target": {
+			input:    "cat <<EOF >&2\nerror\nEOF\n",
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			heredocs := FindHeredocs(test.input)
+
+			if !reflect.DeepEqual(heredocs, test.expected) {
+				t.Errorf("got %+v, wanted %+v", heredocs, test.expected)
+			}
+		})
+	}
+}
+
+func Test_ReplaceHeredocBody(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		body     string
+		expected string
+	}{
+		"heredoc": {
+			input:    "#!/bin/sh\ncat <<EOF > out/file.go\npackage main\nEOF\necho done\n",
+			body:     "// header\n\npackage main\n",
+			expected: "#!/bin/sh\ncat <<EOF > out/file.go\n// header\n\npackage main\nEOF\necho done\n",
+		},
+		"tab stripping": {
+			input:    "\tcat <<-EOF >file.sh\n\t\techo hi\n\tEOF\n",
+			body:     "# header\n\necho hi\n",
+			expected: "\tcat <<-EOF >file.sh\n\t\t# header\n\n\t\techo hi\n\tEOF\n",
+		},
+		"CRLF line endings": {
+			input:    "cat <<EOF > file.go\r\npackage main\r\nEOF\r\n",
+			body:     "// header\npackage main\n",
+			expected: "cat <<EOF > file.go\r\n// header\r\npackage main\r\nEOF\r\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			heredocs := FindHeredocs(test.input)
+			if len(heredocs) != 1 {
+				t.Fatalf("expected 1 heredoc but found %d", len(heredocs))
+			}
+
+			if got := ReplaceHeredocBody(test.input, heredocs[0], test.body); got != test.expected {
+				t.Errorf("got %q, wanted %q", got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"regexp"
+	"strings"
+)
+
+// WithIgnoreYear returns a copy of the template, and of each of its alternatives, which accepts
+// anything in place of the <<YEAR>> marker, so that only the rest of each header is compared. The
+// year given to Fix is still used when adding boilerplate to files.
+func (t BoilerplateTemplate) WithIgnoreYear() BoilerplateTemplate {
+	t.ignoreYear = true
+
+	alternatives := make([]BoilerplateTemplate, len(t.alternatives))
+	for i, alternative := range t.alternatives {
+		alternatives[i] = alternative.WithIgnoreYear()
+	}
+
+	t.alternatives = alternatives
+
+	return t
+}
+
+// WithIgnoreYear returns a copy of the map in which every template accepts anything in place of
+// the year marker
+func (tm TemplateMap) WithIgnoreYear() TemplateMap {
+	out := make(TemplateMap, len(tm))
+
+	for name, tmpl := range tm {
+		out[name] = tmpl.WithIgnoreYear()
+	}
+
+	return out
+}
+
+// normalizeIgnoredYears replaces each line of the normalized contents which matches the template
+// line in the same position, with anything in place of the year, with that template line, so that
+// it matches the template. Lines are only replaced if the template ignores the year.
+func (t BoilerplateTemplate) normalizeIgnoredYears(contents string) string {
+	if !t.ignoreYear {
+		return contents
+	}
+
+	lines := strings.Split(contents, "\n")
+	replacedLines := strings.Split(t.replaced, "\n")
+
+	for i, replacedLine := range replacedLines {
+		if i >= len(lines) {
+			break
+		}
+
+		if YearMarkerRegex.MatchString(replacedLine) && t.matchesIgnoringYear(lines[i], i) {
+			lines[i] = replacedLine
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// matchesIgnoringYear returns true if the given line of a file matches the template line at index,
+// or the only line of a one-line template, with anything in place of the year. Lines of templates
+// which don't ignore the year never match.
+func (t BoilerplateTemplate) matchesIgnoringYear(line string, index int) bool {
+	if !t.ignoreYear {
+		return false
+	}
+
+	// the author can be anything matching the author regex, if there is one
+	templateLines := strings.Split(t.replaced, "\n")
+	if t.authorRegex != nil {
+		templateLines = strings.Split(t.raw, "\n")
+	}
+
+	if t.kind == TemplateKindLine {
+		line, templateLines, index = strings.TrimSpace(line), []string{strings.TrimSpace(strings.Join(templateLines, "\n"))}, 0
+	}
+
+	if index >= len(templateLines) || !YearMarkerRegex.MatchString(templateLines[index]) {
+		return false
+	}
+
+	yearParts := YearMarkerRegex.Split(templateLines[index], -1)
+
+	for i, yearPart := range yearParts {
+		authorParts := AuthorMarkerRegex.Split(yearPart, -1)
+		for j, authorPart := range authorParts {
+			authorParts[j] = regexp.QuoteMeta(authorPart)
+		}
+
+		if t.authorRegex != nil {
+			yearParts[i] = strings.Join(authorParts, "(?:"+t.authorRegex.String()+")")
+		} else {
+			yearParts[i] = strings.Join(authorParts, "")
+		}
+	}
+
+	return regexp.MustCompile("^" + strings.Join(yearParts, ".*") + "$").MatchString(line)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"regexp"
+	"testing"
+)
+
+func Test_IgnoreYear(t *testing.T) {
+	const rest = "\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n"
+
+	lineTemplate, err := NewBoilerplateTemplate("# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n", BoilerplateTemplateConfiguration{ExpectedAuthor: "cert-manager"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withAuthorRegex, err := mustTestTemplate(t).WithAuthorRegex(regexp.MustCompile("cert-manager|Jetstack"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		tmpl  BoilerplateTemplate
+		input string
+
+		expectErr bool
+	}{
+		"valid year": {
+			tmpl:  mustTestTemplate(t),
+			input: "# Copyright 2024 The cert-manager Authors." + rest,
+		},
+		"unusual year": {
+			tmpl:  mustTestTemplate(t),
+			input: "# Copyright 1999-present The cert-manager Authors." + rest,
+		},
+		"placeholder instead of a year": {
+			tmpl:  mustTestTemplate(t),
+			input: "# Copyright YEAR The cert-manager Authors." + rest,
+		},
+		"wrong author": {
+			tmpl:      mustTestTemplate(t),
+			input:     "# Copyright 1999 The Kubernetes Authors." + rest,
+			expectErr: true,
+		},
+		"wrong text after the year": {
+			tmpl:      mustTestTemplate(t),
+			input:     "# Copyright 1999 The cert-manager Authors" + rest,
+			expectErr: true,
+		},
+		"other author matching the author regex": {
+			tmpl:  withAuthorRegex,
+			input: "# Copyright 1999 The Jetstack Authors." + rest,
+		},
+		"one-line template": {
+			tmpl:  lineTemplate,
+			input: "root = true\n# Copyright 1999 The cert-manager Authors.\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.tmpl.WithIgnoreYear().Validate(test.input); (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// fragmentExt is the extension of files which hold content for templates to include, rather than
+// being templates themselves
+const fragmentExt = ".boilerfrag"
+
+// resolveIncludes replaces each include marker in raw with the contents of the named fragment from
+// templateDir, which can include other fragments in turn. Each line of the fragment is given
+// whatever comes before the marker on its line, usually a comment prefix, so that one fragment can
+// be shared by templates for languages which comment in different ways. The marker must be the last
+// thing on its line. fragments holds the contents of fragments to use in place of any with the same
+// name in templateDir. including lists the fragments which are already being included, to catch
+// cycles.
+func resolveIncludes(templateDir fs.FS, raw string, fragments map[string]string, including []string) (string, error) {
+	lines := strings.Split(raw, "\n")
+
+	var out []string
+
+	for _, line := range lines {
+		loc := IncludeMarkerRegex.FindStringSubmatchIndex(line)
+		if loc == nil {
+			out = append(out, line)
+			continue
+		}
+
+		if strings.TrimSpace(line[loc[1]:]) != "" {
+			return "", fmt.Errorf("include marker %q must be at the end of its line", line[loc[0]:loc[1]])
+		}
+
+		name := line[loc[2]:loc[3]]
+
+		for _, parent := range including {
+			if parent == name {
+				return "", fmt.Errorf("fragment %q includes itself", name)
+			}
+		}
+
+		contents, ok := fragments[name]
+		if !ok {
+			contentsBytes, err := fs.ReadFile(templateDir, name+fragmentExt)
+			if err != nil {
+				return "", fmt.Errorf("failed to include fragment %q: %w", name, err)
+			}
+
+			contents = string(contentsBytes)
+		}
+
+		fragment, err := resolveIncludes(templateDir, strings.TrimRight(contents, "\n"), fragments, append(including, name))
+		if err != nil {
+			return "", err
+		}
+
+		prefix := line[:loc[0]]
+
+		for _, fragmentLine := range strings.Split(fragment, "\n") {
+			if fragmentLine == "" {
+				out = append(out, strings.TrimRight(prefix, " \t"))
+			} else {
+				out = append(out, prefix+fragmentLine)
+			}
+		}
+	}
+
+	return strings.Join(out, "\n"), nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func Test_resolveIncludes(t *testing.T) {
+	templateDir := fstest.MapFS{
+		"notice.boilerfrag": {Data: []byte("Licensed under the Example License.\n\n<<INCLUDE url>>\n")},
+		"url.boilerfrag":    {Data: []byte("See https://example.com/license\n")},
+		"loop.boilerfrag":   {Data: []byte("<<INCLUDE loop>>\n")},
+	}
+
+	tests := map[string]struct {
+		raw       string
+		fragments map[string]string
+
+		expected  string
+		expectErr bool
+	}{
+		"no includes": {
+			raw:      "# Copyright <<YEAR>> <<AUTHOR>>\n",
+			expected: "# Copyright <<YEAR>> <<AUTHOR>>\n",
+		},
+		"commented include": {
+			raw:      "# Copyright <<YEAR>> <<AUTHOR>>\n#\n# <<INCLUDE notice>>\n\n",
+			expected: "# Copyright <<YEAR>> <<AUTHOR>>\n#\n# Licensed under the Example License.\n#\n# See https://example.com/license\n\n",
+		},
+		"uncommented include": {
+			raw:      "/*\nCopyright <<YEAR>> <<AUTHOR>>\n\n<<INCLUDE notice>>\n*/\n",
+			expected: "/*\nCopyright <<YEAR>> <<AUTHOR>>\n\nLicensed under the Example License.\n\nSee https://example.com/license\n*/\n",
+		},
+		"replaced fragment": {
+			raw:       "# <<INCLUDE notice>>\n",
+			fragments: map[string]string{"url": "See LICENSE\n"},
+			expected:  "# Licensed under the Example License.\n#\n# See LICENSE\n",
+		},
+		"fragment which only exists as a replacement": {
+			raw:       "# <<INCLUDE other>>\n",
+			fragments: map[string]string{"other": "Another notice.\n"},
+			expected:  "# Another notice.\n",
+		},
+		"unknown fragment": {
+			raw:       "# <<INCLUDE missing>>\n",
+			expectErr: true,
+		},
+		"cycle": {
+			raw:       "# <<INCLUDE loop>>\n",
+			expectErr: true,
+		},
+		"marker not at end of line": {
+			raw:       "/* <<INCLUDE url>> */\n",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resolved, err := resolveIncludes(templateDir, test.raw, test.fragments, nil)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if resolved != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, resolved)
+			}
+		})
+	}
+}
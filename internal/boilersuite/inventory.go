@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"strings"
+)
+
+// licenseNotices maps text which only appears in the standard notice for a license to the SPDX
+// identifier of that license, for files which have a notice but no SPDX tag
+var licenseNotices = []struct {
+	text string
+	id   string
+}{
+	{text: "Licensed under the Apache License, Version 2.0", id: "Apache-2.0"},
+	{text: "subject to the terms of the Mozilla Public License, v. 2.0", id: "MPL-2.0"},
+	{text: "GNU Affero General Public License", id: "AGPL-3.0"},
+	{text: "GNU Lesser General Public License", id: "LGPL"},
+	{text: "GNU General Public License", id: "GPL"},
+	{text: "Permission is hereby granted, free of charge", id: "MIT"},
+	{text: "governed by a BSD-style license", id: "BSD-3-Clause"},
+	{text: "governed by an MIT-style license", id: "MIT"},
+}
+
+// copyrightHolderTrimChars are removed from either end of a copyright holder
+const copyrightHolderTrimChars = " \t.,;:-"
+
+// CopyrightInfo is the copyright and licensing information found in a file, for taking an
+// inventory of files whose headers aren't necessarily in the expected form
+type CopyrightInfo struct {
+	// Holders holds each copyright holder, such as "The cert-manager Authors"
+	Holders []string
+
+	// Years holds each year or range of years in a copyright notice, such as "2019-2024"
+	Years []string
+
+	// Licenses holds the SPDX expression from each "SPDX-License-Identifier" tag, or the SPDX
+	// identifier of each license whose standard notice was found if there were no tags
+	Licenses []string
+}
+
+// FindCopyrightInfo returns the copyright holders, years and licenses found in the given contents,
+// which are usually the start of a file
+func FindCopyrightInfo(contents string) CopyrightInfo {
+	reuse := FindREUSEInfo(contents)
+
+	info := CopyrightInfo{
+		Licenses: reuse.Licenses,
+	}
+
+	for _, notice := range reuse.Copyrights {
+		info.Years = append(info.Years, CopyrightYearsRegex.FindAllString(notice, -1)...)
+
+		if holder := copyrightHolder(notice); holder != "" {
+			info.Holders = append(info.Holders, holder)
+		}
+	}
+
+	if len(info.Licenses) == 0 {
+		for _, notice := range licenseNotices {
+			if strings.Contains(contents, notice.text) {
+				info.Licenses = append(info.Licenses, notice.id)
+			}
+		}
+	}
+
+	info.Holders = dedupe(info.Holders)
+	info.Years = dedupe(info.Years)
+	info.Licenses = dedupe(info.Licenses)
+
+	return info
+}
+
+// ExpectedHolder returns the copyright holder named in the template, such as "The cert-manager
+// Authors", in the same form as the holders returned by FindCopyrightInfo. It's empty if the
+// template has no copyright notice.
+func (t BoilerplateTemplate) ExpectedHolder() string {
+	// any year will do, since it isn't part of the holder
+	info := FindCopyrightInfo(YearMarkerRegex.ReplaceAllString(t.replaced, "2000"))
+	if len(info.Holders) == 0 {
+		return ""
+	}
+
+	return info.Holders[0]
+}
+
+// copyrightHolder returns the holder named in a copyright notice, without any years or copyright
+// symbols, e.g. "Jane Doe" for "(c) 2024 Jane Doe. All rights reserved."
+func copyrightHolder(notice string) string {
+	holder := CopyrightYearsRegex.ReplaceAllString(notice, "")
+
+	for _, symbol := range []string{"(c)", "(C)", "©", "All rights reserved", "Copyright"} {
+		holder = strings.ReplaceAll(holder, symbol, "")
+	}
+
+	return strings.Join(strings.Fields(strings.Trim(holder, copyrightHolderTrimChars)), " ")
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_FindCopyrightInfo(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected CopyrightInfo
+	}{
+		"apache header": {
+			input: "/*\nCopyright 2023 The cert-manager Authors.\n\nLicensed under the Apache License, Version 2.0 (the \"License\");\n*/\n",
+			expected: CopyrightInfo{
+				Holders:  []string{"The cert-manager Authors"},
+				Years:    []string{"2023"},
+				Licenses: []string{"Apache-2.0"},
+			},
+		},
+		"SPDX tags take precedence over notices": {
+			input: "// SPDX-FileCopyrightText: 2019-2024 Jane Doe <jane@example.com>\n// SPDX-License-Identifier: MIT OR Apache-2.0\n// Licensed under the Apache License, Version 2.0\n",
+			expected: CopyrightInfo{
+				Holders:  []string{"Jane Doe <jane@example.com>"},
+				Years:    []string{"2019-2024"},
+				Licenses: []string{"MIT OR Apache-2.0"},
+			},
+		},
+		"several holders": {
+			input: "# Copyright (c) 2020, 2021 Example Corp. All rights reserved.\n# Copyright © 2022 Jane Doe\n# Use of this source code is governed by a BSD-style license\n",
+			expected: CopyrightInfo{
+				Holders:  []string{"Example Corp", "Jane Doe"},
+				Years:    []string{"2020", "2021", "2022"},
+				Licenses: []string{"BSD-3-Clause"},
+			},
+		},
+		"no information": {
+			input:    "package main\n",
+			expected: CopyrightInfo{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := FindCopyrightInfo(test.input)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("got %#v, wanted %#v", got, test.expected)
+			}
+		})
+	}
+}
+
+func Test_ExpectedHolder(t *testing.T) {
+	tmpl := mustTestTemplate(t)
+
+	if holder := tmpl.ExpectedHolder(); holder != "The cert-manager Authors" {
+		t.Errorf("got holder %q, wanted %q", holder, "The cert-manager Authors")
+	}
+}
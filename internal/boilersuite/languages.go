@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"regexp"
+	"strings"
+)
+
+// languageConfig holds the extra configuration needed by the templates for some languages
+type languageConfig struct {
+	normalizationFunc func(string) string
+	preambleRegex     *regexp.Regexp
+
+	boilerplateBeforePreamble bool
+}
+
+// languages maps the name of a template, without any variant suffix such as "-old", to the extra
+// configuration used for files in that language
+var languages = map[string]languageConfig{
+	"go":    {normalizationFunc: normalizeGoFile},
+	"sh":    {normalizationFunc: normalizeShebang, preambleRegex: ShebangRegex},
+	"bash":  {normalizationFunc: normalizeShebang, preambleRegex: ShebangRegex},
+	"py":    {normalizationFunc: normalizePython, preambleRegex: PythonPreambleRegex},
+	"rb":    withPreamble(RubyPreambleRegex),
+	"php":   withPreamble(PHPPreambleRegex),
+	"xml":   withPreamble(XMLDeclarationRegex),
+	"md":    withPreamble(FrontMatterRegex),
+	"kts":   withPreamble(ShebangRegex),
+	"js":    withPreamble(ShebangRegex),
+	"jsx":   withPreamble(ShebangRegex),
+	"mjs":   withPreamble(ShebangRegex),
+	"cjs":   withPreamble(ShebangRegex),
+	"ts":    withPreamble(ShebangRegex),
+	"tsx":   withPreamble(ShebangRegex),
+	"lua":   withPreamble(ShebangRegex),
+	"swift": withPreamble(ShebangRegex),
+	"exs":   withPreamble(ShebangRegex),
+	"c":     {normalizationFunc: normalizeCFile},
+	"h":     {normalizationFunc: normalizeCFile},
+	"cc":    {normalizationFunc: normalizeCFile},
+	"cpp":   {normalizationFunc: normalizeCFile},
+	"hpp":   {normalizationFunc: normalizeCFile},
+	"tpl":   {normalizationFunc: normalizeHelmTemplate},
+	"rs":    withPreamble(RustShebangRegex),
+
+	// Jenkinsfiles often start with "#!groovy" to tell editors what they contain
+	"groovy":      withPreamble(ShebangRegex),
+	"Jenkinsfile": withPreamble(ShebangRegex),
+
+	// HTML comments can come before the doctype, so boilerplate can be either side of it
+	"html": withPreambleBeforeOrAfter(DoctypeRegex),
+
+	// YAML comments can come before directives and the document start marker, so boilerplate can
+	// be either side of them
+	"yaml": withPreambleBeforeOrAfter(YAMLDocumentStartRegex),
+	"yml":  withPreambleBeforeOrAfter(YAMLDocumentStartRegex),
+
+	// HCL has both "#" and "//" comments, and boilerplate can use either
+	"tf":     {normalizationFunc: normalizeHCLFile},
+	"tfvars": {normalizationFunc: normalizeHCLFile},
+	"hcl":    {normalizationFunc: normalizeHCLFile},
+
+	// PowerShell "#Requires" statements can be anywhere in a script, so boilerplate can be either
+	// side of them
+	"ps1":  withPowerShellRequires(),
+	"psm1": withPowerShellRequires(),
+}
+
+// frontMatterLanguage is the language whose files can start with YAML front matter
+const frontMatterLanguage = "md"
+
+// languageOf returns the language of the template with the given name. Variants of a template,
+// such as "go-old", have the same language as the original.
+func languageOf(name string) string {
+	return strings.SplitN(name, "-", 2)[0]
+}
+
+// withPreamble returns the configuration for a language whose files can start with content
+// matching preambleRegex, which must stay above the boilerplate
+func withPreamble(preambleRegex *regexp.Regexp) languageConfig {
+	return languageConfig{
+		normalizationFunc: stripPreamble(preambleRegex),
+		preambleRegex:     preambleRegex,
+	}
+}
+
+// withPreambleBeforeOrAfter returns the configuration for a language whose files can start with
+// content matching preambleRegex, where boilerplate is accepted either above or below it
+func withPreambleBeforeOrAfter(preambleRegex *regexp.Regexp) languageConfig {
+	config := withPreamble(preambleRegex)
+	config.boilerplateBeforePreamble = true
+
+	return config
+}
+
+// withPowerShellRequires returns the configuration for PowerShell, whose boilerplate can be
+// written with either "#" line comments or a "<# ... #>" block comment
+func withPowerShellRequires() languageConfig {
+	config := withPreambleBeforeOrAfter(PowerShellRequiresRegex)
+
+	stripRequires := config.normalizationFunc
+	config.normalizationFunc = func(raw string) string {
+		return normalizePowerShellBlockComment(stripRequires(raw))
+	}
+
+	return config
+}
+
+// stripPreamble returns a normalization function which removes content matching preambleRegex
+// from the start of a file
+func stripPreamble(preambleRegex *regexp.Regexp) func(string) string {
+	return func(raw string) string {
+		raw = strings.TrimLeft(raw, "\n")
+
+		if loc := preambleRegex.FindStringIndex(raw); loc != nil && loc[0] == 0 {
+			return raw[loc[1]:]
+		}
+
+		return raw
+	}
+}
@@ -0,0 +1,524 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+)
+
+func Test_Languages(t *testing.T) {
+	templates, err := LoadTemplates(boilerplatetemplates.FS, "cert-manager")
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	// header returns the boilerplate for the named template, followed by a blank line
+	header := func(name string) string {
+		tmpl, ok := templates[name]
+		if !ok {
+			t.Fatalf("no template named %q", name)
+		}
+
+		return YearMarkerRegex.ReplaceAllString(tmpl.replaced, "2026")
+	}
+
+	tests := map[string]struct {
+		path      string
+		input     string
+		expectErr bool
+
+		// fixed is the expected result of fixing the input, if it's set
+		fixed string
+	}{
+		"php after the opening tag": {
+			path:  "index.php",
+			input: "<?php\n\n" + header("php") + "echo 'hello';\n",
+		},
+		"php after a strict types declaration": {
+			path:  "index.php",
+			input: "<?php\ndeclare(strict_types=1);\n\n" + header("php") + "echo 'hello';\n",
+		},
+		"php before a strict types declaration": {
+			path:  "index.php",
+			input: "<?php\n\n" + header("php") + "declare(strict_types=1);\n",
+		},
+		"php before the opening tag": {
+			path:      "index.php",
+			input:     header("php") + "<?php\necho 'hello';\n",
+			expectErr: true,
+			fixed:     "<?php\n\n" + header("php") + "echo 'hello';\n",
+		},
+		"php missing boilerplate": {
+			path:      "index.php",
+			input:     "<?php\ndeclare(strict_types=1);\necho 'hello';\n",
+			expectErr: true,
+			fixed:     "<?php\ndeclare(strict_types=1);\n\n" + header("php") + "echo 'hello';\n",
+		},
+		"xml after the declaration": {
+			path:  "pom.xml",
+			input: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" + header("xml") + "<project/>\n",
+		},
+		"xml without a declaration": {
+			path:  "pom.xml",
+			input: header("xml") + "<project/>\n",
+		},
+		"xml before the declaration": {
+			path:      "pom.xml",
+			input:     header("xml") + "<?xml version=\"1.0\"?>\n<project/>\n",
+			expectErr: true,
+			fixed:     "<?xml version=\"1.0\"?>\n\n" + header("xml") + "<project/>\n",
+		},
+		"xml with a skip marker after the declaration": {
+			path:  "pom.xml",
+			input: "<?xml version=\"1.0\"?>\n<!-- +skip_license_check -->\n<project/>\n",
+		},
+		"generated xml": {
+			path:  "config/crd/bases/example.xml",
+			input: "<?xml version=\"1.0\"?>\n<!-- Code generated by controller-gen. DO NOT EDIT. -->\n<project/>\n",
+		},
+		"xml missing boilerplate": {
+			path:      "pom.xml",
+			input:     "<?xml version=\"1.0\"?>\n<project/>\n",
+			expectErr: true,
+			fixed:     "<?xml version=\"1.0\"?>\n\n" + header("xml") + "<project/>\n",
+		},
+		"html before the doctype": {
+			path:  "index.html",
+			input: header("html") + "<!DOCTYPE html>\n<html></html>\n",
+		},
+		"html after the doctype": {
+			path:  "index.html",
+			input: "<!doctype html>\n" + header("html") + "<html></html>\n",
+		},
+		"html missing boilerplate": {
+			path:      "index.html",
+			input:     "<!DOCTYPE html>\n<html></html>\n",
+			expectErr: true,
+			fixed:     "<!DOCTYPE html>\n\n" + header("html") + "<html></html>\n",
+		},
+		"html after other content": {
+			path:      "index.html",
+			input:     "<!DOCTYPE html>\n<html>\n" + header("html") + "</html>\n",
+			expectErr: true,
+		},
+		"html with a skip marker after the doctype": {
+			path:  "index.html",
+			input: "<!DOCTYPE html>\n<!-- +skip_license_check reason=\"copied from upstream\" -->\n<html></html>\n",
+		},
+		"generated html": {
+			path:  "docs/api.html",
+			input: "<!DOCTYPE html>\n<!-- Code generated by gen-crd-api-reference-docs. DO NOT EDIT. -->\n<html></html>\n",
+		},
+		"markdown": {
+			path:  "README.md",
+			input: header("md") + "# Example\n",
+		},
+		"markdown after front matter": {
+			path:  "docs/index.md",
+			input: "---\ntitle: Example\n---\n" + header("md") + "# Example\n",
+		},
+		"markdown with a skip marker": {
+			path:  "docs/vendored.md",
+			input: "# Vendored\n\n<!-- +skip_license_check -->\n",
+		},
+		"markdown missing boilerplate after front matter": {
+			path:      "docs/index.md",
+			input:     "---\ntitle: Example\n---\n# Example\n",
+			expectErr: true,
+			fixed:     "---\ntitle: Example\n---\n\n" + header("md") + "# Example\n",
+		},
+		"c": {
+			path:  "src/main.c",
+			input: header("c") + "#include <stdio.h>\n",
+		},
+		"c header with include guard straight after boilerplate": {
+			path:  "include/example.h",
+			input: strings.TrimSuffix(header("h"), "\n") + "#ifndef EXAMPLE_H\n#define EXAMPLE_H\n#endif\n",
+		},
+		"c++ header with pragma once straight after boilerplate": {
+			path:  "include/example.hpp",
+			input: strings.TrimSuffix(header("hpp"), "\n") + "#pragma once\n\nclass Example {};\n",
+		},
+		"c++ missing boilerplate": {
+			path:      "src/example.cc",
+			input:     "#pragma once\n\nclass Example {};\n",
+			expectErr: true,
+			fixed:     header("cc") + "#pragma once\n\nclass Example {};\n",
+		},
+		"c++ other code straight after boilerplate": {
+			path:      "src/example.cpp",
+			input:     strings.TrimSuffix(header("cpp"), "\n") + "#include <vector>\n",
+			expectErr: true,
+		},
+		"java": {
+			path:  "src/main/java/io/cert_manager/Example.java",
+			input: header("java") + "package io.cert_manager;\n\npublic class Example {}\n",
+		},
+		"java boilerplate below the package declaration": {
+			path:      "src/main/java/io/cert_manager/Example.java",
+			input:     "package io.cert_manager;\n\n" + header("java") + "public class Example {}\n",
+			expectErr: true,
+			fixed:     header("java") + "package io.cert_manager;\n\npublic class Example {}\n",
+		},
+		"kotlin before file annotations": {
+			path:  "src/main/kotlin/Example.kt",
+			input: header("kt") + "@file:JvmName(\"Example\")\n\npackage io.cert_manager\n",
+		},
+		"kotlin missing boilerplate above file annotations": {
+			path:      "src/main/kotlin/Example.kt",
+			input:     "@file:JvmName(\"Example\")\n\npackage io.cert_manager\n",
+			expectErr: true,
+			fixed:     header("kt") + "@file:JvmName(\"Example\")\n\npackage io.cert_manager\n",
+		},
+		"kotlin boilerplate below file annotations": {
+			path:      "src/main/kotlin/Example.kt",
+			input:     "@file:JvmName(\"Example\")\n\n" + header("kt") + "package io.cert_manager\n",
+			expectErr: true,
+			fixed:     header("kt") + "@file:JvmName(\"Example\")\n\npackage io.cert_manager\n",
+		},
+		"kotlin script after shebang": {
+			path:  "build.main.kts",
+			input: "#!/usr/bin/env kotlin\n" + header("kts") + "println(\"hello\")\n",
+		},
+		"javascript": {
+			path:  "web/app.jsx",
+			input: header("jsx") + "export default function App() {}\n",
+		},
+		"javascript after node shebang": {
+			path:  "bin/cli.mjs",
+			input: "#!/usr/bin/env node\n" + header("mjs") + "console.log(\"hello\");\n",
+		},
+		"javascript missing boilerplate after node shebang": {
+			path:      "bin/cli.js",
+			input:     "#!/usr/bin/env node\nconsole.log(\"hello\");\n",
+			expectErr: true,
+			fixed:     "#!/usr/bin/env node\n\n" + header("js") + "console.log(\"hello\");\n",
+		},
+		"typescript boilerplate above node shebang": {
+			path:      "bin/cli.ts",
+			input:     header("ts") + "#!/usr/bin/env node\nconsole.log(\"hello\");\n",
+			expectErr: true,
+			fixed:     "#!/usr/bin/env node\n\n" + header("ts") + "console.log(\"hello\");\n",
+		},
+		"ruby after shebang and magic comment": {
+			path:  "bin/example.rb",
+			input: "#!/usr/bin/env ruby\n# frozen_string_literal: true\n\n" + header("rb") + "puts 'hello'\n",
+		},
+		"ruby missing boilerplate after magic comments": {
+			path:      "lib/example.rb",
+			input:     "# encoding: utf-8\n# frozen_string_literal: true\n\nmodule Example; end\n",
+			expectErr: true,
+			fixed:     "# encoding: utf-8\n# frozen_string_literal: true\n\n" + header("rb") + "module Example; end\n",
+		},
+		"ruby boilerplate above magic comment": {
+			path:      "lib/example.rb",
+			input:     header("rb") + "# frozen_string_literal: true\n\nmodule Example; end\n",
+			expectErr: true,
+			fixed:     "# frozen_string_literal: true\n\n" + header("rb") + "module Example; end\n",
+		},
+		"terraform with hash comments": {
+			path:  "infra/main.tf",
+			input: header("tf") + "resource \"null_resource\" \"example\" {}\n",
+		},
+		"terraform with slash comments": {
+			path:  "infra/prod.tfvars",
+			input: strings.ReplaceAll(header("tfvars"), "#", "//") + "region = \"eu-west-1\"\n",
+		},
+		"hcl missing boilerplate": {
+			path:      "config.hcl",
+			input:     "// settings\nlisten = \":8080\"\n",
+			expectErr: true,
+			fixed:     header("hcl") + "// settings\nlisten = \":8080\"\n",
+		},
+		"protobuf": {
+			path:  "api/v1/example.proto",
+			input: header("proto") + "syntax = \"proto3\";\n\npackage example.v1;\n",
+		},
+		"protobuf boilerplate below the syntax declaration": {
+			path:      "api/v1/example.proto",
+			input:     "syntax = \"proto3\";\n\n" + header("proto") + "package example.v1;\n",
+			expectErr: true,
+			fixed:     header("proto") + "syntax = \"proto3\";\n\npackage example.v1;\n",
+		},
+		"sql": {
+			path:  "migrations/0001_init.up.sql",
+			input: header("sql") + "CREATE TABLE example (id INT);\n",
+		},
+		"sql missing boilerplate": {
+			path:      "migrations/0001_init.up.sql",
+			input:     "-- create the example table\nCREATE TABLE example (id INT);\n",
+			expectErr: true,
+			fixed:     header("sql") + "-- create the example table\nCREATE TABLE example (id INT);\n",
+		},
+		"powershell": {
+			path:  "hack/build.ps1",
+			input: header("ps1") + "Write-Output \"hello\"\n",
+		},
+		"powershell after requires": {
+			path:  "hack/build.ps1",
+			input: "#Requires -Version 7.0\n#Requires -Modules Pester\n\n" + header("ps1") + "Write-Output \"hello\"\n",
+		},
+		"powershell before requires": {
+			path:  "hack/build.ps1",
+			input: header("ps1") + "#Requires -Version 7.0\n\nWrite-Output \"hello\"\n",
+		},
+		"powershell block comment": {
+			path: "hack/Example.psm1",
+			input: "<#\n" + regexp.MustCompile(`(?m)^# ?`).ReplaceAllString(strings.TrimSuffix(header("psm1"), "\n"), "") + "#>\n\n" +
+				"function Get-Example {}\n",
+		},
+		"powershell missing boilerplate after requires": {
+			path:      "hack/build.ps1",
+			input:     "#Requires -Version 7.0\nWrite-Output \"hello\"\n",
+			expectErr: true,
+			fixed:     "#Requires -Version 7.0\n\n" + header("ps1") + "Write-Output \"hello\"\n",
+		},
+		"bazel build file": {
+			path:  "pkg/BUILD",
+			input: header("BUILD") + "load(\"@rules_go//go:def.bzl\", \"go_library\")\n",
+		},
+		"bazel build file with extension": {
+			path:      "pkg/BUILD.bazel",
+			input:     "go_library(name = \"example\")\n",
+			expectErr: true,
+			fixed:     header("BUILD.bazel") + "go_library(name = \"example\")\n",
+		},
+		"bazel workspace": {
+			path:  "WORKSPACE",
+			input: header("WORKSPACE") + "workspace(name = \"example\")\n",
+		},
+		"bazel module": {
+			path:  "MODULE.bazel",
+			input: header("bazel") + "module(name = \"example\")\n",
+		},
+		"starlark extension": {
+			path:  "tools/defs.bzl",
+			input: header("bzl") + "def example():\n    pass\n",
+		},
+		"tiltfile": {
+			path:  "Tiltfile",
+			input: header("Tiltfile") + "k8s_yaml(\"deploy.yaml\")\n",
+		},
+		"jsonnet": {
+			path:  "dashboards/main.jsonnet",
+			input: header("jsonnet") + "local lib = import \"lib.libsonnet\";\n",
+		},
+		"jsonnet library missing boilerplate": {
+			path:      "dashboards/lib.libsonnet",
+			input:     "{\n  name: \"example\",\n}\n",
+			expectErr: true,
+			fixed:     header("libsonnet") + "{\n  name: \"example\",\n}\n",
+		},
+		"groovy": {
+			path:  "src/Example.groovy",
+			input: header("groovy") + "class Example {}\n",
+		},
+		"jenkinsfile after shebang": {
+			path:  "Jenkinsfile",
+			input: "#!groovy\n" + header("Jenkinsfile") + "pipeline {}\n",
+		},
+		"jenkinsfile variant missing boilerplate": {
+			path:      "ci/Jenkinsfile.release",
+			input:     "pipeline {}\n",
+			expectErr: true,
+			fixed:     header("Jenkinsfile") + "pipeline {}\n",
+		},
+		"lua": {
+			path:  "filters/auth.lua",
+			input: header("lua") + "function envoy_on_request(handle)\nend\n",
+		},
+		"lua missing boilerplate after shebang": {
+			path:      "scripts/example.lua",
+			input:     "#!/usr/bin/env lua\nprint(\"hello\")\n",
+			expectErr: true,
+			fixed:     "#!/usr/bin/env lua\n\n" + header("lua") + "print(\"hello\")\n",
+		},
+		"swift": {
+			path:  "Sources/Example/Example.swift",
+			input: header("swift") + "import Foundation\n",
+		},
+		"swift script missing boilerplate after shebang": {
+			path:      "scripts/release.swift",
+			input:     "#!/usr/bin/swift\nprint(\"hello\")\n",
+			expectErr: true,
+			fixed:     "#!/usr/bin/swift\n\n" + header("swift") + "print(\"hello\")\n",
+		},
+		"zig": {
+			path:  "build.zig",
+			input: header("zig") + "const std = @import(\"std\");\n",
+		},
+		"zig missing boilerplate": {
+			path:      "src/main.zig",
+			input:     "//! Example program\nconst std = @import(\"std\");\n",
+			expectErr: true,
+			fixed:     header("zig") + "//! Example program\nconst std = @import(\"std\");\n",
+		},
+		"cmake lists": {
+			path:  "native/CMakeLists.txt",
+			input: header("CMakeLists.txt") + "cmake_minimum_required(VERSION 3.20)\n",
+		},
+		"cmake module missing boilerplate": {
+			path:      "native/cmake/FindExample.cmake",
+			input:     "find_path(EXAMPLE_INCLUDE_DIR example.h)\n",
+			expectErr: true,
+			fixed:     header("cmake") + "find_path(EXAMPLE_INCLUDE_DIR example.h)\n",
+		},
+		"helm template": {
+			path:  "deploy/charts/example/templates/_helpers.tpl",
+			input: header("tpl") + "{{- define \"example.name\" -}}example{{- end }}\n",
+		},
+		"helm template without whitespace trimming": {
+			path: "deploy/charts/example/templates/_helpers.tpl",
+			input: strings.NewReplacer("{{- /*", "{{/*", "*/ -}}", "*/}}").Replace(header("tpl")) +
+				"{{- define \"example.name\" -}}example{{- end }}\n",
+		},
+		"helm template with yaml comment": {
+			path:      "deploy/charts/example/templates/_helpers.tpl",
+			input:     header("yaml") + "{{- define \"example.name\" -}}example{{- end }}\n",
+			expectErr: true,
+		},
+		"helm template with a skip marker": {
+			path:  "deploy/charts/example/templates/_vendored.tpl",
+			input: "{{- /* +skip_license_check reason=\"copied from upstream\" */ -}}\n{{- define \"example.name\" -}}example{{- end }}\n",
+		},
+		"helm template missing boilerplate": {
+			path:      "deploy/charts/example/templates/_helpers.tpl",
+			input:     "{{- define \"example.name\" -}}example{{- end }}\n",
+			expectErr: true,
+			fixed:     header("tpl") + "{{- define \"example.name\" -}}example{{- end }}\n",
+		},
+		"elixir": {
+			path:  "lib/example.ex",
+			input: header("ex") + "defmodule Example do\nend\n",
+		},
+		"elixir script after shebang": {
+			path:  "scripts/seed.exs",
+			input: "#!/usr/bin/env elixir\n" + header("exs") + "IO.puts(\"hello\")\n",
+		},
+		"erlang": {
+			path:  "src/example.erl",
+			input: header("erl") + "-module(example).\n",
+		},
+		"erlang header missing boilerplate": {
+			path:      "include/example.hrl",
+			input:     "%% records used by example\n-record(example, {name}).\n",
+			expectErr: true,
+			fixed:     header("hrl") + "%% records used by example\n-record(example, {name}).\n",
+		},
+		"yaml before the document start": {
+			path:  "deploy/manifest.yaml",
+			input: header("yaml") + "---\napiVersion: v1\n",
+		},
+		"yaml after directives and the document start": {
+			path:  "deploy/manifest.yaml",
+			input: "%YAML 1.2\n---\n" + header("yaml") + "apiVersion: v1\n",
+		},
+		"yml missing boilerplate": {
+			path:      ".github/workflows/test.yml",
+			input:     "--- # workflow\non: push\n",
+			expectErr: true,
+			fixed:     "--- # workflow\n\n" + header("yml") + "on: push\n",
+		},
+		"yaml missing boilerplate after directives": {
+			path:      "deploy/manifest.yaml",
+			input:     "%YAML 1.2\n%TAG ! tag:example.com,2026:\n---\napiVersion: v1\n",
+			expectErr: true,
+			fixed:     "%YAML 1.2\n%TAG ! tag:example.com,2026:\n---\n\n" + header("yaml") + "apiVersion: v1\n",
+		},
+		"rust before crate attributes": {
+			path:  "src/lib.rs",
+			input: header("rs") + "#![no_std]\n\npub fn f() {}\n",
+		},
+		"rust missing boilerplate above crate attributes": {
+			path:      "src/lib.rs",
+			input:     "#![no_std]\n#![allow(dead_code)]\n\npub fn f() {}\n",
+			expectErr: true,
+			fixed:     header("rs") + "#![no_std]\n#![allow(dead_code)]\n\npub fn f() {}\n",
+		},
+		"rust boilerplate below crate attributes": {
+			path:      "src/lib.rs",
+			input:     "#![no_std]\n\n" + header("rs") + "pub fn f() {}\n",
+			expectErr: true,
+			fixed:     header("rs") + "#![no_std]\n\npub fn f() {}\n",
+		},
+		"rust script after shebang": {
+			path:  "scripts/build.rs",
+			input: "#!/usr/bin/env rust-script\n" + header("rs") + "fn main() {}\n",
+		},
+		"yaml after other content": {
+			path:      "deploy/manifest.yaml",
+			input:     "---\napiVersion: v1\n" + header("yaml"),
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, ok := templates.TemplateFor(test.path)
+			if !ok {
+				t.Fatalf("no template found for %q", test.path)
+			}
+
+			err := tmpl.Validate(test.input)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if test.fixed == "" {
+				return
+			}
+
+			fixed, err := tmpl.Fix(test.input, FixOptions{Year: 2026})
+			if err != nil {
+				t.Fatalf("failed to fix file: %s", err)
+			}
+
+			if fixed != test.fixed {
+				t.Errorf("fixed=%q, expected=%q", fixed, test.fixed)
+			}
+
+			if err := tmpl.Validate(fixed); err != nil {
+				t.Errorf("fixed file isn't valid: %s", err)
+			}
+		})
+	}
+}
+
+func Test_WithFrontMatterExempt(t *testing.T) {
+	templates, err := LoadTemplates(boilerplatetemplates.FS, "cert-manager")
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	templates = templates.WithFrontMatterExempt()
+
+	if err := templates["md"].Validate("---\ntitle: Example\n---\n# Example\n"); err != nil {
+		t.Errorf("expected Markdown with front matter to be exempt but got: %s", err)
+	}
+
+	if err := templates["md"].Validate("# Example\n"); err == nil {
+		t.Errorf("expected Markdown without front matter to still be checked")
+	}
+
+	if err := templates["sh"].Validate("---\ntitle: Example\n---\necho hello\n"); err == nil {
+		t.Errorf("expected front matter to only be exempt in Markdown")
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// copyleftLicenses match the SPDX identifiers of licenses whose terms would apply to the whole of
+// a project which used them, along with licenses which restrict commercial use
+var copyleftLicenses = []string{"GPL*", "LGPL*", "AGPL*", "SSPL*", "BUSL*", "CC-BY-NC*"}
+
+// licensePolicies holds the policy for a project under each license which boilersuite has a
+// notice for, keyed by the lowercase SPDX identifier of the license
+var licensePolicies = map[string]LicensePolicy{
+	"apache-2.0":   {License: "Apache-2.0", Incompatible: copyleftLicenses},
+	"mit":          {License: "MIT", Incompatible: copyleftLicenses},
+	"bsd-2-clause": {License: "BSD-2-Clause", Incompatible: copyleftLicenses},
+	"bsd-3-clause": {License: "BSD-3-Clause", Incompatible: copyleftLicenses},
+	"mpl-2.0":      {License: "MPL-2.0", Incompatible: copyleftLicenses},
+
+	// later versions of the GPL and LGPL can be relicensed under the AGPL, but GPL-2.0-only can't
+	"agpl-3.0": {License: "AGPL-3.0", Incompatible: []string{"GPL-1.0*", "GPL-2.0", "GPL-2.0-ONLY", "SSPL*", "BUSL*", "CC-BY-NC*"}},
+}
+
+// licensePolicySearchLines is the number of lines at the start of a file in which declared licenses
+// are searched for, unless the template is longer
+const licensePolicySearchLines = 20
+
+// LicensePolicy decides which licenses files in a project may declare in their headers
+type LicensePolicy struct {
+	// License is the SPDX identifier of the project's license, e.g. "Apache-2.0"
+	License string
+
+	// Incompatible holds globs matching the SPDX identifiers of licenses which can't be used in
+	// the project, e.g. "GPL*". Identifiers are matched in upper case.
+	Incompatible []string
+}
+
+// LicensePolicyFor returns the policy for a project under the named license, e.g. "apache-2.0"
+func LicensePolicyFor(license string) (LicensePolicy, error) {
+	policy, ok := licensePolicies[strings.ToLower(license)]
+	if !ok {
+		return LicensePolicy{}, fmt.Errorf("no license policy for %q", license)
+	}
+
+	return policy, nil
+}
+
+// IncompatibleLicenses returns the identifiers of the licenses declared in contents, which are
+// usually the start of a file, that the policy doesn't allow. Licenses are taken from SPDX tags,
+// or from standard license notices if there are no tags. An SPDX expression which offers a choice
+// of licenses with "OR" is allowed if any of the choices is.
+func (p LicensePolicy) IncompatibleLicenses(contents string) []string {
+	var incompatible []string
+
+	for _, expression := range FindCopyrightInfo(contents).Licenses {
+		incompatible = append(incompatible, p.incompatibleInExpression(expression)...)
+	}
+
+	return dedupe(incompatible)
+}
+
+// incompatibleInExpression returns the incompatible identifiers in an SPDX expression, or nothing
+// if any choice offered by the expression is compatible
+func (p LicensePolicy) incompatibleInExpression(expression string) []string {
+	var incompatible []string
+
+	for _, choice := range orChoices(expression) {
+		found := p.incompatibleIn(LicenseIdentifiers(choice))
+		if len(found) == 0 {
+			return nil
+		}
+
+		incompatible = append(incompatible, found...)
+	}
+
+	return incompatible
+}
+
+// orChoices splits an SPDX expression on each "OR", ignoring any parentheses, e.g.
+// ["MIT AND BSD-3-Clause", "Apache-2.0"] for "(MIT AND BSD-3-Clause) OR Apache-2.0"
+func orChoices(expression string) []string {
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expression))
+
+	var choices []string
+	var choice []string
+
+	for _, field := range fields {
+		if strings.EqualFold(field, "OR") {
+			choices = append(choices, strings.Join(choice, " "))
+			choice = nil
+
+			continue
+		}
+
+		choice = append(choice, field)
+	}
+
+	return append(choices, strings.Join(choice, " "))
+}
+
+// incompatibleIn returns the identifiers which match one of the policy's globs
+func (p LicensePolicy) incompatibleIn(ids []string) []string {
+	var incompatible []string
+
+	for _, id := range ids {
+		for _, pattern := range p.Incompatible {
+			if MatchGlob(pattern, strings.ToUpper(id)) {
+				incompatible = append(incompatible, id)
+				break
+			}
+		}
+	}
+
+	return incompatible
+}
+
+// IncompatibleLicenseError is returned for files whose header declares a license which the
+// project's license policy doesn't allow. Such files can't be fixed by replacing their header,
+// since the code itself may not be usable in the project, and so need reviewing by a person.
+type IncompatibleLicenseError struct {
+	// Licenses holds the incompatible SPDX identifiers found in the file
+	Licenses []string
+
+	// ProjectLicense is the SPDX identifier of the project's license
+	ProjectLicense string
+}
+
+func (e *IncompatibleLicenseError) Error() string {
+	return fmt.Sprintf("declares a license which is incompatible with the project's %s license (%s); the file needs reviewing by a person and won't be fixed automatically", e.ProjectLicense, strings.Join(e.Licenses, ", "))
+}
+
+// WithLicensePolicy returns a copy of the template which reports files declaring a license that
+// the given policy doesn't allow
+func (t BoilerplateTemplate) WithLicensePolicy(policy LicensePolicy) BoilerplateTemplate {
+	t.licensePolicy = &policy
+	return t
+}
+
+// WithLicensePolicy returns a copy of the map in which every template reports files declaring a
+// license that the given policy doesn't allow
+func (tm TemplateMap) WithLicensePolicy(policy LicensePolicy) TemplateMap {
+	out := make(TemplateMap, len(tm))
+
+	for name, tmpl := range tm {
+		out[name] = tmpl.WithLicensePolicy(policy)
+	}
+
+	return out
+}
+
+// checkLicensePolicy returns an IncompatibleLicenseError if the start of raw declares a license
+// which the template's policy doesn't allow
+func (t BoilerplateTemplate) checkLicensePolicy(raw string) error {
+	if t.licensePolicy == nil {
+		return nil
+	}
+
+	limit := max(t.lineCount, licensePolicySearchLines)
+
+	lines := strings.SplitN(raw, "\n", limit+1)
+	if len(lines) > limit {
+		lines = lines[:limit]
+	}
+
+	incompatible := t.licensePolicy.IncompatibleLicenses(strings.Join(lines, "\n"))
+	if len(incompatible) == 0 {
+		return nil
+	}
+
+	return &IncompatibleLicenseError{Licenses: incompatible, ProjectLicense: t.licensePolicy.License}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_IncompatibleLicenses(t *testing.T) {
+	tests := map[string]struct {
+		license  string
+		input    string
+		expected []string
+	}{
+		"apache header in an apache project": {
+			license: "apache-2.0",
+			input:   "# Copyright 2024 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n",
+		},
+		"GPL tag in an apache project": {
+			license:  "apache-2.0",
+			input:    "// SPDX-License-Identifier: GPL-2.0-only\n",
+			expected: []string{"GPL-2.0-only"},
+		},
+		"GPL notice in an MIT project": {
+			license:  "mit",
+			input:    "# This program is free software; you can redistribute it under the terms of the\n# GNU General Public License as published by the Free Software Foundation\n",
+			expected: []string{"GPL"},
+		},
+		"choice including a compatible license": {
+			license: "apache-2.0",
+			input:   "// SPDX-License-Identifier: GPL-2.0-or-later OR MIT\n",
+		},
+		"every choice incompatible": {
+			license:  "apache-2.0",
+			input:    "// SPDX-License-Identifier: (GPL-2.0-only AND MIT) OR lgpl-3.0-only\n",
+			expected: []string{"GPL-2.0-only", "lgpl-3.0-only"},
+		},
+		"later GPL in an AGPL project": {
+			license: "agpl-3.0",
+			input:   "// SPDX-License-Identifier: GPL-3.0-or-later\n",
+		},
+		"GPL-2.0-only in an AGPL project": {
+			license:  "agpl-3.0",
+			input:    "// SPDX-License-Identifier: GPL-2.0-only\n",
+			expected: []string{"GPL-2.0-only"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy, err := LicensePolicyFor(test.license)
+			if err != nil {
+				t.Fatalf("failed to get policy: %s", err)
+			}
+
+			got := policy.IncompatibleLicenses(test.input)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("got %q, wanted %q", got, test.expected)
+			}
+		})
+	}
+}
+
+func Test_IncompatibleLicenseNotFixed(t *testing.T) {
+	policy, err := LicensePolicyFor("apache-2.0")
+	if err != nil {
+		t.Fatalf("failed to get policy: %s", err)
+	}
+
+	tmpl := mustTestTemplate(t).WithLicensePolicy(policy)
+
+	input := "#!/bin/sh\n# SPDX-License-Identifier: GPL-2.0-only\n\necho hello\n"
+
+	var incompatible *IncompatibleLicenseError
+
+	if err := tmpl.Validate(input); !errors.As(err, &incompatible) {
+		t.Errorf("expected Validate to return an IncompatibleLicenseError, got %v", err)
+	}
+
+	fixed, err := tmpl.Fix(input, FixOptions{Year: 2024})
+	if !errors.As(err, &incompatible) {
+		t.Errorf("expected Fix to return an IncompatibleLicenseError, got %v with output %q", err, fixed)
+	}
+
+	if err := mustTestTemplate(t).Validate(input); errors.As(err, &incompatible) {
+		t.Errorf("expected a template without a policy to allow any license, got %v", err)
+	}
+}
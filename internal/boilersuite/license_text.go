@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LicenseTextMatcher decides whether a LICENSE file holds the full text of a license
+type LicenseTextMatcher struct {
+	re *regexp.Regexp
+}
+
+// NewLicenseTextMatcher creates a matcher for the given license text, in which each "<<ANY>>"
+// marker matches any text. Differences in case, whitespace and heading underlines are ignored.
+func NewLicenseTextMatcher(text string) LicenseTextMatcher {
+	parts := LicenseTextWildcardRegex.Split(text, -1)
+
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(normalizeLicenseText(part))
+	}
+
+	return LicenseTextMatcher{
+		re: regexp.MustCompile(`(?s)` + strings.Join(parts, ` ?.*? ?`)),
+	}
+}
+
+// Matches reports whether contents holds the whole license text anywhere within it, so that a
+// copyright notice or title before the text is allowed
+func (m LicenseTextMatcher) Matches(contents string) bool {
+	return m.re.MatchString(normalizeLicenseText(contents))
+}
+
+// normalizeLicenseText lowercases text and collapses whitespace and heading underlines, so that
+// copies of a license which were reformatted still match
+func normalizeLicenseText(text string) string {
+	text = LicenseTextRuleRegex.ReplaceAllString(strings.ToLower(text), " ")
+
+	return strings.Join(strings.Fields(text), " ")
+}
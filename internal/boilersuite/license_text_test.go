@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import "testing"
+
+func Test_LicenseTextMatcher(t *testing.T) {
+	matcher := NewLicenseTextMatcher("Example License\n===============\n\nNeither the name of <<ANY>> nor the names of its\ncontributors may be used.\n")
+
+	tests := map[string]struct {
+		contents string
+		expected bool
+	}{
+		"exact text": {
+			contents: "Example License\n===============\n\nNeither the name of the copyright holder nor the names of its\ncontributors may be used.\n",
+			expected: true,
+		},
+		"rewrapped with a copyright notice": {
+			contents: "Copyright 2024 Jane Doe\n\n# EXAMPLE LICENSE\n\nNeither the name of Jane Doe nor the names of its contributors may be used.\n",
+			expected: true,
+		},
+		"missing text": {
+			contents: "Example License\n\nNeither the name of Jane Doe nor the names of its contributors.\n",
+		},
+		"different license": {
+			contents: "Other License\n\nNeither the name of Jane Doe nor the names of its contributors may be used.\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := matcher.Matches(test.contents); got != test.expected {
+				t.Errorf("got %v, wanted %v", got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// markerRegex matches anything which looks like a marker, so that misspelled markers are caught
+var markerRegex = regexp.MustCompile(`<<([A-Z]+)(?: [^>]*)?>>`)
+
+// knownMarkers are the names of the markers which templates can use
+var knownMarkers = map[string]bool{
+	"YEAR":    true,
+	"AUTHOR":  true,
+	"INCLUDE": true,
+}
+
+// TemplateLintIssue describes a mistake in a template or fragment which would stop it from working
+// as intended
+type TemplateLintIssue struct {
+	// File is the name of the template or fragment
+	File string
+
+	// Line is the line of the file, starting from 1, on which the mistake was found. It's 0 if the
+	// mistake applies to the whole file.
+	Line int
+
+	// Message describes the mistake
+	Message string
+}
+
+// String implements fmt.Stringer
+func (i TemplateLintIssue) String() string {
+	if i.Line == 0 {
+		return fmt.Sprintf("%s: %s", i.File, i.Message)
+	}
+
+	return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+}
+
+// LintTemplates checks every template and fragment at the root of templateDir for mistakes which
+// would make the templates fail to load, or mis-flag files which are correct. Templates with the
+// same name or language as one in reference, which is usually the bundled templates, are expected
+// to use the same comment syntax. Issues are sorted by file and line.
+func LintTemplates(templateDir fs.FS, reference TemplateMap) ([]TemplateLintIssue, error) {
+	entries, err := fs.ReadDir(templateDir, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates: %s", err.Error())
+	}
+
+	var issues []TemplateLintIssue
+
+	foundTemplate := false
+
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+
+		if entry.IsDir() || (ext != ".boilertmpl" && ext != fragmentExt) {
+			continue
+		}
+
+		contents, err := fs.ReadFile(templateDir, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %s", name, err.Error())
+		}
+
+		raw := string(contents)
+
+		issues = append(issues, lintLines(name, raw)...)
+
+		if ext == ".boilertmpl" {
+			foundTemplate = true
+			issues = append(issues, lintTemplate(templateDir, name, raw, reference)...)
+		}
+	}
+
+	if !foundTemplate {
+		return nil, fmt.Errorf("found no templates in template dir")
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+
+		return issues[i].Line < issues[j].Line
+	})
+
+	return issues, nil
+}
+
+// lintLines checks each line of a template or fragment for whitespace and markers which would
+// stop it from matching files
+func lintLines(file string, raw string) []TemplateLintIssue {
+	var issues []TemplateLintIssue
+
+	for i, line := range strings.Split(raw, "\n") {
+		report := func(format string, args ...any) {
+			issues = append(issues, TemplateLintIssue{File: file, Line: i + 1, Message: fmt.Sprintf(format, args...)})
+		}
+
+		if strings.HasSuffix(line, "\r") {
+			report("has a Windows-style line ending; Unix-style line endings are required")
+			line = strings.TrimSuffix(line, "\r")
+		}
+
+		if strings.TrimRight(line, " \t") != line {
+			report("has trailing whitespace")
+		}
+
+		if strings.Contains(line, "\t") {
+			report("contains a tab; use spaces instead")
+		}
+
+		for _, match := range markerRegex.FindAllStringSubmatch(line, -1) {
+			if !knownMarkers[match[1]] {
+				report("has unknown marker %q", match[0])
+			}
+		}
+	}
+
+	return issues
+}
+
+// lintTemplate checks that a template has the markers it needs and that its comment syntax is
+// consistent, both within the template and with the reference template for its language
+func lintTemplate(templateDir fs.FS, file string, raw string, reference TemplateMap) []TemplateLintIssue {
+	var issues []TemplateLintIssue
+
+	report := func(line int, format string, args ...any) {
+		issues = append(issues, TemplateLintIssue{File: file, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if _, err := resolveIncludes(templateDir, raw, nil, nil); err != nil {
+		report(0, "%s", err)
+	}
+
+	if !AuthorMarkerRegex.MatchString(raw) {
+		report(0, "has no %s marker, so it can't be loaded", AuthorMarkerRegex)
+	}
+
+	style := commentStyleOf(raw)
+
+	if style.prefix != "" {
+		for i, line := range strings.Split(raw, "\n") {
+			if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, style.prefix) {
+				report(i+1, "isn't commented with %q like the first line", style.prefix)
+			}
+		}
+	}
+
+	name := strings.TrimSuffix(file, ".boilertmpl")
+	if _, suffix, ok := strings.Cut(name, "."); ok {
+		name = suffix
+	}
+
+	referenceName := name
+
+	expected, ok := reference[referenceName]
+	if !ok {
+		referenceName = languageOf(name)
+		expected, ok = reference[referenceName]
+	}
+
+	if ok && referenceName != DefaultTemplateName {
+		if expectedStyle := commentStyleOf(expected.raw); !expectedStyle.accepts(style) {
+			report(0, "uses %s, but the %q template uses %s", style, referenceName, expectedStyle)
+		}
+	}
+
+	return issues
+}
+
+// commentStyle describes how a template is commented: either as a block which opens and closes
+// on lines of their own, e.g. "/*" and "*/", or by prefixing each line, e.g. with "#"
+type commentStyle struct {
+	open   string
+	close  string
+	prefix string
+}
+
+// cStyleBlock is the block comment used by C and the languages whose comments follow it, all of
+// which also have "//" line comments
+var cStyleBlock = commentStyle{open: "/*", close: "*/"}
+
+// accepts returns true if a template using the other style would be valid in a language whose
+// templates use s
+func (s commentStyle) accepts(other commentStyle) bool {
+	return other == s || (s == cStyleBlock && other == commentStyle{prefix: "//"})
+}
+
+// String implements fmt.Stringer
+func (s commentStyle) String() string {
+	switch {
+	case s.open != "":
+		return fmt.Sprintf("%q ... %q block comments", s.open, s.close)
+
+	case s.prefix != "":
+		return fmt.Sprintf("%q line comments", s.prefix)
+
+	default:
+		return "no comments"
+	}
+}
+
+// commentStyleOf returns the comment style of the given raw template. A first line with no
+// letters or digits is taken to open a block comment, which the last line closes.
+func commentStyleOf(raw string) commentStyle {
+	var lines []string
+
+	for _, line := range strings.Split(raw, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			lines = append(lines, trimmed)
+		}
+	}
+
+	if len(lines) == 0 {
+		return commentStyle{}
+	}
+
+	isAlphanumeric := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+
+	if len(lines) > 1 && !strings.ContainsFunc(lines[0], isAlphanumeric) {
+		return commentStyle{open: lines[0], close: lines[len(lines)-1]}
+	}
+
+	prefix := lines[0]
+	for i, r := range lines[0] {
+		if isAlphanumeric(r) || unicode.IsSpace(r) || strings.HasPrefix(lines[0][i:], "<<") {
+			prefix = lines[0][:i]
+			break
+		}
+	}
+
+	return commentStyle{prefix: prefix}
+}
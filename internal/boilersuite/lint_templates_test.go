@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+)
+
+func Test_LintTemplates(t *testing.T) {
+	reference, err := LoadTemplates(boilerplatetemplates.FS, "cert-manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		files map[string]string
+
+		expected []string
+	}{
+		"valid templates": {
+			files: map[string]string{
+				"boilerplate.go.boilertmpl":      "/*\nCopyright <<YEAR>> The <<AUTHOR>> Authors.\n\n<<INCLUDE notice>>\n*/\n\n",
+				"boilerplate.go-spdx.boilertmpl": "// Copyright <<YEAR>> The <<AUTHOR>> Authors.\n// SPDX-License-Identifier: Apache-2.0\n\n",
+				"boilerplate.sh.boilertmpl":      "# Copyright The <<AUTHOR>> Authors.\n#\n# <<INCLUDE notice>>\n\n",
+				"notice.boilerfrag":              "Licensed under the Example License.\n",
+			},
+		},
+		"whitespace": {
+			files: map[string]string{
+				"boilerplate.sh.boilertmpl": "# Copyright <<YEAR>> The <<AUTHOR>> Authors. \n#\t\n# Licensed under the Example License.\r\n",
+				"notice.boilerfrag":         "Licensed under\tthe Example License.\n",
+			},
+			expected: []string{
+				"boilerplate.sh.boilertmpl:1: has trailing whitespace",
+				"boilerplate.sh.boilertmpl:2: has trailing whitespace",
+				"boilerplate.sh.boilertmpl:2: contains a tab; use spaces instead",
+				"boilerplate.sh.boilertmpl:3: has a Windows-style line ending; Unix-style line endings are required",
+				"notice.boilerfrag:1: contains a tab; use spaces instead",
+			},
+		},
+		"markers": {
+			files: map[string]string{
+				"boilerplate.sh.boilertmpl": "# Copyright <<YAER>> The cert-manager Authors.\n# <<INCLUDE missing>>\n\n",
+			},
+			expected: []string{
+				`boilerplate.sh.boilertmpl: failed to include fragment "missing": open missing.boilerfrag: file does not exist`,
+				"boilerplate.sh.boilertmpl: has no <<AUTHOR>> marker, so it can't be loaded",
+				`boilerplate.sh.boilertmpl:1: has unknown marker "<<YAER>>"`,
+			},
+		},
+		"comment syntax": {
+			files: map[string]string{
+				"boilerplate.html.boilertmpl": "/*\nCopyright <<YEAR>> The <<AUTHOR>> Authors.\n*/\n\n",
+				"boilerplate.py.boilertmpl":   "# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n// Licensed under the Example License.\n\n",
+			},
+			expected: []string{
+				`boilerplate.html.boilertmpl: uses "/*" ... "*/" block comments, but the "html" template uses "<!--" ... "-->" block comments`,
+				`boilerplate.py.boilertmpl:2: isn't commented with "#" like the first line`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			templateDir := fstest.MapFS{}
+			for file, contents := range test.files {
+				templateDir[file] = &fstest.MapFile{Data: []byte(contents)}
+			}
+
+			issues, err := LintTemplates(templateDir, reference)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var found []string
+			for _, issue := range issues {
+				found = append(found, issue.String())
+			}
+
+			if !reflect.DeepEqual(found, test.expected) {
+				t.Errorf("expected issues:\n%q\ngot:\n%q", test.expected, found)
+			}
+		})
+	}
+}
+
+func Test_LintTemplatesBuiltins(t *testing.T) {
+	reference, err := LoadTemplates(boilerplatetemplates.FS, "cert-manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := LintTemplates(boilerplatetemplates.FS, reference)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, issue := range issues {
+		t.Errorf("unexpected issue in built-in template: %s", issue)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"strings"
+)
+
+// MigrateCopyrightHolder returns raw with from replaced by to in each copyright notice near the
+// start of the file, such as "Copyright 2019 Jetstack Ltd." or "SPDX-FileCopyrightText: 2019
+// Jetstack Ltd.". Years and everything else in the file are left as they were. It also returns
+// whether any notice was changed.
+func MigrateCopyrightHolder(raw string, from string, to string) (string, bool) {
+	if from == "" || from == to {
+		return raw, false
+	}
+
+	lines := strings.SplitN(raw, "\n", extractYearSearchLines+1)
+	changed := false
+
+	for i := 0; i < len(lines) && i < extractYearSearchLines; i++ {
+		if !REUSECopyrightRegex.MatchString(lines[i]) || !strings.Contains(lines[i], from) {
+			continue
+		}
+
+		lines[i] = strings.ReplaceAll(lines[i], from, to)
+		changed = true
+	}
+
+	if !changed {
+		return raw, false
+	}
+
+	return strings.Join(lines, "\n"), true
+}
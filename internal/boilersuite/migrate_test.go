@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_MigrateCopyrightHolder(t *testing.T) {
+	tests := map[string]struct {
+		input         string
+		expected      string
+		expectChanged bool
+	}{
+		"block header": {
+			input:         "/*\nCopyright 2019 Jetstack Ltd.\n\nLicensed under the Apache License, Version 2.0 (the \"License\");\n*/\n\npackage main\n",
+			expected:      "/*\nCopyright 2019 The cert-manager Authors.\n\nLicensed under the Apache License, Version 2.0 (the \"License\");\n*/\n\npackage main\n",
+			expectChanged: true,
+		},
+		"several notices and CRLF line endings": {
+			input:         "# SPDX-FileCopyrightText: 2019-2021 Jetstack Ltd.\r\n# Copyright (c) 2022 Jetstack Ltd.\r\n\r\necho Jetstack Ltd.\r\n",
+			expected:      "# SPDX-FileCopyrightText: 2019-2021 The cert-manager Authors.\r\n# Copyright (c) 2022 The cert-manager Authors.\r\n\r\necho Jetstack Ltd.\r\n",
+			expectChanged: true,
+		},
+		"holder outside a copyright notice": {
+			input:    "# Maintained by Jetstack Ltd.\n\necho hello\n",
+			expected: "# Maintained by Jetstack Ltd.\n\necho hello\n",
+		},
+		"different holder": {
+			input:    "// Copyright 2024 Someone Else\n",
+			expected: "// Copyright 2024 Someone Else\n",
+		},
+		"notice beyond the start of the file": {
+			input:    "package main\n" + strings.Repeat("\n", extractYearSearchLines) + "// Copyright 2019 Jetstack Ltd.\n",
+			expected: "package main\n" + strings.Repeat("\n", extractYearSearchLines) + "// Copyright 2019 Jetstack Ltd.\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, changed := MigrateCopyrightHolder(test.input, "Jetstack Ltd.", "The cert-manager Authors.")
+
+			if got != test.expected {
+				t.Errorf("got %q, wanted %q", got, test.expected)
+			}
+
+			if changed != test.expectChanged {
+				t.Errorf("changed=%t, wanted %t", changed, test.expectChanged)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// headerLines returns the lines of the template's boilerplate, without the blank lines which follow it
+func (t BoilerplateTemplate) headerLines() []string {
+	return strings.Split(strings.TrimRight(t.replaced, "\n"), "\n")
+}
+
+// matchesHeaderAt returns true if the template's boilerplate appears in lines starting at the given index
+func (t BoilerplateTemplate) matchesHeaderAt(lines []string, start int) bool {
+	header := t.headerLines()
+
+	if start < 0 || start+len(header) > len(lines) {
+		return false
+	}
+
+	for i, expected := range header {
+		line := strings.TrimSuffix(lines[start+i], "\r")
+		line = t.normalizeYears(line)
+
+		if line != expected {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findMisplaced looks for valid boilerplate which isn't at the start of the file, such as below the
+// package clause of a Go file. Only the start of the file is searched, so that boilerplate written
+// out by a script (e.g. in a heredoc) isn't mistaken for the file's own.
+func (t BoilerplateTemplate) findMisplaced(raw string) *ValidationError {
+	lines := strings.Split(fileBeginning(raw, t.lineCount), "\n")
+	first := nextNonBlankLine(lines, 0)
+
+	for start := first + 1; start < len(lines); start++ {
+		if t.matchesHeaderAt(lines, start) {
+			return &ValidationError{
+				Reason:      fmt.Sprintf("misplaced boilerplate: found on line %d, but it must be at the start of the file", start+1),
+				Kind:        ErrorKindMisplaced,
+				Similarity:  1,
+				Misplaced:   true,
+				headerStart: start,
+				template:    &t,
+			}
+		}
+	}
+
+	return nil
+}
+
+// findMisplacedPreamble returns an error if the file starts with boilerplate which is followed by
+// content which must come first, such as a shebang which is only effective on the first line of a script
+func (t BoilerplateTemplate) findMisplacedPreamble(raw string) *ValidationError {
+	if t.preambleRegex == nil || t.boilerplateBeforePreamble {
+		return nil
+	}
+
+	if loc := t.preambleRegex.FindStringIndex(raw); loc != nil && loc[0] == 0 {
+		return nil
+	}
+
+	lines := strings.Split(fileBeginning(raw, t.lineCount), "\n")
+
+	start := nextNonBlankLine(lines, 0)
+	if !t.matchesHeaderAt(lines, start) {
+		return nil
+	}
+
+	next := nextNonBlankLine(lines, start+len(t.headerLines()))
+	if next >= len(lines) {
+		return nil
+	}
+
+	if loc := t.preambleRegex.FindStringIndex(lines[next] + "\n"); loc == nil || loc[0] != 0 {
+		return nil
+	}
+
+	return &ValidationError{
+		Reason:      fmt.Sprintf("misplaced boilerplate: it must come after line %d, which must be at the start of the file", next+1),
+		Kind:        ErrorKindMisplaced,
+		Similarity:  1,
+		Misplaced:   true,
+		headerStart: start,
+		template:    &t,
+	}
+}
+
+// moveHeader moves the boilerplate found by findMisplaced or findMisplacedPreamble to the start of
+// the file, keeping its copyright year
+func (t BoilerplateTemplate) moveHeader(raw string, misplaced *ValidationError) (string, error) {
+	lines := strings.Split(raw, "\n")
+
+	start := misplaced.headerStart
+	end := start + len(t.headerLines())
+
+	header := make([]string, 0, end-start)
+	for _, line := range lines[start:end] {
+		header = append(header, strings.TrimSuffix(line, "\r"))
+	}
+
+	// blank lines which separated the boilerplate from what followed it are removed along with it
+	if start == 0 || strings.TrimSpace(lines[start-1]) == "" {
+		end = nextNonBlankLine(lines, end)
+	}
+
+	remaining := append(append([]string{}, lines[:start]...), lines[end:]...)
+
+	trailing := t.replaced[len(strings.TrimRight(t.replaced, "\n")):]
+
+	return t.insertHeader(strings.Join(remaining, "\n"), strings.Join(header, "\n")+trailing)
+}
+
+// nextNonBlankLine returns the index of the first line at or after start which isn't blank, or
+// len(lines) if there's no such line
+func nextNonBlankLine(lines []string, start int) int {
+	for start < len(lines) && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+
+	return start
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_Misplaced(t *testing.T) {
+	tmpl, err := NewBoilerplateTemplate(testTemplate, BoilerplateTemplateConfiguration{
+		ExpectedAuthor:    "cert-manager",
+		NormalizationFunc: normalizeShebang,
+		PreambleRegex:     ShebangRegex,
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	const header = "# Copyright 2024 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n"
+
+	tests := map[string]struct {
+		input           string
+		expectMisplaced bool
+		expectErr       bool
+		fixed           string
+	}{
+		"valid": {
+			input: "#!/bin/sh\n\n" + header + "\necho hello\n",
+		},
+		"below other content": {
+			input:           "set -e\n\n" + header + "\necho hello\n",
+			expectMisplaced: true,
+			fixed:           header + "\nset -e\n\necho hello\n",
+		},
+		"above the shebang": {
+			input:           header + "\n#!/bin/sh\necho hello\n",
+			expectMisplaced: true,
+			fixed:           "#!/bin/sh\n\n" + header + "\necho hello\n",
+		},
+		"below other content with CRLF line endings": {
+			input:           "set -e\r\n\r\n" + "# Copyright 2024 The cert-manager Authors.\r\n#\r\n# Licensed under the Apache License, Version 2.0 (the \"License\");\r\n" + "\r\necho hello\r\n",
+			expectMisplaced: true,
+			fixed:           "# Copyright 2024 The cert-manager Authors.\r\n#\r\n# Licensed under the Apache License, Version 2.0 (the \"License\");\r\n" + "\r\nset -e\r\n\r\necho hello\r\n",
+		},
+		"at the start without a blank line after it": {
+			input:     header + "echo hello\n",
+			expectErr: true,
+		},
+		"too far down the file": {
+			input:     "a\nb\nc\nd\ne\nf\ng\nh\n" + header,
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tmpl.Validate(test.input)
+
+			var validationErr *ValidationError
+			misplaced := errors.As(err, &validationErr) && validationErr.Misplaced
+
+			if misplaced != test.expectMisplaced {
+				t.Fatalf("err=%v, expectMisplaced=%v", err, test.expectMisplaced)
+			}
+
+			if !test.expectMisplaced {
+				if (err != nil) != test.expectErr {
+					t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+				}
+
+				return
+			}
+
+			fixed, err := tmpl.Fix(test.input, FixOptions{Year: 2026})
+			if err != nil {
+				t.Fatalf("failed to fix file: %s", err)
+			}
+
+			if fixed != test.fixed {
+				t.Errorf("fixed=%q, expected=%q", fixed, test.fixed)
+			}
+		})
+	}
+}
@@ -27,8 +27,18 @@ var (
 	// AuthorMarkerRegex matches the marker which should appear in boilerplate sample files but not in actual files
 	AuthorMarkerRegex = regexp.MustCompile(`<<AUTHOR>>`)
 
-	// DateRegex matches the actual date found inside a file
-	DateRegex = regexp.MustCompile(`Copyright 20\d\d`)
+	// IncludeMarkerRegex matches a marker which is replaced by the contents of the named fragment,
+	// e.g. "<<INCLUDE apache-2.0>>" is replaced by the contents of "apache-2.0.boilerfrag"
+	IncludeMarkerRegex = regexp.MustCompile(`<<INCLUDE ([\w.-]+)>>`)
+
+	// DateRegex matches the actual date found inside a file, which can be a range of years such as
+	// "Copyright 2019-2026". A copyright symbol can come before the year, e.g. "Copyright (c) 2024"
+	// or "Copyright © 2024".
+	DateRegex = regexp.MustCompile(`Copyright (?:\([cC]\) |© )?20\d\d(?:-20\d\d)?`)
+
+	// AnyYearRegex matches a copyright line's year in any common form, such as "Copyright 1999",
+	// "Copyright (c) 2019 - present" or "Copyright 2019, 2021-2024"
+	AnyYearRegex = regexp.MustCompile(`Copyright (?:\([cC]\) |© )?(?:19|20)\d\d(?:(?:[ \t]*[-–][ \t]*|,[ \t]*)(?:(?:19|20)\d\d|present))*`)
 
 	// BuildConstraintsRegex matches golang build constraints
 	BuildConstraintsRegex = regexp.MustCompile(`(?m)^(\/\/(go:build| \+build).*\n)+$`)
@@ -37,9 +47,113 @@ var (
 	// but we use a multiline here to be safe
 	ShebangRegex = regexp.MustCompile(`(?m)^#!.*\n`)
 
-	// SkipFileRegex matches files which should not be validated
-	SkipFileRegex = regexp.MustCompile(`(?m)^(\/\/|#) \+skip_license_check$`)
+	// PythonEncodingRegex matches a PEP 263 source encoding declaration, such as "# coding=utf-8",
+	// which must be on the first or second line of a Python file
+	PythonEncodingRegex = regexp.MustCompile(`^[ \t\f]*#[^\n]*?coding[:=][ \t]*[-\w.]+[^\n]*(?:\n|$)`)
+
+	// PythonPreambleRegex matches a shebang, an encoding declaration or both at the start of a Python file
+	PythonPreambleRegex = regexp.MustCompile(`(?m)^(?:#!.*\n)?[ \t\f]*#.*?coding[:=][ \t]*[-\w.]+.*\n|^#!.*\n`)
+
+	// RubyPreambleRegex matches a shebang, magic comments such as "# frozen_string_literal: true" or both
+	// at the start of a Ruby file
+	RubyPreambleRegex = regexp.MustCompile(`^(?:#![^\n]*\n)?(?:[ \t]*#[ \t]*(?:-\*-[ \t]*)?(?:frozen_string_literal|(?:en)?coding|warn_indent|shareable_constant_value)[ \t]*:[^\n]*\n)+|^#![^\n]*\n`)
+
+	// PHPPreambleRegex matches the opening tag of a PHP file, along with any strict types declaration
+	PHPPreambleRegex = regexp.MustCompile(`^<\?php[ \t]*\n(?:\s*declare\(\s*strict_types\s*=\s*1\s*\)\s*;[ \t]*\n)?`)
+
+	// XMLDeclarationRegex matches the declaration at the start of an XML file, which must come before any comment
+	XMLDeclarationRegex = regexp.MustCompile(`^<\?xml[^>]*\?>[ \t]*\n`)
+
+	// DoctypeRegex matches the doctype at the start of an HTML file
+	DoctypeRegex = regexp.MustCompile(`^(?i:<!doctype html)[^>]*>[ \t]*\n`)
+
+	// FrontMatterRegex matches YAML front matter at the start of a Markdown file
+	FrontMatterRegex = regexp.MustCompile(`^---[ \t]*\r?\n(?:[^\n]*\n)*?(?:---|\.\.\.)[ \t]*\r?(?:\n|$)`)
+
+	// YAMLDocumentStartRegex matches any directives, such as "%YAML 1.2", followed by the "---" marker
+	// at the start of a YAML document
+	YAMLDocumentStartRegex = regexp.MustCompile(`^(?:%[^\n]*\n)*---[ \t]*(?:#[^\n]*)?\n`)
+
+	// RustShebangRegex matches a shebang at the start of a Rust script. Unlike the shebang of a shell
+	// script, it mustn't be followed by "[", since "#![...]" is a crate attribute which comes after
+	// the boilerplate.
+	RustShebangRegex = regexp.MustCompile(`^#![ \t]*[^\[\s][^\n]*\n`)
+
+	// IncludeGuardRegex matches the end of a block comment which is directly followed by an include
+	// guard or "#pragma once" in a C or C++ header, capturing both
+	IncludeGuardRegex = regexp.MustCompile(`(?m)^([ \t]*\*/[ \t]*\n)([ \t]*#[ \t]*(?:ifndef|if[ \t]+!|pragma[ \t]+once)\b)`)
+
+	// HCLSlashCommentRegex matches the start of a "//" comment line in an HCL file, which is
+	// equivalent to a "#" comment
+	HCLSlashCommentRegex = regexp.MustCompile(`(?m)^//`)
+
+	// PowerShellRequiresRegex matches "#Requires" statements at the start of a PowerShell script
+	PowerShellRequiresRegex = regexp.MustCompile(`^(?i:[ \t]*#requires\b[^\n]*\n)+`)
+
+	// PowerShellBlockCommentRegex matches a "<# ... #>" block comment at the start of the given text,
+	// capturing the lines inside it
+	PowerShellBlockCommentRegex = regexp.MustCompile(`^<#[ \t]*\n((?:[^\n]*\n)*?)[ \t]*#>[ \t]*(?:\n|$)`)
+
+	// HelmCommentStartRegex matches the start of a Go template comment at the start of the given text,
+	// with or without whitespace trimming
+	HelmCommentStartRegex = regexp.MustCompile(`^\{\{(?:- )?/\*[ \t]*\n`)
+
+	// HelmCommentEndRegex matches the line which ends a Go template comment, with or without
+	// whitespace trimming
+	HelmCommentEndRegex = regexp.MustCompile(`(?m)^[ \t]*\*/(?: -)?\}\}[ \t]*$`)
+
+	// ModelineRegex matches a comment line at the start of the given text which configures an editor,
+	// such as "# vim: set ts=4 sw=4:" or "// -*- mode: go -*-"
+	ModelineRegex = regexp.MustCompile(`^[ \t]*(?:#|//|/\*|--|;|%|<!--|\.\.|")[^\n]*(?:-\*-[^\n]*-\*-|\b(?:vi|vim|ex):)[^\n]*(?:\n|$)`)
+
+	// EmacsModeRegex matches an Emacs file variables line, capturing the mode in either the first
+	// submatch (for "-*- mode: python -*-") or the second (for "-*- python -*-")
+	EmacsModeRegex = regexp.MustCompile(`-\*-[ \t]*(?:[^\n]*?\bmode:[ \t]*([\w+-]+)|([\w+-]+)[ \t]*-\*-)`)
+
+	// VimFiletypeRegex matches a vim modeline which sets the filetype, capturing it
+	VimFiletypeRegex = regexp.MustCompile(`\b(?:vi|vim|ex):[^\n]*?\b(?:ft|filetype|syntax)=([\w+-]+)`)
+
+	// SkipFileRegex matches files which should not be validated. The marker can optionally carry
+	// attributes such as an expiry date and a reason, e.g.
+	// "# +skip_license_check until=2026-01-01 reason=vendored-temporarily", captured in the second submatch.
+	// Languages with only block comments use "<!-- +skip_license_check -->" or, in Go templates,
+	// "{{/* +skip_license_check */}}".
+	SkipFileRegex = regexp.MustCompile(`(?m)^(\/\/|#|--|%%|<!--|\{\{(?:- )?\/\*) \+skip_license_check((?: [a-z]+=(?:"[^"\n]*"|\S+))*)(?: -->| \*\/(?: -)?\}\})?$`)
+
+	// SkipAttributeRegex matches a single key=value attribute of a skip marker. Values containing
+	// spaces can be quoted.
+	SkipAttributeRegex = regexp.MustCompile(` ([a-z]+)=("[^"\n]*"|\S+)`)
+
+	// HeredocStartRegex matches the start of a shell heredoc, capturing whether tabs are stripped,
+	// any quote around the delimiter and the delimiter itself
+	HeredocStartRegex = regexp.MustCompile(`<<(-?)\s*(['"]?)([A-Za-z_][A-Za-z0-9_]*)['"]?`)
+
+	// HeredocRedirectRegex matches a redirection of output to a file in a shell command, capturing the file
+	HeredocRedirectRegex = regexp.MustCompile(`>>?\s*("[^"]+"|'[^']+'|[^\s;&|<>]+)`)
+
+	// ShellYearRegex matches a copyright line whose year is computed by the shell, e.g. "Copyright $(date +%Y)"
+	ShellYearRegex = regexp.MustCompile(`Copyright (\$\(date \+["']?%Y["']?\)|\$\{?[A-Za-z_][A-Za-z0-9_]*\}?)`)
+
+	// REUSECopyrightRegex matches a line holding a copyright notice in one of the forms accepted by the
+	// REUSE specification, such as "SPDX-FileCopyrightText: 2024 Jane Doe" or "Copyright 2024 Jane
+	// Doe", capturing the holder along with any year
+	REUSECopyrightRegex = regexp.MustCompile(`(?m)^[^\w\n]*(?:SPDX-FileCopyrightText:|Copyright\b|©)[ \t]*([^\n]*)$`)
+
+	// REUSELicenseRegex matches an "SPDX-License-Identifier" tag, capturing the license expression
+	REUSELicenseRegex = regexp.MustCompile(`(?m)^[^\w\n]*SPDX-License-Identifier:[ \t]*([^\n]*)$`)
+
+	// CopyrightYearsRegex matches a year or range of years in a copyright notice, e.g. "2019-2024"
+	CopyrightYearsRegex = regexp.MustCompile(`\b(?:19|20)\d\d(?:[ \t]*[-–][ \t]*(?:(?:19|20)\d\d|present))?\b`)
+
+	// LicenseTextWildcardRegex matches the marker in the text of a license which stands for any text,
+	// such as the name of the copyright holder
+	LicenseTextWildcardRegex = regexp.MustCompile(`<<ANY>>`)
+
+	// LicenseTextRuleRegex matches rules made of "=" or "-" characters, which underline headings in
+	// some copies of licenses
+	LicenseTextRuleRegex = regexp.MustCompile(`[=-]{3,}`)
 
-	// GeneratedRegex matches comments added by k8s code generators
-	GeneratedRegex = regexp.MustCompile(`(?m)^[\/*#]+.*DO NOT EDIT\.$`)
+	// GeneratedRegex matches comments added by k8s code generators, including those written as
+	// HTML or Go template comments
+	GeneratedRegex = regexp.MustCompile(`(?m)^(?:[\/*#%]+|--|<!--|\{\{(?:- )?\/\*).*DO NOT EDIT\.(?: -->| \*\/(?: -)?\}\})?$`)
 )
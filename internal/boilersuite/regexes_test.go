@@ -143,10 +143,38 @@ func Test_GeneratedRegex(t *testing.T) {
 			shouldMatch: true,
 			input:       "// Code generated by informer-gen. DO NOT EDIT.\n",
 		},
+		"protoc-gen-go": {
+			shouldMatch: true,
+			input:       "// Code generated by protoc-gen-go. DO NOT EDIT.\n",
+		},
 		"informer-gen but in python": {
 			shouldMatch: true,
 			input:       "# Code generated by informer-gen. DO NOT EDIT.\n",
 		},
+		"sql style comment": {
+			shouldMatch: true,
+			input:       "-- Code generated by sqlc. DO NOT EDIT.\n",
+		},
+		"erlang style comment": {
+			shouldMatch: true,
+			input:       "%% Code generated by a tool. DO NOT EDIT.\n",
+		},
+		"markdown comment": {
+			shouldMatch: true,
+			input:       "<!-- Code generated by gen-crd-api-reference-docs. DO NOT EDIT. -->\n",
+		},
+		"xml comment after the declaration": {
+			shouldMatch: true,
+			input:       "<?xml version=\"1.0\"?>\n<!-- Code generated by xjc. DO NOT EDIT. -->\n<project/>\n",
+		},
+		"html comment after the doctype": {
+			shouldMatch: true,
+			input:       "<!DOCTYPE html>\n<!-- Code generated by hugo. DO NOT EDIT. -->\n<html></html>\n",
+		},
+		"go template comment": {
+			shouldMatch: true,
+			input:       "{{- /* Code generated by helm-docs. DO NOT EDIT. */ -}}\n",
+		},
 		"longer file with no matches": {
 			shouldMatch: false,
 			input:       skipFileLong,
@@ -181,6 +209,66 @@ func Test_SkipFileRegex(t *testing.T) {
 			shouldMatch: true,
 			input:       "# +skip_license_check\n",
 		},
+		"sql style comment": {
+			shouldMatch: true,
+			input:       "-- +skip_license_check\n",
+		},
+		"erlang style comment": {
+			shouldMatch: true,
+			input:       "%% +skip_license_check\n",
+		},
+		"markdown comment": {
+			shouldMatch: true,
+			input:       "# Vendored notes\n\n<!-- +skip_license_check -->\n",
+		},
+		"markdown comment with expiry": {
+			shouldMatch: true,
+			input:       "<!-- +skip_license_check until=2026-01-01 -->\n",
+		},
+		"markdown comment closed on the next line": {
+			shouldMatch: true,
+			input:       "<!-- +skip_license_check\n-->\n",
+		},
+		"markdown comment with trailing text": {
+			shouldMatch: false,
+			input:       "<!-- +skip_license_check for now -->\n",
+		},
+		"xml comment after the declaration": {
+			shouldMatch: true,
+			input:       "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<!-- +skip_license_check -->\n<project/>\n",
+		},
+		"html comment after the doctype": {
+			shouldMatch: true,
+			input:       "<!DOCTYPE html>\n<!-- +skip_license_check -->\n<html></html>\n",
+		},
+		"html comment indented inside an element": {
+			shouldMatch: false,
+			input:       "<html>\n  <!-- +skip_license_check -->\n</html>\n",
+		},
+		"go template comment": {
+			shouldMatch: true,
+			input:       "{{/* +skip_license_check */}}\n",
+		},
+		"go template comment trimming whitespace": {
+			shouldMatch: true,
+			input:       "{{- /* +skip_license_check until=2026-01-01 */ -}}\n",
+		},
+		"go template comment with trailing text": {
+			shouldMatch: false,
+			input:       "{{/* +skip_license_check for now */}}\n",
+		},
+		"comment with expiry": {
+			shouldMatch: true,
+			input:       "# +skip_license_check until=2026-01-01\n",
+		},
+		"comment with expiry and reason": {
+			shouldMatch: true,
+			input:       "# +skip_license_check until=2026-06-01 reason=vendored-temporarily\n",
+		},
+		"trailing text which isn't an expiry": {
+			shouldMatch: false,
+			input:       "# +skip_license_check for now\n",
+		},
 		"longer file": {
 			shouldMatch: true,
 			input:       skipFileLong,
@@ -0,0 +1,362 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// ResolutionStrategy is a way of deriving the name of a template from the path of a file
+type ResolutionStrategy string
+
+const (
+	// ResolveFilename uses the whole base name of the file, e.g. "Makefile"
+	ResolveFilename ResolutionStrategy = "filename"
+
+	// ResolveMultiSuffix uses suffixes of the base name which contain more than one dot, longest
+	// first, e.g. "tf.json" for "main.tf.json"
+	ResolveMultiSuffix ResolutionStrategy = "multi-suffix"
+
+	// ResolveExtension uses the extension of the file, e.g. "go" for "main.go"
+	ResolveExtension ResolutionStrategy = "extension"
+
+	// ResolveBasename uses the part of the base name before the first dot, e.g. "Dockerfile" for
+	// "Dockerfile.abc"
+	ResolveBasename ResolutionStrategy = "basename"
+
+	// ResolveShebang uses the interpreter named in the shebang of a file without an extension, e.g.
+	// "bash" for a file starting with "#!/usr/bin/env bash". If there's no template named after the
+	// interpreter itself, the template for its language is tried, e.g. "py" for "python3".
+	ResolveShebang ResolutionStrategy = "shebang"
+
+	// ResolveContent guesses the language of a file from its contents; see DetectLanguage. It isn't
+	// one of the default strategies, since guesses can be wrong.
+	ResolveContent ResolutionStrategy = "content"
+
+	// ResolveDefault uses the generic DefaultTemplateName template for any non-empty text file
+	// which doesn't match one of the rule's Exclude patterns. It isn't one of the default
+	// strategies, since the template only applies once it has been given a comment prefix; see
+	// TemplateMap.WithDefaultCommentPrefix.
+	ResolveDefault ResolutionStrategy = "default"
+
+	// ResolvePattern uses a fixed template for files matching a glob pattern; see ResolutionRule
+	ResolvePattern ResolutionStrategy = "pattern"
+)
+
+// ResolutionRule is a single step in choosing the template for a file
+type ResolutionRule struct {
+	Strategy ResolutionStrategy
+
+	// Pattern and Template are only used by ResolvePattern rules. Files whose base name matches
+	// Pattern (or whose full slash-separated path matches, if Pattern contains a "/") use the
+	// template called Template. See MatchGlob for the pattern syntax.
+	Pattern  string
+	Template string
+
+	// Exclude is only used by ResolveDefault rules. Files matching any of its patterns, in the same
+	// way as Pattern, never get the default template; for example, "*.json" or "LICENSE*" files
+	// can't hold a comment.
+	Exclude []string
+}
+
+// DefaultResolutionRules is the order in which strategies are tried by TemplateFor
+var DefaultResolutionRules = []ResolutionRule{
+	{Strategy: ResolveFilename},
+	{Strategy: ResolveMultiSuffix},
+	{Strategy: ResolveExtension},
+	{Strategy: ResolveBasename},
+	{Strategy: ResolveShebang},
+}
+
+// shebangLimit is the most bytes of a file which are read when looking for a shebang
+const shebangLimit = 256
+
+// interpreterTemplates maps interpreters named in shebangs to the built-in template for their
+// language, where the two have different names
+var interpreterTemplates = map[string]string{
+	"dash":   "sh",
+	"ksh":    "sh",
+	"zsh":    "sh",
+	"python": "py",
+	"ruby":   "rb",
+	"node":   "js",
+	"nodejs": "js",
+	"elixir": "exs",
+	"kotlin": "kts",
+	"pwsh":   "ps1",
+}
+
+// ParseResolutionRule parses either the name of a strategy, such as "extension", or a pattern rule
+// of the form "<glob>=<template>", such as "*.yaml.tmpl=yaml"
+func ParseResolutionRule(s string) (ResolutionRule, error) {
+	if pattern, template, ok := strings.Cut(s, "="); ok {
+		if pattern == "" || template == "" {
+			return ResolutionRule{}, fmt.Errorf("invalid rule %q; expected <glob>=<template>", s)
+		}
+
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return ResolutionRule{}, fmt.Errorf("invalid pattern in rule %q: %w", s, err)
+		}
+
+		return ResolutionRule{Strategy: ResolvePattern, Pattern: pattern, Template: template}, nil
+	}
+
+	switch strategy := ResolutionStrategy(s); strategy {
+	case ResolveFilename, ResolveMultiSuffix, ResolveExtension, ResolveBasename, ResolveShebang, ResolveContent:
+		return ResolutionRule{Strategy: strategy}, nil
+	}
+
+	return ResolutionRule{}, fmt.Errorf("unknown resolution strategy %q; expected one of %q, %q, %q, %q, %q, %q or a <glob>=<template> rule", s, ResolveFilename, ResolveMultiSuffix, ResolveExtension, ResolveBasename, ResolveShebang, ResolveContent)
+}
+
+// String returns the rule in the form accepted by ParseResolutionRule
+func (r ResolutionRule) String() string {
+	if r.Strategy == ResolvePattern {
+		return r.Pattern + "=" + r.Template
+	}
+
+	return string(r.Strategy)
+}
+
+// candidates returns the template names which the rule would try for the given path, in order
+func (r ResolutionRule) candidates(path string) []string {
+	base := filepath.Base(path)
+
+	switch r.Strategy {
+	case ResolveFilename:
+		return []string{base}
+
+	case ResolveMultiSuffix:
+		var out []string
+
+		parts := strings.Split(strings.TrimPrefix(base, "."), ".")
+
+		// the last suffix with more than one dot is the final two parts of the name
+		for i := 1; i < len(parts)-1; i++ {
+			out = append(out, strings.Join(parts[i:], "."))
+		}
+
+		return out
+
+	case ResolveExtension:
+		ext := strings.TrimPrefix(filepath.Ext(path), ".")
+		if ext == "" {
+			return nil
+		}
+
+		return []string{ext}
+
+	case ResolveBasename:
+		return []string{strings.SplitN(base, ".", 2)[0]}
+
+	case ResolveShebang:
+		if filepath.Ext(base) != "" {
+			return nil
+		}
+
+		return shebangCandidates(path)
+
+	case ResolveContent:
+		head, ok := readHead(path, detectionLimit)
+		if !ok {
+			return nil
+		}
+
+		return DetectLanguage(head)
+
+	case ResolveDefault:
+		for _, pattern := range r.Exclude {
+			if matchPattern(pattern, path) {
+				return nil
+			}
+		}
+
+		head, ok := readHead(path, detectionLimit)
+		if !ok || !isText([]byte(head), len(head) == detectionLimit) {
+			return nil
+		}
+
+		return []string{DefaultTemplateName}
+
+	case ResolvePattern:
+		if matchPattern(r.Pattern, path) {
+			return []string{r.Template}
+		}
+	}
+
+	return nil
+}
+
+// matchPattern reports whether path matches the glob pattern. Patterns containing a "/" match the
+// whole slash-separated path, and others match its base name.
+func matchPattern(pattern string, path string) bool {
+	subject := filepath.Base(path)
+	if strings.Contains(pattern, "/") {
+		subject = filepath.ToSlash(path)
+	}
+
+	return MatchGlob(pattern, subject)
+}
+
+// isText returns true if head, the start of a file, is non-empty and holds UTF-16 text or valid
+// UTF-8 without NUL bytes. Binary formats without NUL bytes near their start fail the UTF-8 check.
+// If truncated is set, head may end part way through a character.
+func isText(head []byte, truncated bool) bool {
+	if len(head) == 0 || IsBinary(head) {
+		return false
+	}
+
+	if IsUTF16(head) {
+		return true
+	}
+
+	if truncated {
+		for i := len(head) - 1; i >= 0 && i >= len(head)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(head[i]) {
+				if !utf8.FullRune(head[i:]) {
+					head = head[:i]
+				}
+
+				break
+			}
+		}
+	}
+
+	return utf8.Valid(head)
+}
+
+// shebangCandidates returns the template names which could apply to the script at path, based on
+// its shebang. Files which can't be read are treated as having no shebang.
+func shebangCandidates(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+
+	defer f.Close()
+
+	line, err := bufio.NewReaderSize(f, shebangLimit).ReadSlice('\n')
+	if err != nil && len(line) == 0 {
+		return nil
+	}
+
+	return interpreterCandidates(string(line))
+}
+
+// interpreterCandidates returns the template names which could apply to a script with the given
+// shebang line
+func interpreterCandidates(line string) []string {
+	interpreter, ok := InterpreterOf(line)
+	if !ok {
+		return nil
+	}
+
+	candidates := []string{interpreter}
+
+	if language, ok := interpreterTemplates[strings.TrimRight(interpreter, "0123456789.")]; ok {
+		candidates = append(candidates, language)
+	}
+
+	return candidates
+}
+
+// readHead returns up to limit bytes from the start of the file at path
+func readHead(path string, limit int64) (string, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+
+	defer f.Close()
+
+	head, err := io.ReadAll(io.LimitReader(f, limit))
+	if err != nil {
+		return "", false
+	}
+
+	return string(head), true
+}
+
+// InterpreterOf returns the name of the interpreter in the given shebang line, such as "bash" for
+// either "#!/bin/bash" or "#!/usr/bin/env -S bash -e"
+func InterpreterOf(line string) (string, bool) {
+	if !strings.HasPrefix(line, "#!") {
+		return "", false
+	}
+
+	fields := strings.Fields(line[2:])
+	if len(fields) == 0 {
+		return "", false
+	}
+
+	interpreter := filepath.Base(fields[0])
+
+	if interpreter == "env" {
+		// skip any options given to env, along with any variables it sets
+		interpreter = ""
+
+		for _, field := range fields[1:] {
+			if strings.HasPrefix(field, "-") || strings.Contains(field, "=") {
+				continue
+			}
+
+			interpreter = filepath.Base(field)
+			break
+		}
+	}
+
+	return interpreter, interpreter != ""
+}
+
+// ResolutionStep records a template name which was tried while resolving a template for a file
+type ResolutionStep struct {
+	Rule      ResolutionRule
+	Candidate string
+	Matched   bool
+}
+
+// Resolve tries each rule in order and returns the name of the first template which exists for the
+// given path, along with every step which was tried to get there.
+func (tm TemplateMap) Resolve(path string, rules []ResolutionRule) (string, []ResolutionStep, bool) {
+	var steps []ResolutionStep
+
+	for _, rule := range rules {
+		for _, candidate := range rule.candidates(path) {
+			// the default template is only used by its own strategy, so that it doesn't apply to
+			// files which happen to be named "default"
+			if candidate == DefaultTemplateName && rule.Strategy != ResolveDefault {
+				continue
+			}
+
+			_, ok := tm[candidate]
+
+			steps = append(steps, ResolutionStep{Rule: rule, Candidate: candidate, Matched: ok})
+
+			if ok {
+				return candidate, steps, true
+			}
+		}
+	}
+
+	return "", steps, false
+}
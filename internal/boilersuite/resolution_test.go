@@ -0,0 +1,317 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Resolve(t *testing.T) {
+	tm := TemplateMap{
+		"go":          {},
+		"yaml":        {},
+		"tf.json":     {},
+		"json":        {},
+		"Dockerfile":  {},
+		"Makefile":    {},
+		"BUILD":       {},
+		"BUILD.bazel": {},
+		"bazel":       {},
+
+		"CMakeLists.txt": {},
+		"txt":            {},
+	}
+
+	userRules := []ResolutionRule{
+		{Strategy: ResolvePattern, Pattern: "*.yaml.tmpl", Template: "yaml"},
+		{Strategy: ResolvePattern, Pattern: "deploy/*.tpl", Template: "go"},
+	}
+
+	tests := map[string]struct {
+		path     string
+		rules    []ResolutionRule
+		expected string
+	}{
+		"extension": {
+			path:     "pkg/main.go",
+			expected: "go",
+		},
+		"full filename": {
+			path:     "Makefile",
+			expected: "Makefile",
+		},
+		"basename prefix": {
+			path:     "build/Dockerfile.abc",
+			expected: "Dockerfile",
+		},
+		"full filename containing a dot": {
+			path:     "pkg/BUILD.bazel",
+			expected: "BUILD.bazel",
+		},
+		"full filename is preferred to extension": {
+			path:     "native/CMakeLists.txt",
+			expected: "CMakeLists.txt",
+		},
+		"extension is preferred to basename": {
+			path:     "WORKSPACE.bazel",
+			expected: "bazel",
+		},
+		"multi-dot suffix is preferred to extension": {
+			path:     "infra/main.tf.json",
+			expected: "tf.json",
+		},
+		"extension is preferred to multi-dot suffix when ordered first": {
+			path:     "infra/main.tf.json",
+			rules:    []ResolutionRule{{Strategy: ResolveExtension}, {Strategy: ResolveMultiSuffix}},
+			expected: "json",
+		},
+		"no template": {
+			path:     "config.yaml.tmpl",
+			expected: "",
+		},
+		"user rule on base name": {
+			path:     "config.yaml.tmpl",
+			rules:    append(userRules, DefaultResolutionRules...),
+			expected: "yaml",
+		},
+		"user rule on path": {
+			path:     "deploy/chart.tpl",
+			rules:    append(userRules, DefaultResolutionRules...),
+			expected: "go",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rules := test.rules
+			if rules == nil {
+				rules = DefaultResolutionRules
+			}
+
+			got, _, ok := tm.Resolve(test.path, rules)
+			if ok != (test.expected != "") {
+				t.Fatalf("ok=%v but expected %q", ok, test.expected)
+			}
+
+			if got != test.expected {
+				t.Errorf("got template %q, wanted %q", got, test.expected)
+			}
+		})
+	}
+}
+
+func Test_ResolveShebang(t *testing.T) {
+	tm := TemplateMap{
+		"sh":   {},
+		"bash": {},
+		"py":   {},
+	}
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"update-codegen": "#!/usr/bin/env bash\n\necho hello\n",
+		"install":        "#!/bin/sh -e\necho hello\n",
+		"generate":       "#!/usr/bin/env -S python3 -u\nprint('hello')\n",
+		"setup.zsh":      "#!/bin/zsh\necho hello\n",
+		"run-perl":       "#!/usr/bin/perl\nprint \"hello\";\n",
+		"OWNERS":         "approvers:\n- example\n",
+	}
+
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]struct {
+		path     string
+		expected string
+	}{
+		"bash script":                  {path: "update-codegen", expected: "bash"},
+		"interpreter with options":     {path: "install", expected: "sh"},
+		"env with options":             {path: "generate", expected: "py"},
+		"file with an extension":       {path: "setup.zsh"},
+		"interpreter with no template": {path: "run-perl"},
+		"no shebang":                   {path: "OWNERS"},
+		"missing file":                 {path: "missing"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, _, _ := tm.Resolve(filepath.Join(dir, test.path), DefaultResolutionRules)
+			if got != test.expected {
+				t.Errorf("got template %q, wanted %q", got, test.expected)
+			}
+		})
+	}
+}
+
+func Test_ResolveDefault(t *testing.T) {
+	tm := TemplateMap{
+		"go":                {},
+		DefaultTemplateName: {},
+	}
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.go":  "package main\n",
+		"main.nim": "echo \"hello\"\n",
+		"default":  "echo \"hello\"\n",
+		"logo.png": "\x89PNG\r\n\x1a\n\x00\x00",
+		"py.typed": "",
+		"data.bin": "\x1f\x8b\x08\x08compressed\x80",
+		"x.json":   "{\"a\": 1}\n",
+		"LICENSE":  "Permission is hereby granted, free of charge\n",
+		"gen.nim":  "echo \"generated\"\n",
+	}
+
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rules := append(append([]ResolutionRule(nil), DefaultResolutionRules...), ResolutionRule{Strategy: ResolveDefault, Exclude: []string{"*.json", "LICENSE*", dir + "/gen.*"}})
+
+	tests := map[string]struct {
+		path     string
+		rules    []ResolutionRule
+		expected string
+	}{
+		"file with a template":          {path: "main.go", rules: rules, expected: "go"},
+		"file without a template":       {path: "main.nim", rules: rules, expected: DefaultTemplateName},
+		"binary file":                   {path: "logo.png", rules: rules},
+		"binary file without NUL bytes": {path: "data.bin", rules: rules},
+		"excluded extension":            {path: "x.json", rules: rules},
+		"excluded file name":            {path: "LICENSE", rules: rules},
+		"excluded path":                 {path: "gen.nim", rules: rules},
+		"empty file":                    {path: "py.typed", rules: rules},
+		"default strategy disabled":     {path: "main.nim", rules: DefaultResolutionRules},
+		"file named after the template": {path: "default", rules: DefaultResolutionRules},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, _, _ := tm.Resolve(filepath.Join(dir, test.path), test.rules)
+			if got != test.expected {
+				t.Errorf("got template %q, wanted %q", got, test.expected)
+			}
+		})
+	}
+}
+
+func Test_isText(t *testing.T) {
+	tests := map[string]struct {
+		head      string
+		truncated bool
+		expected  bool
+	}{
+		"ascii":                            {head: "echo hello\n", expected: true},
+		"empty":                            {head: ""},
+		"NUL byte":                         {head: "a\x00b"},
+		"invalid UTF-8":                    {head: "\x1f\x8b\x08"},
+		"UTF-16":                           {head: "\xff\xfeh\x00i\x00", expected: true},
+		"character cut off by truncation":  {head: "caf\xc3", truncated: true, expected: true},
+		"character cut off without reason": {head: "caf\xc3"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isText([]byte(test.head), test.truncated); got != test.expected {
+				t.Errorf("got %v, wanted %v", got, test.expected)
+			}
+		})
+	}
+}
+
+func Test_InterpreterOf(t *testing.T) {
+	tests := map[string]struct {
+		line     string
+		expected string
+	}{
+		"absolute path":         {line: "#!/bin/bash\n", expected: "bash"},
+		"env":                   {line: "#!/usr/bin/env python3\n", expected: "python3"},
+		"env with options":      {line: "#!/usr/bin/env -S VAR=1 node --harmony\n", expected: "node"},
+		"space after marker":    {line: "#! /bin/sh\n", expected: "sh"},
+		"no shebang":            {line: "# just a comment\n"},
+		"empty shebang":         {line: "#!\n"},
+		"env with no arguments": {line: "#!/usr/bin/env\n"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := InterpreterOf(test.line)
+			if ok != (test.expected != "") || got != test.expected {
+				t.Errorf("got %q (ok=%v), wanted %q", got, ok, test.expected)
+			}
+		})
+	}
+}
+
+func Test_ParseResolutionRule(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		expectErr bool
+	}{
+		"strategy":          {input: "multi-suffix"},
+		"shebang strategy":  {input: "shebang"},
+		"pattern":           {input: "*.yaml.tmpl=yaml"},
+		"unknown strategy":  {input: "magic", expectErr: true},
+		"missing template":  {input: "*.yaml.tmpl=", expectErr: true},
+		"malformed pattern": {input: "[=yaml", expectErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, err := ParseResolutionRule(test.input)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if err == nil && rule.String() != test.input {
+				t.Errorf("String()=%q, expected %q", rule.String(), test.input)
+			}
+		})
+	}
+}
+
+func Test_MatchGlob(t *testing.T) {
+	tests := map[string]struct {
+		pattern     string
+		name        string
+		shouldMatch bool
+	}{
+		"double star matches nested files":      {pattern: "cmd/legacy/**", name: "cmd/legacy/a/b/main.go", shouldMatch: true},
+		"double star matches direct children":   {pattern: "cmd/legacy/**", name: "cmd/legacy/main.go", shouldMatch: true},
+		"double star doesn't match siblings":    {pattern: "cmd/legacy/**", name: "cmd/new/main.go", shouldMatch: false},
+		"double star in the middle":             {pattern: "**/testdata/*.go", name: "pkg/x/testdata/a.go", shouldMatch: true},
+		"double star matching nothing":          {pattern: "**/*.go", name: "main.go", shouldMatch: true},
+		"single star doesn't cross directories": {pattern: "cmd/*.go", name: "cmd/x/main.go", shouldMatch: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if matched := MatchGlob(test.pattern, test.name); matched != test.shouldMatch {
+				t.Errorf("matched=%v, shouldMatch=%v", matched, test.shouldMatch)
+			}
+		})
+	}
+}
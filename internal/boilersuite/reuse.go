@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"strings"
+)
+
+// commentClosers are suffixes which end comments on the same line as a REUSE tag, and so aren't
+// part of the tag's value
+var commentClosers = []string{"*/", "-->", "#>", "*)", "-}"}
+
+// REUSEInfo holds the copyright and licensing information which the REUSE specification requires
+// for every file
+type REUSEInfo struct {
+	// Copyrights holds each copyright notice, without the "SPDX-FileCopyrightText:" or "Copyright"
+	// prefix
+	Copyrights []string
+
+	// Licenses holds each SPDX license expression, such as "Apache-2.0 OR MIT"
+	Licenses []string
+}
+
+// FindREUSEInfo returns the copyright notices and license expressions found in the given contents,
+// which are usually either the start of a file or the whole of its ".license" companion file
+func FindREUSEInfo(contents string) REUSEInfo {
+	var info REUSEInfo
+
+	for _, match := range REUSECopyrightRegex.FindAllStringSubmatch(contents, -1) {
+		if notice := trimTagValue(match[1]); notice != "" {
+			info.Copyrights = append(info.Copyrights, notice)
+		}
+	}
+
+	for _, match := range REUSELicenseRegex.FindAllStringSubmatch(contents, -1) {
+		if expression := trimTagValue(match[1]); expression != "" {
+			info.Licenses = append(info.Licenses, expression)
+		}
+	}
+
+	return info
+}
+
+// LicenseIdentifiers returns the license and exception identifiers used in an SPDX license
+// expression, e.g. ["Apache-2.0", "MIT"] for "(Apache-2.0 OR MIT)". A trailing "+" meaning "or
+// later" is removed.
+func LicenseIdentifiers(expression string) []string {
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expression))
+
+	var ids []string
+
+	for _, field := range fields {
+		switch strings.ToUpper(field) {
+		case "AND", "OR", "WITH":
+			continue
+		}
+
+		ids = append(ids, strings.TrimSuffix(field, "+"))
+	}
+
+	return ids
+}
+
+// trimTagValue removes whitespace and any comment closer from the end of the value of a tag
+func trimTagValue(value string) string {
+	value = strings.TrimSpace(value)
+
+	for _, closer := range commentClosers {
+		value = strings.TrimSpace(strings.TrimSuffix(value, closer))
+	}
+
+	return value
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_FindREUSEInfo(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected REUSEInfo
+	}{
+		"SPDX tags": {
+			input: "// SPDX-FileCopyrightText: 2024 Jane Doe <jane@example.com>\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+			expected: REUSEInfo{
+				Copyrights: []string{"2024 Jane Doe <jane@example.com>"},
+				Licenses:   []string{"Apache-2.0"},
+			},
+		},
+		"copyright notice": {
+			input: "# Copyright 2024 The cert-manager Authors.\n# SPDX-License-Identifier: MIT\n",
+			expected: REUSEInfo{
+				Copyrights: []string{"2024 The cert-manager Authors."},
+				Licenses:   []string{"MIT"},
+			},
+		},
+		"tags in a block comment": {
+			input: "/* SPDX-FileCopyrightText: 2024 Jane Doe */\n/* SPDX-License-Identifier: MIT OR Apache-2.0 */\n",
+			expected: REUSEInfo{
+				Copyrights: []string{"2024 Jane Doe"},
+				Licenses:   []string{"MIT OR Apache-2.0"},
+			},
+		},
+		"tag in the middle of a line": {
+			input:    "fmt.Println(\"SPDX-License-Identifier: MIT\")\n",
+			expected: REUSEInfo{},
+		},
+		"no information": {
+			input:    "package main\n",
+			expected: REUSEInfo{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := FindREUSEInfo(test.input)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("got %#v, wanted %#v", got, test.expected)
+			}
+		})
+	}
+}
+
+func Test_LicenseIdentifiers(t *testing.T) {
+	tests := map[string][]string{
+		"MIT":                                {"MIT"},
+		"Apache-2.0 OR MIT":                  {"Apache-2.0", "MIT"},
+		"(MIT AND BSD-3-Clause) or GPL-2.0+": {"MIT", "BSD-3-Clause", "GPL-2.0"},
+		"GPL-2.0-only WITH Classpath-exception-2.0": {"GPL-2.0-only", "Classpath-exception-2.0"},
+	}
+
+	for expression, expected := range tests {
+		t.Run(expression, func(t *testing.T) {
+			if got := LicenseIdentifiers(expression); !reflect.DeepEqual(got, expected) {
+				t.Errorf("got %q, wanted %q", got, expected)
+			}
+		})
+	}
+}
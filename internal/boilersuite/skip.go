@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"bytes"
+	"strings"
+)
+
+// binarySniffLength is how much of a file is searched when deciding whether it's binary. It
+// matches the amount git searches.
+const binarySniffLength = 8000
+
+var (
+	// AlwaysSkippedDirs holds the names of directories which are never checked
+	AlwaysSkippedDirs = []string{".git", "_bin", "bin", "node_modules", "vendor", "third_party", "staging"}
+)
+
+// IsSkippedFilename returns true for files which are never checked, even though they might
+// match a template (e.g. "go.mod" would otherwise match the "go" template)
+func IsSkippedFilename(filename string) bool {
+	if filename == "go.mod" || filename == "go.sum" || filename == "go.work" || filename == "go.work.sum" {
+		return true
+	}
+
+	if strings.HasPrefix(filename, "zz_generated") {
+		return true
+	}
+
+	return false
+}
+
+// IsBinary returns true if the given contents seem to be binary rather than text, because there's a
+// NUL byte near the start. Such files are never checked, even if they match a template. UTF-16 files
+// contain NUL bytes but are text, so they're recognised by their byte order mark.
+func IsBinary(contents []byte) bool {
+	if IsUTF16(contents) {
+		return false
+	}
+
+	if len(contents) > binarySniffLength {
+		contents = contents[:binarySniffLength]
+	}
+
+	return bytes.IndexByte(contents, 0) != -1
+}
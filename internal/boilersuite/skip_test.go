@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_IsBinary(t *testing.T) {
+	tests := map[string]struct {
+		contents string
+		expected bool
+	}{
+		"text": {
+			contents: "#!/bin/sh\n\necho hello\n",
+			expected: false,
+		},
+		"empty": {
+			contents: "",
+			expected: false,
+		},
+		"NUL byte": {
+			contents: "\x7fELF\x02\x01\x01\x00\x00",
+			expected: true,
+		},
+		"UTF-16 text": {
+			contents: "\xff\xfe#\x00!\x00",
+			expected: false,
+		},
+		"NUL byte past the sniffed region": {
+			contents: strings.Repeat("a", binarySniffLength) + "\x00",
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsBinary([]byte(test.contents)); got != test.expected {
+				t.Errorf("got %v, wanted %v", got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Suppression describes a skip_license_check marker found in a file
+type Suppression struct {
+	// Line is the line of the file on which the marker was found, starting from 1
+	Line int
+
+	// Until is the last day on which the marker is honoured. It's zero if the marker never expires.
+	Until time.Time
+
+	// Reason explains why the file is skipped, if given
+	Reason string
+}
+
+// String describes the marker for use in error messages
+func (s Suppression) String() string {
+	if s.Reason == "" {
+		return "skip_license_check marker"
+	}
+
+	return fmt.Sprintf("skip_license_check marker (reason: %s)", s.Reason)
+}
+
+// ExpiresAt returns the time at which the marker stops being honoured, or the zero time if it
+// never expires
+func (s Suppression) ExpiresAt() time.Time {
+	if s.Until.IsZero() {
+		return time.Time{}
+	}
+
+	// the marker is valid for the whole of the day on which it expires
+	return s.Until.AddDate(0, 0, 1)
+}
+
+// Expired returns true if the marker has an expiry date which has passed
+func (s Suppression) Expired() bool {
+	expiry := s.ExpiresAt()
+
+	return !expiry.IsZero() && !now().Before(expiry)
+}
+
+// FindSuppression returns the skip_license_check marker in the given file, if there is one. An
+// error is returned if the marker has an invalid or unknown attribute.
+func FindSuppression(raw string) (Suppression, bool, error) {
+	loc := SkipFileRegex.FindStringSubmatchIndex(raw)
+	if loc == nil {
+		return Suppression{}, false, nil
+	}
+
+	suppression := Suppression{
+		Line: strings.Count(raw[:loc[0]], "\n") + 1,
+	}
+
+	attributes := raw[loc[4]:loc[5]]
+
+	for _, attribute := range SkipAttributeRegex.FindAllStringSubmatch(attributes, -1) {
+		key, value := attribute[1], strings.Trim(attribute[2], `"`)
+
+		switch key {
+		case "until":
+			until, err := time.Parse(SkipExpiryLayout, value)
+			if err != nil {
+				return Suppression{}, false, fmt.Errorf("invalid expiry date %q on skip_license_check marker; expected a date like %s", value, SkipExpiryLayout)
+			}
+
+			suppression.Until = until
+
+		case "reason":
+			suppression.Reason = value
+
+		default:
+			return Suppression{}, false, fmt.Errorf("unknown attribute %q on skip_license_check marker; expected \"until\" or \"reason\"", key)
+		}
+	}
+
+	return suppression, true, nil
+}
+
+// checkSkipMarker determines whether the given file should be skipped because of a skip marker.
+// If the file has a skip marker whose expiry date has passed, the file is not skipped and the
+// marker is returned so that it can be reported.
+func checkSkipMarker(raw string) (bool, *Suppression, error) {
+	suppression, ok, err := FindSuppression(raw)
+	if err != nil || !ok {
+		return false, nil, err
+	}
+
+	if suppression.Expired() {
+		return false, &suppression, nil
+	}
+
+	return true, nil, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_FindSuppression(t *testing.T) {
+	tests := map[string]struct {
+		input        string
+		expectFound  bool
+		expectErr    bool
+		expectLine   int
+		expectUntil  string
+		expectReason string
+	}{
+		"no marker": {
+			input: "package main\n",
+		},
+		"bare marker": {
+			input:       "package main\n\n// +skip_license_check\n",
+			expectFound: true,
+			expectLine:  3,
+		},
+		"expiry and reason": {
+			input:        "# +skip_license_check until=2026-06-01 reason=vendored-temporarily\n",
+			expectFound:  true,
+			expectLine:   1,
+			expectUntil:  "2026-06-01",
+			expectReason: "vendored-temporarily",
+		},
+		"quoted reason before expiry": {
+			input:        "# +skip_license_check reason=\"copied from upstream\" until=2026-06-01\n",
+			expectFound:  true,
+			expectLine:   1,
+			expectUntil:  "2026-06-01",
+			expectReason: "copied from upstream",
+		},
+		"markdown comment with expiry and reason": {
+			input:        "# Notes\n<!-- +skip_license_check until=2026-06-01 reason=\"copied from upstream\" -->\n",
+			expectFound:  true,
+			expectLine:   2,
+			expectUntil:  "2026-06-01",
+			expectReason: "copied from upstream",
+		},
+		"go template comment with expiry and reason": {
+			input:        "{{- /* +skip_license_check until=2026-06-01 reason=\"copied from upstream\" */ -}}\n",
+			expectFound:  true,
+			expectLine:   1,
+			expectUntil:  "2026-06-01",
+			expectReason: "copied from upstream",
+		},
+		"unknown attribute": {
+			input:     "# +skip_license_check owner=someone\n",
+			expectErr: true,
+		},
+		"invalid expiry": {
+			input:     "# +skip_license_check until=soon reason=testing\n",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			suppression, found, err := FindSuppression(test.input)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if found != test.expectFound {
+				t.Fatalf("found=%v, expectFound=%v", found, test.expectFound)
+			}
+
+			if !found {
+				return
+			}
+
+			var until string
+			if !suppression.Until.IsZero() {
+				until = suppression.Until.Format(SkipExpiryLayout)
+			}
+
+			if suppression.Line != test.expectLine || until != test.expectUntil || suppression.Reason != test.expectReason {
+				t.Errorf("got line=%d until=%q reason=%q, expected line=%d until=%q reason=%q", suppression.Line, until, suppression.Reason, test.expectLine, test.expectUntil, test.expectReason)
+			}
+		})
+	}
+}
+
+func Test_ExpiredSuppressionError(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+
+	now = func() time.Time {
+		return time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	tmpl := mustTestTemplate(t)
+
+	err := tmpl.Validate("#!/bin/sh\n# +skip_license_check until=2026-06-01 reason=vendored-temporarily\n\necho hello\necho hello\n")
+	if err == nil {
+		t.Fatalf("expected an expired suppression to fail validation")
+	}
+
+	expected := "skip_license_check marker (reason: vendored-temporarily) expired on 2026-06-01: "
+	if !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("got error %q, expected it to start with %q", err.Error(), expected)
+	}
+}
@@ -17,10 +17,43 @@ limitations under the License.
 package boilersuite
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// SkipExpiryLayout is the layout of the date given in the "until" field of a skip marker
+const SkipExpiryLayout = "2006-01-02"
+
+// now returns the current time, and is overridden in tests
+var now = time.Now
+
+// TemplateKind describes how a template is matched against files
+type TemplateKind int
+
+const (
+	// TemplateKindBlock templates are multi-line blocks which must appear at the start of a file
+	TemplateKindBlock TemplateKind = iota
+
+	// TemplateKindLine templates are a single line, used for formats such as .ini or .editorconfig
+	// where large comment blocks are unidiomatic. The line can appear anywhere in the first few
+	// lines of a file, and surrounding whitespace is ignored.
+	TemplateKindLine
+)
+
+// lineTemplateSearchLines is the number of lines at the start of a file in which a TemplateKindLine
+// template is searched for
+const lineTemplateSearchLines = 5
+
+// extractYearSearchLines is the number of lines at the start of a file in which a copyright year is searched for
+const extractYearSearchLines = 20
+
 // BoilerplateTemplate takes a raw template as input and pre-processes it so it's ready for use
 // during validation.
 type BoilerplateTemplate struct {
@@ -29,7 +62,47 @@ type BoilerplateTemplate struct {
 
 	lineCount int
 
+	kind TemplateKind
+
 	normalizationFunc func(string) string
+
+	preambleRegex *regexp.Regexp
+
+	// boilerplateBeforePreamble allows boilerplate to come before the preamble as well as after it
+	boilerplateBeforePreamble bool
+
+	// exemptFrontMatter stops files which start with YAML front matter from being checked
+	exemptFrontMatter bool
+
+	// anyYear accepts any plausible year or range of years matched by AnyYearRegex, rather than
+	// only those matched by DateRegex
+	anyYear bool
+
+	// ignoreYear accepts anything in place of the year marker
+	ignoreYear bool
+
+	// years, if set, replaces the year marker when adding boilerplate, rather than the year given
+	// to Fix. It holds any literal copyright years which were in the template.
+	years string
+
+	// extraCopyright decides whether copyright lines for other holders may be stacked next to the
+	// template's own copyright line. If empty, they're forbidden.
+	extraCopyright ExtraCopyrightPolicy
+
+	// alternatives are other templates which are also acceptable for files matched by this template
+	alternatives []BoilerplateTemplate
+
+	// generated decides whether files are generated. If nil, DefaultGeneratedMatcher is used.
+	generated *GeneratedMatcher
+
+	// licensePolicy decides which licenses files may declare. If nil, any license is allowed.
+	licensePolicy *LicensePolicy
+
+	// authorRegex, if set, matches the authors which are accepted in place of the <<AUTHOR>>
+	// marker. authorLines holds a regular expression for each line of the template containing the
+	// marker, keyed by line number.
+	authorRegex *regexp.Regexp
+	authorLines map[int]*regexp.Regexp
 }
 
 // BoilerplateTemplateConfiguration holds configuration values which can be used for pre-processing a template
@@ -42,14 +115,21 @@ type BoilerplateTemplateConfiguration struct {
 	// files matched by this template. For example, in go files we might need
 	// to remove golang build constraints
 	NormalizationFunc func(string) string
+
+	// PreambleRegex optionally matches content which must stay at the very start of a file, above any
+	// boilerplate. For example, in scripts the shebang must be the first line. Used when adding
+	// boilerplate to a file.
+	PreambleRegex *regexp.Regexp
+
+	// BoilerplateBeforePreamble allows boilerplate to come before the preamble as well as after it,
+	// for formats where either is valid. Boilerplate is still added after the preamble.
+	BoilerplateBeforePreamble bool
 }
 
-// NewBoilerplateTemplate creates a new boilerplate template using the given raw template and configuration
+// NewBoilerplateTemplate creates a new boilerplate template using the given raw template and configuration.
+// The <<YEAR>> marker is optional, for projects whose headers don't carry a year, e.g. "Copyright The
+// cert-manager Authors".
 func NewBoilerplateTemplate(raw string, config BoilerplateTemplateConfiguration) (BoilerplateTemplate, error) {
-	if !YearMarkerRegex.MatchString(raw) {
-		return BoilerplateTemplate{}, fmt.Errorf("invalid template: couldn't find year replacement marker %s", YearMarkerRegex.String())
-	}
-
 	if !AuthorMarkerRegex.MatchString(raw) {
 		return BoilerplateTemplate{}, fmt.Errorf("invalid template: couldn't find author replacement marker %s", AuthorMarkerRegex.String())
 	}
@@ -58,35 +138,432 @@ func NewBoilerplateTemplate(raw string, config BoilerplateTemplateConfiguration)
 
 	lineCount := strings.Count(replaced, "\n") + 1
 
+	kind := TemplateKindBlock
+	if !strings.Contains(strings.TrimSpace(replaced), "\n") {
+		kind = TemplateKindLine
+	}
+
 	return BoilerplateTemplate{
 		raw:               raw,
 		replaced:          replaced,
 		lineCount:         lineCount,
+		kind:              kind,
 		normalizationFunc: config.NormalizationFunc,
+		preambleRegex:     config.PreambleRegex,
+
+		boilerplateBeforePreamble: config.BoilerplateBeforePreamble,
 	}, nil
 }
 
-// Validate checks the given raw input file against the template
+// Validate checks the given raw input file against the template. A UTF-8 byte order mark at the
+// start of the file is ignored.
 func (t BoilerplateTemplate) Validate(raw string) error {
-	if SkipFileRegex.MatchString(raw) || GeneratedRegex.MatchString(raw) {
+	raw, _ = trimByteOrderMark(raw)
+
+	if t.isGenerated(raw) || t.isExemptFrontMatter(raw) {
 		return nil
 	}
 
-	normalizedContents, err := t.normalizeAndTrimFile(raw)
+	skip, expired, err := checkSkipMarker(raw)
 	if err != nil {
 		return err
 	}
 
+	if skip {
+		return nil
+	}
+
+	if err := t.checkLicensePolicy(raw); err != nil {
+		return err
+	}
+
+	err = t.validateContents(raw)
+	if err != nil && expired != nil {
+		return fmt.Errorf("%s expired on %s: %w", expired, expired.Until.Format(SkipExpiryLayout), err)
+	}
+
+	return err
+}
+
+// Kind returns the kind of the template
+func (t BoilerplateTemplate) Kind() TemplateKind {
+	return t.kind
+}
+
+// hasYear returns true if the template has a <<YEAR>> marker
+func (t BoilerplateTemplate) hasYear() bool {
+	return YearMarkerRegex.MatchString(t.raw)
+}
+
+// WithAlternatives returns a copy of the template which also accepts files matching any of the
+// given templates. The original template is still used when adding boilerplate to files.
+func (t BoilerplateTemplate) WithAlternatives(alternatives ...BoilerplateTemplate) BoilerplateTemplate {
+	t.alternatives = append(append([]BoilerplateTemplate(nil), t.alternatives...), alternatives...)
+	return t
+}
+
+// withCommentPrefix returns a copy of the template with each line commented using prefix.
+// Empty lines are commented without a trailing space, e.g. "#" rather than "# ".
+func (t BoilerplateTemplate) withCommentPrefix(prefix string) BoilerplateTemplate {
+	t.raw = commentLines(t.raw, prefix)
+	t.replaced = commentLines(t.replaced, prefix)
+
+	return t
+}
+
+// commentLines prefixes each line of raw with prefix, leaving any trailing newlines as they are
+func commentLines(raw string, prefix string) string {
+	body := strings.TrimRight(raw, "\n")
+	lines := strings.Split(body, "\n")
+
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = prefix
+		} else {
+			lines[i] = prefix + " " + line
+		}
+	}
+
+	return strings.Join(lines, "\n") + raw[len(body):]
+}
+
+// Fingerprint returns a string which changes whenever the template, its alternatives or the way
+// generated files are identified changes, and so can be used to invalidate cached results
+func (t BoilerplateTemplate) Fingerprint() string {
+	h := sha256.New()
+
+	t.writeFingerprint(h)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (t BoilerplateTemplate) writeFingerprint(w io.Writer) {
+	fmt.Fprintf(w, "%d\x00%q\x00", t.kind, t.replaced)
+
+	if t.preambleRegex != nil {
+		fmt.Fprintf(w, "preamble=%q,%t\x00", t.preambleRegex.String(), t.boilerplateBeforePreamble)
+	}
+
+	fmt.Fprintf(w, "exemptFrontMatter=%t,anyYear=%t,ignoreYear=%t\x00", t.exemptFrontMatter, t.anyYear, t.ignoreYear)
+
+	if t.extraCopyright != "" {
+		fmt.Fprintf(w, "extraCopyright=%s\x00", t.extraCopyright)
+	}
+
+	if t.generated != nil {
+		fmt.Fprintf(w, "generated=%d", t.generated.MaxLines)
+
+		for _, pattern := range t.generated.Patterns {
+			fmt.Fprintf(w, ",%q", pattern.String())
+		}
+
+		fmt.Fprint(w, "\x00")
+	}
+
+	if t.authorRegex != nil {
+		fmt.Fprintf(w, "authorRegex=%q\x00", t.authorRegex.String())
+	}
+
+	if t.licensePolicy != nil {
+		fmt.Fprintf(w, "licensePolicy=%q,%q\x00", t.licensePolicy.License, strings.Join(t.licensePolicy.Incompatible, ","))
+	}
+
+	for _, alternative := range t.alternatives {
+		fmt.Fprint(w, "alternative\x00")
+		alternative.writeFingerprint(w)
+	}
+}
+
+func (t BoilerplateTemplate) isExemptFrontMatter(raw string) bool {
+	return t.exemptFrontMatter && FrontMatterRegex.MatchString(raw)
+}
+
+// validateContents checks the file against the template and each of its alternatives, passing if
+// any one of them matches. If none match, the error from the most similar template is returned so
+// that any suggestion is as relevant as possible.
+func (t BoilerplateTemplate) validateContents(raw string) error {
+	err := t.validateSingle(raw)
+	if err == nil || len(t.alternatives) == 0 {
+		return err
+	}
+
+	best := err
+
+	for _, alternative := range t.alternatives {
+		altErr := alternative.validateContents(raw)
+		if altErr == nil {
+			return nil
+		}
+
+		if similarityOf(altErr) > similarityOf(best) {
+			best = altErr
+		}
+	}
+
+	return best
+}
+
+func similarityOf(err error) float64 {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Similarity
+	}
+
+	return 0
+}
+
+func (t BoilerplateTemplate) validateSingle(raw string) error {
+	if t.kind == TemplateKindLine {
+		return t.validateLine(raw)
+	}
+
+	normalizedContents, extraCopyrightLines, err := t.normalizeAndTrimFile(raw)
+	normalizedContents = t.normalizeAuthor(t.normalizeIgnoredYears(normalizedContents))
+
+	if err != nil {
+		// a file holding nothing but boilerplate doesn't need the blank line which usually follows it,
+		// or even a final newline
+		if strings.TrimRight(normalizedContents, "\n") == strings.TrimRight(t.replaced, "\n") {
+			return nil
+		}
+
+		if misplaced := t.findMisplaced(raw); misplaced != nil {
+			return misplaced
+		}
+
+		kind := ErrorKindTooShort
+		if !REUSECopyrightRegex.MatchString(normalizedContents) {
+			kind = ErrorKindMissingBoilerplate
+		}
+
+		return &ValidationError{Reason: err.Error(), Kind: kind}
+	}
+
 	if !strings.HasPrefix(normalizedContents, t.replaced) {
-		return fmt.Errorf("does not start with expected template type")
+		if misplaced := t.findMisplaced(raw); misplaced != nil {
+			return misplaced
+		}
+
+		mismatch := newMismatchError(normalizedContents, t.replaced)
+		mismatch.Kind = t.mismatchKind(normalizedContents, mismatch)
+
+		return mismatch
+	}
+
+	if misplaced := t.findMisplacedPreamble(raw); misplaced != nil {
+		return misplaced
+	}
+
+	if t.extraCopyright == ExtraCopyrightRequired && extraCopyrightLines == 0 {
+		return &ValidationError{
+			Reason: "expected at least one other copyright line next to the expected copyright line",
+			Kind:   ErrorKindMalformedHeader,
+		}
 	}
 
 	return nil
 }
 
+func (t BoilerplateTemplate) validateLine(raw string) error {
+	expected := strings.TrimSpace(t.replaced)
+
+	kind := ErrorKindMissingBoilerplate
+
+	for _, line := range t.searchLines(raw) {
+		line = t.normalizeYears(line)
+
+		if strings.TrimSpace(line) == expected || t.matchesAuthorLine(strings.TrimSpace(line)) || t.matchesIgnoringYear(line, 0) {
+			return nil
+		}
+
+		// the most specific kind of any copyright line describes the failure
+		if kind != ErrorKindWrongAuthor && kind != ErrorKindWrongYear && REUSECopyrightRegex.MatchString(line) {
+			kind = t.lineMismatchKind(line, 0)
+		}
+	}
+
+	return &ValidationError{
+		Reason: fmt.Sprintf("does not contain expected one-line boilerplate in the first %d lines", lineTemplateSearchLines),
+		Kind:   kind,
+	}
+}
+
+// searchLines returns the lines of the given file in which a TemplateKindLine template is searched for
+func (t BoilerplateTemplate) searchLines(raw string) []string {
+	raw = strings.ReplaceAll(raw, "\r", "")
+
+	if t.normalizationFunc != nil {
+		raw = t.normalizationFunc(raw)
+	}
+
+	lines := strings.SplitN(raw, "\n", lineTemplateSearchLines+1)
+	if len(lines) > lineTemplateSearchLines {
+		lines = lines[:lineTemplateSearchLines]
+	}
+
+	return lines
+}
+
+// FixOptions configures how boilerplate is added to files
+type FixOptions struct {
+	// Year is substituted for the <<YEAR>> marker in any added boilerplate
+	Year int
+
+	// IncludeGenerated adds boilerplate to generated files, which would otherwise be left
+	// unchanged since they're not validated
+	IncludeGenerated bool
+
+	// AddTrailingNewline adds a line ending to the end of files which don't have one. Otherwise,
+	// the end of each file is left as it was.
+	AddTrailingNewline bool
+
+	// NormalizeCopyright removes any copyright symbol, such as "(c)" or "©", from the copyright
+	// line of valid boilerplate, e.g. changing "Copyright (c) 2024" to "Copyright 2024"
+	NormalizeCopyright bool
+}
+
+// Fix returns the given raw input file with boilerplate added after any preamble. Files which
+// already pass validation are returned unchanged. Files which seem to already have boilerplate
+// which doesn't match the template, or which declare a license that the template's license policy
+// doesn't allow, can't be fixed safely, and an error is returned for them.
+// A UTF-8 byte order mark at the start of the file is kept there, and added boilerplate uses the
+// same line endings as the file.
+func (t BoilerplateTemplate) Fix(raw string, opts FixOptions) (string, error) {
+	if trimmed, ok := trimByteOrderMark(raw); ok {
+		fixed, err := t.Fix(trimmed, opts)
+		if err != nil {
+			return "", err
+		}
+
+		return byteOrderMark + fixed, nil
+	}
+
+	fixed, err := t.addBoilerplate(raw, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.NormalizeCopyright {
+		fixed = t.normalizeCopyrightSymbol(fixed)
+	}
+
+	if opts.AddTrailingNewline && fixed != "" && !strings.HasSuffix(fixed, "\n") {
+		fixed += lineEnding(fixed)
+	}
+
+	return fixed, nil
+}
+
+// addBoilerplate adds boilerplate to raw after any preamble, unless it already passes validation
+func (t BoilerplateTemplate) addBoilerplate(raw string, opts FixOptions) (string, error) {
+	var validationErr error
+
+	if opts.IncludeGenerated {
+		skip, _, err := checkSkipMarker(raw)
+		if err != nil {
+			return "", err
+		}
+
+		if skip || t.isExemptFrontMatter(raw) {
+			return raw, nil
+		}
+
+		validationErr = t.checkLicensePolicy(raw)
+		if validationErr == nil {
+			validationErr = t.validateContents(raw)
+		}
+	} else {
+		validationErr = t.Validate(raw)
+	}
+
+	if validationErr == nil {
+		return raw, nil
+	}
+
+	// replacing the header of a file under an incompatible license would hide the problem
+	var incompatible *IncompatibleLicenseError
+	if errors.As(validationErr, &incompatible) {
+		return "", validationErr
+	}
+
+	// the error may have come from an alternative template, whose boilerplate is the one to move
+	var misplaced *ValidationError
+	if errors.As(validationErr, &misplaced) && misplaced.Misplaced {
+		return misplaced.template.moveHeader(raw, misplaced)
+	}
+
+	beginning := fileBeginning(raw, t.lineCount)
+	if t.kind == TemplateKindLine {
+		beginning = strings.Join(t.searchLines(raw), "\n")
+	}
+
+	// a dated line or any copyright line, such as a yearless "Copyright The X Authors.", suggests a
+	// header which is out of date and which inserting another would duplicate
+	if DateRegex.MatchString(beginning) || REUSECopyrightRegex.MatchString(beginning) {
+		return "", fmt.Errorf("found existing boilerplate which doesn't match the template; it must be fixed manually")
+	}
+
+	years := strconv.Itoa(opts.Year)
+	if t.years != "" {
+		years = t.years
+	}
+
+	header := YearMarkerRegex.ReplaceAllString(t.replaced, years)
+
+	if t.kind == TemplateKindLine {
+		// one-line boilerplate is separated from the rest of the file by a blank line
+		header = strings.TrimSpace(header) + "\n\n"
+	}
+
+	return t.insertHeader(raw, header)
+}
+
+// insertHeader adds the given header, which uses "\n" line endings, to raw after any preamble. The
+// header is written using the same line endings as raw.
+func (t BoilerplateTemplate) insertHeader(raw string, header string) (string, error) {
+	eol := lineEnding(raw)
+
+	preambleEnd := 0
+
+	if t.preambleRegex != nil {
+		if loc := t.preambleRegex.FindStringIndex(raw); loc != nil && loc[0] == 0 {
+			preambleEnd = loc[1]
+		}
+	}
+
+	// editor modelines stay above the boilerplate too
+	preambleEnd += modelinesLength(raw[preambleEnd:])
+
+	preamble, rest := "", raw
+
+	if preambleEnd > 0 {
+		// leave a blank line between the preamble and the boilerplate
+		preamble, rest = raw[:preambleEnd]+eol, raw[preambleEnd:]
+	}
+
+	rest = strings.TrimLeft(rest, "\r\n")
+
+	if rest == "" {
+		// a file holding nothing but boilerplate ends straight after it, rather than with a blank line
+		header = strings.TrimRight(header, "\n") + "\n"
+	}
+
+	header = strings.ReplaceAll(header, "\n", eol)
+
+	fixed := preamble + header + rest
+
+	if err := t.validateContents(fixed); err != nil {
+		// shouldn't happen, but guards against writing out a file which still wouldn't validate
+		return "", fmt.Errorf("file still invalid after adding boilerplate: %w", err)
+	}
+
+	return fixed, nil
+}
+
 // normalizeAndTrimFile takes a given input file and strips any shebang lines,
-// Golang build constraints and any leading or trailing whitespace
-func (t BoilerplateTemplate) normalizeAndTrimFile(raw string) (string, error) {
+// Golang build constraints and any leading or trailing whitespace. It also strips any extra
+// copyright lines accepted by the template, returning how many were stripped.
+func (t BoilerplateTemplate) normalizeAndTrimFile(raw string) (string, int, error) {
 	raw = strings.ReplaceAll(raw, "\r", "")
 
 	raw = fileBeginning(raw, t.lineCount)
@@ -95,20 +572,51 @@ func (t BoilerplateTemplate) normalizeAndTrimFile(raw string) (string, error) {
 		raw = t.normalizationFunc(raw)
 	}
 
+	// editor modelines can come before the boilerplate
+	raw = strings.TrimLeft(raw, "\n")
+	raw = raw[modelinesLength(raw):]
+
 	// replace anything which looks like a date with the year marker
-	raw = DateRegex.ReplaceAllString(raw, "Copyright "+YearMarkerRegex.String())
+	raw = t.normalizeYears(raw)
 
 	// Remove any windows-style line feeds in the raw input
 
 	raw = strings.TrimLeft(raw, "\n")
 
+	raw, extraCopyrightLines := t.removeExtraCopyright(raw)
+
 	split := strings.Split(raw, "\n")
 
 	if len(split) < t.lineCount {
-		return raw, fmt.Errorf("file is shorter than the boilerplate header; cannot have correct boilerplate")
+		return raw, extraCopyrightLines, fmt.Errorf("file is shorter than the boilerplate header; cannot have correct boilerplate")
 	}
 
-	return strings.Join(split[:t.lineCount], "\n"), nil
+	return strings.Join(split[:t.lineCount], "\n"), extraCopyrightLines, nil
+}
+
+// modelinesLength returns the length of any editor modelines at the very start of raw
+func modelinesLength(raw string) int {
+	length := 0
+
+	for {
+		loc := ModelineRegex.FindStringIndex(raw[length:])
+		if loc == nil || loc[1] == 0 {
+			return length
+		}
+
+		length += loc[1]
+	}
+}
+
+// lineEnding returns the line ending used by the first line of raw, so that lines added to a file
+// with Windows-style line endings match the rest of it
+func lineEnding(raw string) string {
+	i := strings.IndexByte(raw, '\n')
+	if i > 0 && raw[i-1] == '\r' {
+		return "\r\n"
+	}
+
+	return "\n"
 }
 
 func fileBeginning(raw string, templateLineCount int) string {
@@ -129,3 +637,144 @@ func normalizeShebang(raw string) string {
 	// Remove the shebang line, if there is one
 	return ShebangRegex.ReplaceAllString(raw, "")
 }
+
+func normalizeCFile(raw string) string {
+	// An include guard can come straight after the boilerplate, without the usual blank line
+	return IncludeGuardRegex.ReplaceAllString(raw, "$1\n$2")
+}
+
+func normalizeHCLFile(raw string) string {
+	// HCL allows both "#" and "//" comments, so boilerplate can use either
+	return HCLSlashCommentRegex.ReplaceAllString(raw, "#")
+}
+
+// normalizePowerShellBlockComment rewrites a "<# ... #>" block comment at the start of raw as "#"
+// line comments, so that boilerplate can be written either way
+func normalizePowerShellBlockComment(raw string) string {
+	raw = strings.TrimLeft(raw, "\n")
+
+	loc := PowerShellBlockCommentRegex.FindStringSubmatchIndex(raw)
+	if loc == nil {
+		return raw
+	}
+
+	var normalized strings.Builder
+
+	for _, line := range strings.Split(strings.TrimSuffix(raw[loc[2]:loc[3]], "\n"), "\n") {
+		if line == "" {
+			normalized.WriteString("#\n")
+			continue
+		}
+
+		normalized.WriteString("# " + line + "\n")
+	}
+
+	return normalized.String() + raw[loc[1]:]
+}
+
+func normalizeHelmTemplate(raw string) string {
+	// Go template comments can be written with or without whitespace trimming, which makes no
+	// difference to the boilerplate
+	loc := HelmCommentStartRegex.FindStringIndex(raw)
+	if loc == nil {
+		return raw
+	}
+
+	raw = "{{- /*\n" + raw[loc[1]:]
+
+	end := HelmCommentEndRegex.FindStringIndex(raw)
+	if end == nil {
+		return raw
+	}
+
+	return raw[:end[0]] + "*/ -}}" + raw[end[1]:]
+}
+
+func normalizePython(raw string) string {
+	raw = normalizeShebang(raw)
+
+	// Remove an encoding declaration, which must come before the boilerplate
+	return PythonEncodingRegex.ReplaceAllString(strings.TrimLeft(raw, "\n"), "")
+}
+
+// ExtractYear returns the year from the first copyright line near the start of the given file
+func ExtractYear(raw string) (string, bool) {
+	lines := strings.SplitN(raw, "\n", extractYearSearchLines+1)
+	if len(lines) > extractYearSearchLines {
+		lines = lines[:extractYearSearchLines]
+	}
+
+	match := DateRegex.FindString(strings.Join(lines, "\n"))
+	if match == "" {
+		return "", false
+	}
+
+	_, years := splitCopyrightYears(match)
+
+	return years, true
+}
+
+// headerYear returns the location of the copyright year, including the "Copyright" before it, in
+// the valid boilerplate of raw. It returns false if raw doesn't have valid boilerplate, if it's
+// generated or skipped, or if the year is in a form which DateRegex doesn't match, e.g. "2019, 2021".
+func (t BoilerplateTemplate) headerYear(raw string) ([]int, bool) {
+	if t.isGenerated(raw) || t.isExemptFrontMatter(raw) {
+		return nil, false
+	}
+
+	if skip, _, err := checkSkipMarker(raw); skip || err != nil {
+		return nil, false
+	}
+
+	if t.validateContents(raw) != nil {
+		return nil, false
+	}
+
+	searchEnd := len(raw)
+	if lines := strings.SplitN(raw, "\n", extractYearSearchLines+1); len(lines) > extractYearSearchLines {
+		searchEnd = len(raw) - len(lines[extractYearSearchLines])
+	}
+
+	loc := DateRegex.FindStringIndex(raw[:searchEnd])
+	if loc == nil {
+		return nil, false
+	}
+
+	// years in other forms are left for a person to update
+	if full := AnyYearRegex.FindString(raw[loc[0]:searchEnd]); len(full) != loc[1]-loc[0] {
+		return nil, false
+	}
+
+	return loc, true
+}
+
+// normalizeCopyrightSymbol removes any copyright symbol from the copyright line of raw's valid
+// boilerplate, e.g. changing "Copyright (c) 2024" to "Copyright 2024"
+func (t BoilerplateTemplate) normalizeCopyrightSymbol(raw string) string {
+	loc, ok := t.headerYear(raw)
+	if !ok {
+		return raw
+	}
+
+	_, years := splitCopyrightYears(raw[loc[0]:loc[1]])
+
+	normalized := raw[:loc[0]] + "Copyright " + years + raw[loc[1]:]
+
+	// the copyright line may not have been the one in the boilerplate, e.g. in a preamble
+	if t.validateContents(normalized) != nil {
+		return raw
+	}
+
+	return normalized
+}
+
+// splitCopyrightYears splits a match of DateRegex or AnyYearRegex into the text before the years,
+// such as "Copyright (c) ", and the years themselves
+func splitCopyrightYears(match string) (string, string) {
+	i := strings.IndexAny(match, "0123456789")
+	if i < 0 {
+		return match, ""
+	}
+
+	return match[:i], match[i:]
+}
@@ -17,83 +17,223 @@ limitations under the License.
 package boilersuite
 
 import (
-	"embed"
 	"fmt"
+	"io/fs"
 	"path/filepath"
 	"strings"
 )
 
 type TemplateMap map[string]BoilerplateTemplate
 
-// LoadTemplates attempts to read all of the templates under the given embedded filesystem
+// DefaultTemplateName is the name of the generic template, whose lines aren't commented until the
+// template is given a comment prefix by WithDefaultCommentPrefix
+const DefaultTemplateName = "default"
+
+// licenseFragment is the fragment which holds the license notice in the bundled templates, and
+// which LoadTemplatesForLicense replaces
+const licenseFragment = "license"
+
+// LoadTemplates attempts to read all of the templates at the root of the given filesystem
 // and return a TemplateMap which can be used for fetching templates later.
-func LoadTemplates(templateDir embed.FS, expectedAuthor string) (TemplateMap, error) {
-	allEntries, err := templateDir.ReadDir("boilerplate-templates")
-	if err != nil {
-		return nil, fmt.Errorf("failed to read templates: %s", err.Error())
+func LoadTemplates(templateDir fs.FS, expectedAuthor string) (TemplateMap, error) {
+	return LoadTemplatesForLicense(templateDir, expectedAuthor, "")
+}
+
+// LoadTemplatesForLicense is like LoadTemplates, but templates which include the "license" fragment
+// get the given notice in its place, commented in the same way. If notice is empty, the fragment
+// in templateDir is used.
+func LoadTemplatesForLicense(templateDir fs.FS, expectedAuthor string, notice string) (TemplateMap, error) {
+	var fragments map[string]string
+	if notice != "" {
+		fragments = map[string]string{licenseFragment: notice}
 	}
 
-	if len(allEntries) == 0 {
-		return nil, fmt.Errorf("found no templates in template dir")
+	return loadTemplates(templateDir, expectedAuthor, fragments, func(raw string) (string, error) {
+		return raw, nil
+	})
+}
+
+// loadTemplates reads all of the templates at the root of the given filesystem, passing the
+// contents of each through transform before parsing it. fragments replaces fragments of the same
+// name in templateDir.
+func loadTemplates(templateDir fs.FS, expectedAuthor string, fragments map[string]string, transform func(raw string) (string, error)) (TemplateMap, error) {
+	allEntries, err := fs.ReadDir(templateDir, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates: %s", err.Error())
 	}
 
 	out := make(TemplateMap)
 
 	for _, entry := range allEntries {
 		name := entry.Name()
-		path := filepath.Join("boilerplate-templates", name)
+
+		if entry.IsDir() || filepath.Ext(name) != ".boilertmpl" {
+			continue
+		}
 
 		trimmedName := strings.TrimSuffix(name, ".boilertmpl")
 
+		// everything after "boilerplate." names the template, which might contain dots itself,
+		// e.g. "boilerplate.tf.json.boilertmpl"
 		target := strings.TrimPrefix(filepath.Ext(trimmedName), ".")
+		if _, suffix, ok := strings.Cut(trimmedName, "."); ok {
+			target = suffix
+		}
 
-		contents, err := templateDir.ReadFile(path)
+		contents, err := fs.ReadFile(templateDir, name)
 		if err != nil {
 			// if files were embedded properly, shouldn't fail to read
-			return nil, fmt.Errorf("failed to read %q: %s", path, err.Error())
+			return nil, fmt.Errorf("failed to read %q: %s", name, err.Error())
 		}
 
-		var normalizationFunc func(string) string
+		raw, err := resolveIncludes(templateDir, string(contents), fragments, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template %q: %s", name, err.Error())
+		}
 
-		if target == "go" {
-			normalizationFunc = normalizeGoFile
-		} else if target == "sh" || target == "bash" || target == "py" {
-			normalizationFunc = normalizeShebang
+		raw, err = transform(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template %q: %s", name, err.Error())
 		}
 
-		out[target], err = NewBoilerplateTemplate(string(contents), BoilerplateTemplateConfiguration{
+		// any literal copyright years are replaced with the year marker, so that files with other
+		// years still validate, but are kept for adding boilerplate
+		raw, years := literalYears(raw)
+
+		language := languages[languageOf(target)]
+
+		out[target], err = NewBoilerplateTemplate(raw, BoilerplateTemplateConfiguration{
 			ExpectedAuthor:    expectedAuthor,
-			NormalizationFunc: normalizationFunc,
+			NormalizationFunc: language.normalizationFunc,
+			PreambleRegex:     language.preambleRegex,
+
+			BoilerplateBeforePreamble: language.boilerplateBeforePreamble,
 		})
 		if err != nil {
 			// all templates should be valid before embedding
-			return nil, fmt.Errorf("invalid template %q: %s", path, err.Error())
+			return nil, fmt.Errorf("invalid template %q: %s", name, err.Error())
 		}
+
+		tmpl := out[target]
+		tmpl.years = years
+		out[target] = tmpl
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("found no templates in template dir")
 	}
 
 	return out, nil
 }
 
-// TemplateMap returns a template which matches the given name, if one exists in the map.
-func (tm TemplateMap) TemplateFor(path string) (BoilerplateTemplate, bool) {
-	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+// WithAlternatives returns a copy of the map in which each named template also accepts files
+// matching its listed alternatives, e.g. {"go": ["go-spdx"]} allows Go files to have either the
+// "go" or "go-spdx" header.
+func (tm TemplateMap) WithAlternatives(alternatives map[string][]string) (TemplateMap, error) {
+	out := make(TemplateMap, len(tm))
+
+	for name, tmpl := range tm {
+		out[name] = tmpl
+	}
+
+	for name, alternativeNames := range alternatives {
+		tmpl, ok := tm[name]
+		if !ok {
+			return nil, fmt.Errorf("can't add alternatives to unknown template %q", name)
+		}
+
+		for _, alternativeName := range alternativeNames {
+			alternative, ok := tm[alternativeName]
+			if !ok {
+				return nil, fmt.Errorf("unknown alternative template %q for %q", alternativeName, name)
+			}
+
+			tmpl = tmpl.WithAlternatives(alternative)
+		}
+
+		out[name] = tmpl
+	}
+
+	return out, nil
+}
+
+// WithAliases returns a copy of the map in which each alias names the same template as its target,
+// e.g. {"bats": "bash"} checks files with a "bats" extension against the "bash" template. An alias
+// replaces any template which already has its name.
+func (tm TemplateMap) WithAliases(aliases map[string]string) (TemplateMap, error) {
+	out := make(TemplateMap, len(tm)+len(aliases))
+
+	for name, tmpl := range tm {
+		out[name] = tmpl
+	}
 
-	tmpl, ok := tm[ext]
+	for alias, target := range aliases {
+		tmpl, ok := tm[target]
+		if !ok {
+			return nil, fmt.Errorf("alias %q refers to unknown template %q", alias, target)
+		}
 
-	if ok {
-		return tmpl, true
+		out[alias] = tmpl
 	}
 
-	// might be a prefix type (e.g. Dockerfile.abc should match the "Dockerfile" template)
+	return out, nil
+}
+
+// WithFrontMatterExempt returns a copy of the map in which Markdown templates don't apply to files
+// which start with YAML front matter. Otherwise, boilerplate is expected after the front matter.
+func (tm TemplateMap) WithFrontMatterExempt() TemplateMap {
+	out := make(TemplateMap, len(tm))
+
+	for name, tmpl := range tm {
+		if languageOf(name) == frontMatterLanguage {
+			tmpl.exemptFrontMatter = true
+		}
 
-	base := filepath.Base(path)
-	name := strings.SplitN(base, ".", 2)[0]
+		out[name] = tmpl
+	}
 
-	tmpl, ok = tm[name]
+	return out
+}
+
+// WithDefaultCommentPrefix returns a copy of the map in which each line of the default template is
+// commented using the given prefix, e.g. "#" or "//", so that it can be used for files in languages
+// which have no template of their own. Files only use the default template when they're resolved
+// by ResolveDefault.
+func (tm TemplateMap) WithDefaultCommentPrefix(prefix string) (TemplateMap, error) {
+	tmpl, ok := tm[DefaultTemplateName]
+	if !ok {
+		return nil, fmt.Errorf("no %q template to comment", DefaultTemplateName)
+	}
+
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, fmt.Errorf("comment prefix for the %q template can't be empty", DefaultTemplateName)
+	}
+
+	out := make(TemplateMap, len(tm))
+
+	for name, tmpl := range tm {
+		out[name] = tmpl
+	}
+
+	out[DefaultTemplateName] = tmpl.withCommentPrefix(prefix)
+
+	return out, nil
+}
+
+// TemplateFor returns a template which matches the given name, if one exists in the map.
+// Templates are chosen according to DefaultResolutionRules.
+func (tm TemplateMap) TemplateFor(path string) (BoilerplateTemplate, bool) {
+	return tm.TemplateForRules(path, DefaultResolutionRules)
+}
 
-	if ok {
-		return tmpl, true
+// TemplateForRules returns a template which matches the given name using the given rules, if one
+// exists in the map.
+func (tm TemplateMap) TemplateForRules(path string, rules []ResolutionRule) (BoilerplateTemplate, bool) {
+	name, _, ok := tm.Resolve(path, rules)
+	if !ok {
+		return BoilerplateTemplate{}, false
 	}
 
-	return BoilerplateTemplate{}, false
+	return tm[name], true
 }
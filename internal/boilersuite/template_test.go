@@ -0,0 +1,694 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilersuite
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+const testTemplate = `# Copyright <<YEAR>> The <<AUTHOR>> Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+
+`
+
+func mustTestTemplate(t *testing.T) BoilerplateTemplate {
+	t.Helper()
+
+	tmpl, err := NewBoilerplateTemplate(testTemplate, BoilerplateTemplateConfiguration{
+		ExpectedAuthor:    "cert-manager",
+		NormalizationFunc: normalizeShebang,
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	return tmpl
+}
+
+func Test_ValidateSkipMarker(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+
+	now = func() time.Time {
+		return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	tmpl := mustTestTemplate(t)
+
+	tests := map[string]struct {
+		input     string
+		expectErr bool
+	}{
+		"no marker and no boilerplate": {
+			input:     "#!/bin/sh\n\necho hello\necho hello\necho hello\necho hello\n",
+			expectErr: true,
+		},
+		"marker without expiry": {
+			input:     "#!/bin/sh\n# +skip_license_check\n\necho hello\n",
+			expectErr: false,
+		},
+		"marker with future expiry": {
+			input:     "#!/bin/sh\n# +skip_license_check until=2026-06-01\n\necho hello\n",
+			expectErr: false,
+		},
+		"marker expiring today": {
+			input:     "#!/bin/sh\n# +skip_license_check until=2026-03-01\n\necho hello\n",
+			expectErr: false,
+		},
+		"marker with past expiry": {
+			input:     "#!/bin/sh\n# +skip_license_check until=2026-01-01\n\necho hello\necho hello\necho hello\n",
+			expectErr: true,
+		},
+		"marker with past expiry on a valid file": {
+			input:     "#!/bin/sh\n\n# Copyright 2025 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\n# +skip_license_check until=2026-01-01\n",
+			expectErr: false,
+		},
+		"marker with invalid expiry": {
+			input:     "#!/bin/sh\n# +skip_license_check until=tomorrow\n\necho hello\n",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tmpl.Validate(test.input)
+
+			if (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+		})
+	}
+}
+
+func Test_LineTemplate(t *testing.T) {
+	tmpl, err := NewBoilerplateTemplate("# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n", BoilerplateTemplateConfiguration{
+		ExpectedAuthor: "cert-manager",
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	if tmpl.Kind() != TemplateKindLine {
+		t.Fatalf("expected a one-line template to have TemplateKindLine")
+	}
+
+	tests := map[string]struct {
+		input     string
+		expectErr bool
+		fixed     string
+	}{
+		"header on first line": {
+			input: "# Copyright 2024 The cert-manager Authors.\nroot = true\n",
+		},
+		"header after other comments": {
+			input: "# editor settings\n\n  # Copyright 2024 The cert-manager Authors.  \r\nroot = true\n",
+		},
+		"header too far down": {
+			input:     "a\nb\nc\nd\ne\n# Copyright 2024 The cert-manager Authors.\n",
+			expectErr: true,
+			fixed:     "# Copyright 2026 The cert-manager Authors.\n\na\nb\nc\nd\ne\n# Copyright 2024 The cert-manager Authors.\n",
+		},
+		"missing header": {
+			input:     "root = true\n",
+			expectErr: true,
+			fixed:     "# Copyright 2026 The cert-manager Authors.\n\nroot = true\n",
+		},
+		"missing header with CRLF line endings": {
+			input:     "root = true\r\n",
+			expectErr: true,
+			fixed:     "# Copyright 2026 The cert-manager Authors.\r\n\r\nroot = true\r\n",
+		},
+		"wrong author": {
+			input:     "# Copyright 2024 Someone Else\nroot = true\n",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tmpl.Validate(test.input)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			fixed, err := tmpl.Fix(test.input, FixOptions{Year: 2026})
+			if (err != nil) != (test.expectErr && test.fixed == "") {
+				t.Fatalf("unexpected fix error: %v", err)
+			}
+
+			expected := test.fixed
+			if !test.expectErr {
+				expected = test.input
+			}
+
+			if err == nil && fixed != expected {
+				t.Errorf("fixed=%q, expected=%q", fixed, expected)
+			}
+		})
+	}
+}
+
+func Test_FixLineEndings(t *testing.T) {
+	tmpl, err := NewBoilerplateTemplate(testTemplate, BoilerplateTemplateConfiguration{
+		ExpectedAuthor:    "cert-manager",
+		NormalizationFunc: normalizeShebang,
+		PreambleRegex:     ShebangRegex,
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected string
+	}{
+		"LF": {
+			input:    "#!/bin/sh\necho hello\n",
+			expected: "#!/bin/sh\n\n# Copyright 2026 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+		},
+		"CRLF": {
+			input:    "#!/bin/sh\r\necho hello\r\n",
+			expected: "#!/bin/sh\r\n\r\n# Copyright 2026 The cert-manager Authors.\r\n#\r\n# Licensed under the Apache License, Version 2.0 (the \"License\");\r\n\r\necho hello\r\n",
+		},
+		"CRLF with leading blank lines": {
+			input:    "\r\n\r\necho hello\r\n",
+			expected: "# Copyright 2026 The cert-manager Authors.\r\n#\r\n# Licensed under the Apache License, Version 2.0 (the \"License\");\r\n\r\necho hello\r\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixed, err := tmpl.Fix(test.input, FixOptions{Year: 2026})
+			if err != nil {
+				t.Fatalf("failed to fix file: %s", err)
+			}
+
+			if fixed != test.expected {
+				t.Errorf("fixed=%q, expected=%q", fixed, test.expected)
+			}
+
+			if err := tmpl.Validate(fixed); err != nil {
+				t.Errorf("fixed file isn't valid: %s", err)
+			}
+		})
+	}
+}
+
+func Test_FixTrailingNewline(t *testing.T) {
+	tmpl := mustTestTemplate(t)
+
+	const header = "# Copyright 2026 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n"
+
+	tests := map[string]struct {
+		input              string
+		addTrailingNewline bool
+		expected           string
+	}{
+		"missing newline is preserved": {
+			input:    "echo hello",
+			expected: header + "\necho hello",
+		},
+		"missing newline is added": {
+			input:              "echo hello",
+			addTrailingNewline: true,
+			expected:           header + "\necho hello\n",
+		},
+		"missing newline is added to a valid file": {
+			input:              header + "\necho hello",
+			addTrailingNewline: true,
+			expected:           header + "\necho hello\n",
+		},
+		"empty file": {
+			input:    "",
+			expected: header,
+		},
+		"header without a final newline is valid": {
+			input:    strings.TrimSuffix(header, "\n"),
+			expected: strings.TrimSuffix(header, "\n"),
+		},
+		"header without a final newline is given one": {
+			input:              strings.TrimSuffix(header, "\n"),
+			addTrailingNewline: true,
+			expected:           header,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixed, err := tmpl.Fix(test.input, FixOptions{Year: 2026, AddTrailingNewline: test.addTrailingNewline})
+			if err != nil {
+				t.Fatalf("failed to fix file: %s", err)
+			}
+
+			if fixed != test.expected {
+				t.Errorf("fixed=%q, expected=%q", fixed, test.expected)
+			}
+
+			if err := tmpl.Validate(fixed); err != nil {
+				t.Errorf("fixed file isn't valid: %s", err)
+			}
+		})
+	}
+}
+
+func Test_Modelines(t *testing.T) {
+	tmpl, err := NewBoilerplateTemplate(testTemplate, BoilerplateTemplateConfiguration{
+		ExpectedAuthor:    "cert-manager",
+		NormalizationFunc: normalizeShebang,
+		PreambleRegex:     ShebangRegex,
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	const header = "# Copyright 2026 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n"
+
+	tests := map[string]struct {
+		input     string
+		expectErr bool
+		fixed     string
+	}{
+		"vim modeline": {
+			input: "# vim: set ts=4 sw=4:\n" + header + "\necho hello\n",
+		},
+		"emacs modeline after a blank line": {
+			input: "# -*- mode: sh -*-\n\n" + header + "\necho hello\n",
+		},
+		"emacs modeline after a shebang": {
+			input: "#!/bin/sh\n# -*- mode: sh -*-\n\n" + header + "\necho hello\n",
+		},
+		"several modelines": {
+			input: "# -*- coding: utf-8 -*-\n# vim: set ts=4:\n" + header + "\necho hello\n",
+		},
+		"missing header is added below a modeline": {
+			input:     "# vim: set ts=4 sw=4:\necho hello\n",
+			expectErr: true,
+			fixed:     "# vim: set ts=4 sw=4:\n\n" + header + "\necho hello\n",
+		},
+		"missing header is added below a shebang and modeline": {
+			input:     "#!/bin/sh\n# -*- mode: sh -*-\necho hello\n",
+			expectErr: true,
+			fixed:     "#!/bin/sh\n# -*- mode: sh -*-\n\n" + header + "\necho hello\n",
+		},
+		"ordinary comment isn't a modeline": {
+			input:     "# a comment\n" + header + "\necho hello\n",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tmpl.Validate(test.input)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if test.fixed == "" {
+				return
+			}
+
+			fixed, err := tmpl.Fix(test.input, FixOptions{Year: 2026})
+			if err != nil {
+				t.Fatalf("failed to fix file: %s", err)
+			}
+
+			if fixed != test.fixed {
+				t.Errorf("fixed=%q, expected=%q", fixed, test.fixed)
+			}
+		})
+	}
+}
+
+func Test_PythonEncodingDeclaration(t *testing.T) {
+	tmpl, err := NewBoilerplateTemplate(testTemplate, BoilerplateTemplateConfiguration{
+		ExpectedAuthor:    "cert-manager",
+		NormalizationFunc: normalizePython,
+		PreambleRegex:     PythonPreambleRegex,
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	const header = "# Copyright 2026 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n"
+
+	tests := map[string]struct {
+		input     string
+		expectErr bool
+		fixed     string
+	}{
+		"declaration after a shebang": {
+			input: "#!/usr/bin/env python3\n# coding=utf-8\n\n" + header + "\nprint()\n",
+		},
+		"emacs-style declaration": {
+			input: "# -*- coding: latin-1 -*-\n" + header + "\nprint()\n",
+		},
+		"missing header is added below a shebang and declaration": {
+			input:     "#!/usr/bin/env python3\n# coding: utf-8\nprint()\n",
+			expectErr: true,
+			fixed:     "#!/usr/bin/env python3\n# coding: utf-8\n\n" + header + "\nprint()\n",
+		},
+		"missing header is added below a declaration": {
+			input:     "# coding=utf-8\nprint()\n",
+			expectErr: true,
+			fixed:     "# coding=utf-8\n\n" + header + "\nprint()\n",
+		},
+		"declaration below the header": {
+			input:     header + "\n# coding=utf-8\nprint()\n",
+			expectErr: true,
+			fixed:     "# coding=utf-8\n\n" + header + "\nprint()\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tmpl.Validate(test.input)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if test.fixed == "" {
+				return
+			}
+
+			fixed, err := tmpl.Fix(test.input, FixOptions{Year: 2026})
+			if err != nil {
+				t.Fatalf("failed to fix file: %s", err)
+			}
+
+			if fixed != test.fixed {
+				t.Errorf("fixed=%q, expected=%q", fixed, test.fixed)
+			}
+		})
+	}
+}
+
+func Test_Alternatives(t *testing.T) {
+	spdx, err := NewBoilerplateTemplate("# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n# SPDX-License-Identifier: Apache-2.0\n\n", BoilerplateTemplateConfiguration{
+		ExpectedAuthor:    "cert-manager",
+		NormalizationFunc: normalizeShebang,
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	tm, err := TemplateMap{"sh": mustTestTemplate(t), "sh-spdx": spdx}.WithAlternatives(map[string][]string{
+		"sh": {"sh-spdx"},
+	})
+	if err != nil {
+		t.Fatalf("failed to add alternatives: %s", err)
+	}
+
+	tests := map[string]struct {
+		input     string
+		expectErr bool
+	}{
+		"primary template": {
+			input: "#!/bin/sh\n\n# Copyright 2025 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+		},
+		"alternative template": {
+			input: "#!/bin/sh\n\n# Copyright 2025 The cert-manager Authors.\n# SPDX-License-Identifier: Apache-2.0\n\necho hello\n",
+		},
+		"neither template": {
+			input:     "#!/bin/sh\n\n# Copyright 2025 The cert-manager Authors.\n# SPDX-License-Identifier: MIT\n\necho hello\n",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tm["sh"].Validate(test.input)
+
+			if (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+		})
+	}
+
+	// the closest template should be used for suggestions
+	err = tm["sh"].Validate("#!/bin/sh\n\n# Copyright 2025 The cert-manager Authors.\n# SPDX-License-Identifier: MIT\n\necho hello\n")
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Expected != "Apache-2.0" {
+		t.Errorf("expected a suggestion based on the SPDX template, got %#v", err)
+	}
+
+	if _, err := (TemplateMap{"sh": mustTestTemplate(t)}).WithAlternatives(map[string][]string{"sh": {"missing"}}); err == nil {
+		t.Errorf("expected an error for an unknown alternative")
+	}
+}
+
+func Test_Aliases(t *testing.T) {
+	tm, err := TemplateMap{"sh": mustTestTemplate(t)}.WithAliases(map[string]string{"bats": "sh"})
+	if err != nil {
+		t.Fatalf("failed to add aliases: %s", err)
+	}
+
+	if tm["bats"].Fingerprint() != tm["sh"].Fingerprint() {
+		t.Errorf("expected alias to use the template it refers to")
+	}
+
+	if tmpl, ok := tm.TemplateFor("test/example.bats"); !ok || tmpl.Fingerprint() != tm["sh"].Fingerprint() {
+		t.Errorf("expected a file with an aliased extension to use the aliased template")
+	}
+
+	if _, err := (TemplateMap{"sh": mustTestTemplate(t)}).WithAliases(map[string]string{"bats": "missing"}); err == nil {
+		t.Errorf("expected an error for an alias of an unknown template")
+	}
+}
+
+func Test_DefaultCommentPrefix(t *testing.T) {
+	generic, err := NewBoilerplateTemplate("Copyright <<YEAR>> The <<AUTHOR>> Authors.\n\nLicensed under the Apache License, Version 2.0 (the \"License\");\n\n", BoilerplateTemplateConfiguration{
+		ExpectedAuthor: "cert-manager",
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	tests := map[string]struct {
+		prefix    string
+		input     string
+		expectErr bool
+	}{
+		"hash comments": {
+			prefix: "#",
+			input:  "# Copyright 2025 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\nx = 1\n",
+		},
+		"slash comments": {
+			prefix: "// ",
+			input:  "// Copyright 2025 The cert-manager Authors.\n//\n// Licensed under the Apache License, Version 2.0 (the \"License\");\n\nx = 1\n",
+		},
+		"wrong comment style": {
+			prefix:    "#",
+			input:     "// Copyright 2025 The cert-manager Authors.\n//\n// Licensed under the Apache License, Version 2.0 (the \"License\");\n\nx = 1\n",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tm, err := TemplateMap{DefaultTemplateName: generic}.WithDefaultCommentPrefix(test.prefix)
+			if err != nil {
+				t.Fatalf("failed to comment template: %s", err)
+			}
+
+			err = tm[DefaultTemplateName].Validate(test.input)
+
+			if (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+		})
+	}
+
+	if _, err := (TemplateMap{DefaultTemplateName: generic}).WithDefaultCommentPrefix(" "); err == nil {
+		t.Errorf("expected an error for an empty comment prefix")
+	}
+
+	if _, err := (TemplateMap{"sh": mustTestTemplate(t)}).WithDefaultCommentPrefix("#"); err == nil {
+		t.Errorf("expected an error when there's no default template")
+	}
+}
+
+func Test_LoadTemplatesForLicense(t *testing.T) {
+	const notice = "Use of this source code is governed by the MIT license.\n\nSPDX-License-Identifier: MIT\n"
+
+	templateDir := fstest.MapFS{
+		"license.boilerfrag":    {Data: []byte("<<INCLUDE apache-2.0>>\n")},
+		"apache-2.0.boilerfrag": {Data: []byte("Licensed under the Apache License, Version 2.0 (the \"License\");\nlimitations under the License.\n")},
+
+		"boilerplate.go.boilertmpl":  {Data: []byte("/*\nCopyright <<YEAR>> The <<AUTHOR>> Authors.\n\n<<INCLUDE license>>\n*/\n\n")},
+		"boilerplate.sh.boilertmpl":  {Data: []byte("# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n#\n# <<INCLUDE license>>\n\n")},
+		"boilerplate.py.boilertmpl":  {Data: []byte("# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n#\n# <<INCLUDE apache-2.0>>\n\n")},
+		"boilerplate.ini.boilertmpl": {Data: []byte("; Copyright <<YEAR>> The <<AUTHOR>> Authors.\n")},
+	}
+
+	tests := map[string]struct {
+		notice   string
+		expected map[string]string
+	}{
+		"default notice": {
+			expected: map[string]string{
+				"go":  "/*\nCopyright 2026 The cert-manager Authors.\n\nLicensed under the Apache License, Version 2.0 (the \"License\");\nlimitations under the License.\n*/\n",
+				"sh":  "# Copyright 2026 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n# limitations under the License.\n",
+				"py":  "# Copyright 2026 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n# limitations under the License.\n",
+				"ini": "; Copyright 2026 The cert-manager Authors.\n",
+			},
+		},
+		"another license": {
+			notice: notice,
+			expected: map[string]string{
+				"go": "/*\nCopyright 2026 The cert-manager Authors.\n\nUse of this source code is governed by the MIT license.\n\nSPDX-License-Identifier: MIT\n*/\n",
+				"sh": "# Copyright 2026 The cert-manager Authors.\n#\n# Use of this source code is governed by the MIT license.\n#\n# SPDX-License-Identifier: MIT\n",
+				// templates which include a notice directly rather than through the license
+				// fragment keep it
+				"py":  "# Copyright 2026 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n# limitations under the License.\n",
+				"ini": "; Copyright 2026 The cert-manager Authors.\n",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			templates, err := LoadTemplatesForLicense(templateDir, "cert-manager", test.notice)
+			if err != nil {
+				t.Fatalf("failed to load templates: %s", err)
+			}
+
+			for target, expected := range test.expected {
+				fixed, err := templates[target].Fix("", FixOptions{Year: 2026})
+				if err != nil {
+					t.Fatalf("failed to fix an empty %s file: %s", target, err)
+				}
+
+				if fixed != expected {
+					t.Errorf("%s: got %q, wanted %q", target, fixed, expected)
+				}
+			}
+		})
+	}
+}
+
+func Test_Fingerprint(t *testing.T) {
+	base := mustTestTemplate(t)
+
+	other, err := NewBoilerplateTemplate("# Copyright <<YEAR>> The <<AUTHOR>> Authors.\n# SPDX-License-Identifier: Apache-2.0\n\n", BoilerplateTemplateConfiguration{
+		ExpectedAuthor: "cert-manager",
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	matcher, err := NewGeneratedMatcher([]string{"@generated"}, 0)
+	if err != nil {
+		t.Fatalf("failed to create matcher: %s", err)
+	}
+
+	if base.Fingerprint() != mustTestTemplate(t).Fingerprint() {
+		t.Errorf("expected identical templates to have the same fingerprint")
+	}
+
+	for name, changed := range map[string]BoilerplateTemplate{
+		"different template":    other,
+		"with alternatives":     base.WithAlternatives(other),
+		"with generated config": base.WithGeneratedMatcher(matcher),
+	} {
+		if changed.Fingerprint() == base.Fingerprint() {
+			t.Errorf("%s: expected fingerprint to change", name)
+		}
+	}
+}
+
+func Test_YearlessTemplate(t *testing.T) {
+	tmpl, err := NewBoilerplateTemplate("# Copyright The <<AUTHOR>> Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\n", BoilerplateTemplateConfiguration{
+		ExpectedAuthor: "cert-manager",
+	})
+	if err != nil {
+		t.Fatalf("failed to create template: %s", err)
+	}
+
+	const valid = "# Copyright The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n"
+
+	tests := map[string]struct {
+		input string
+
+		expectErr    bool
+		expectFixed  string
+		expectFixErr bool
+	}{
+		"without a year": {
+			input:       valid,
+			expectFixed: valid,
+		},
+		"with a year": {
+			input:        "# Copyright 2024 The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+			expectErr:    true,
+			expectFixErr: true,
+		},
+		"missing": {
+			input:       "echo hello\n",
+			expectErr:   true,
+			expectFixed: valid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tmpl.Validate(test.input); (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			fixed, err := tmpl.Fix(test.input, FixOptions{Year: 2026})
+			if (err != nil) != test.expectFixErr {
+				t.Fatalf("fix err=%v, expectFixErr=%v", err, test.expectFixErr)
+			}
+
+			if fixed != test.expectFixed {
+				t.Errorf("got %q, wanted %q", fixed, test.expectFixed)
+			}
+		})
+	}
+}
+
+func Test_FixYearlessHeaderWithYearedTemplate(t *testing.T) {
+	tmpl := mustTestTemplate(t)
+
+	tests := map[string]struct {
+		input string
+	}{
+		"yearless copyright line": {
+			input: "# Copyright The cert-manager Authors.\n#\n# Licensed under the Apache License, Version 2.0 (the \"License\");\n\necho hello\n",
+		},
+		"yearless copyright line for another author": {
+			input: "# Copyright The Kubernetes Authors.\n\necho hello\n",
+		},
+		"SPDX copyright line": {
+			input: "# SPDX-FileCopyrightText: The cert-manager Authors\n\necho hello\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixed, err := tmpl.Fix(test.input, FixOptions{Year: 2026})
+			if err == nil {
+				t.Fatalf("expected an error rather than a second header, got %q", fixed)
+			}
+		})
+	}
+}
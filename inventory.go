@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// inventorySearchLimit is the most bytes at the start of each file which are searched for
+// copyright and licensing information
+const inventorySearchLimit = 16 * 1024
+
+// inventoryEntry is the copyright and licensing information found in a single file
+type inventoryEntry struct {
+	Path     string   `json:"path"`
+	Binary   bool     `json:"binary,omitempty"`
+	Holders  []string `json:"holders"`
+	Years    []string `json:"years"`
+	Licenses []string `json:"licenses"`
+}
+
+func runInventory(args []string) {
+	flags := flag.NewFlagSet("inventory", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "inventory [flags] <path-to-scan>", "Prints the copyright holders, years and licenses found in every file, whether or not a template matches it, for reviewing code before it's imported.")
+
+	global := addGlobalFlags(flags)
+	selection := addTargetFlags(flags)
+
+	outputFlag := flags.String("output", outputCSV, "The format of the inventory written to stdout; either \"csv\" or \"json\"")
+
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	selection.checkArgs(flags, env.logger, "boilersuite inventory")
+
+	if *outputFlag != outputCSV && *outputFlag != outputJSON {
+		fatal(env.logger, fmt.Sprintf("unknown --output %q; must be %q or %q", *outputFlag, outputCSV, outputJSON))
+	}
+
+	// files without a template are included, since they're often the ones which need reviewing
+	env.resolver.unknown = &unknownFiles{}
+
+	set := selection.find(flags, env)
+
+	paths := set.unknown
+	for _, t := range set.targets {
+		paths = append(paths, t.path)
+	}
+
+	entries, err := takeInventory(paths)
+	if err != nil {
+		fatal(env.logger, "failed to take inventory", "err", err)
+	}
+
+	if *outputFlag == outputJSON {
+		err = writeInventoryJSON(os.Stdout, entries)
+	} else {
+		err = writeInventoryCSV(os.Stdout, entries)
+	}
+
+	if err != nil {
+		fatal(env.logger, "failed to write inventory", "err", err)
+	}
+}
+
+// takeInventory returns the copyright and licensing information in each of the given files,
+// sorted by path
+func takeInventory(paths []string) ([]inventoryEntry, error) {
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+
+	entries := make([]inventoryEntry, 0, len(sorted))
+
+	for _, path := range sorted {
+		head, _, err := target{path: path}.readHead(inventorySearchLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := inventoryEntry{Path: path}
+
+		if boilersuite.IsBinary(head) {
+			entry.Binary = true
+		} else if text, _, err := boilersuite.DecodeText(head); err == nil {
+			info := boilersuite.FindCopyrightInfo(text)
+
+			entry.Holders = info.Holders
+			entry.Years = info.Years
+			entry.Licenses = info.Licenses
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// writeInventoryCSV writes a CSV row for each entry, joining multiple values in a column with "; "
+func writeInventoryCSV(w io.Writer, entries []inventoryEntry) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"path", "binary", "holders", "years", "licenses"}); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		row := []string{
+			entry.Path,
+			fmt.Sprint(entry.Binary),
+			strings.Join(entry.Holders, "; "),
+			strings.Join(entry.Years, "; "),
+			strings.Join(entry.Licenses, "; "),
+		}
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// writeInventoryJSON writes the entries as a JSON array, with empty lists rather than nulls
+func writeInventoryJSON(w io.Writer, entries []inventoryEntry) error {
+	for i := range entries {
+		for _, list := range []*[]string{&entries[i].Holders, &entries[i].Years, &entries[i].Licenses} {
+			if *list == nil {
+				*list = []string{}
+			}
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(entries)
+}
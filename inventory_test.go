@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_inventory(t *testing.T) {
+	files := map[string]string{
+		"main.go":  "// SPDX-FileCopyrightText: 2024 The cert-manager Authors\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n",
+		"logo.png": "\x89PNG\r\n\x1a\n\x00\x00",
+		"notes.md": "Some notes\n",
+	}
+
+	dir := t.TempDir()
+
+	var paths []string
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		paths = append(paths, path)
+	}
+
+	entries, err := takeInventory(paths)
+	if err != nil {
+		t.Fatalf("failed to take inventory: %s", err)
+	}
+
+	for i := range entries {
+		entries[i].Path = filepath.Base(entries[i].Path)
+	}
+
+	tests := map[string]struct {
+		write    func(*bytes.Buffer) error
+		expected string
+	}{
+		"csv": {
+			write: func(b *bytes.Buffer) error { return writeInventoryCSV(b, entries) },
+			expected: "path,binary,holders,years,licenses\n" +
+				"logo.png,true,,,\n" +
+				"main.go,false,The cert-manager Authors,2024,Apache-2.0\n" +
+				"notes.md,false,,,\n",
+		},
+		"json": {
+			write: func(b *bytes.Buffer) error { return writeInventoryJSON(b, entries) },
+			expected: `[
+  {
+    "path": "logo.png",
+    "binary": true,
+    "holders": [],
+    "years": [],
+    "licenses": []
+  },
+  {
+    "path": "main.go",
+    "holders": [
+      "The cert-manager Authors"
+    ],
+    "years": [
+      "2024"
+    ],
+    "licenses": [
+      "Apache-2.0"
+    ]
+  },
+  {
+    "path": "notes.md",
+    "holders": [],
+    "years": [],
+    "licenses": []
+  }
+]
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			if err := test.write(&out); err != nil {
+				t.Fatalf("failed to write inventory: %s", err)
+			}
+
+			if out.String() != test.expected {
+				t.Errorf("got:\n%s\nwanted:\n%s", out.String(), test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// licenseFileNames are the names which the LICENSE file at the root of a repository can have, in
+// the order they're looked for
+var licenseFileNames = []string{"LICENSE", "LICENSE.md", "LICENSE.txt"}
+
+// rootLicense returns the license which the LICENSE file in dir must hold. That's the license
+// given by a config file in dir, if there is one, or else fallback, which is usually --license.
+func rootLicense(resolver templateResolver, dir string, fallback string) (string, error) {
+	if resolver.configs == nil {
+		return fallback, nil
+	}
+
+	cfg, err := resolver.configs.forDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.license, nil
+}
+
+// checkLicenseFile returns an error if dir has no LICENSE file, or if its LICENSE file doesn't hold
+// the full text of the named license
+func checkLicenseFile(dir string, license string) error {
+	text, err := boilerplatetemplates.LicenseText(license)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range licenseFileNames {
+		path := filepath.Join(dir, name)
+
+		contents, err := target{path: path}.readText()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return &fileError{path: path, err: err}
+		}
+
+		if !boilersuite.NewLicenseTextMatcher(text).Matches(contents) {
+			return &fileError{path: path, err: fmt.Errorf("doesn't contain the full text of the %s license which headers are checked against; see --license", strings.ToLower(license))}
+		}
+
+		return nil
+	}
+
+	return &fileError{path: filepath.Join(dir, licenseFileNames[0]), err: fmt.Errorf("no license file found; expected one of %s holding the full text of the %s license", strings.Join(licenseFileNames, ", "), strings.ToLower(license))}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+)
+
+// goLicense is a BSD 3-Clause license formatted in the same way as Go's own
+const goLicense = `Copyright 2009 The Go Authors.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are
+met:
+
+   * Redistributions of source code must retain the above copyright
+notice, this list of conditions and the following disclaimer.
+   * Redistributions in binary form must reproduce the above
+copyright notice, this list of conditions and the following disclaimer
+in the documentation and/or other materials provided with the
+distribution.
+   * Neither the name of Google LLC nor the names of its
+contributors may be used to endorse or promote products derived from
+this software without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+"AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR
+A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT
+LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE,
+DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY
+THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+`
+
+// mitLicense is an MIT license in Markdown, wrapped differently to the usual text
+const mitLicense = `# MIT License
+
+Copyright (c) 2024 Jane Doe
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated
+documentation files (the "Software"), to deal in the Software without restriction, including without limitation the
+rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit
+persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the
+Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE
+WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR
+OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+`
+
+func Test_checkLicenseFile(t *testing.T) {
+	apacheLicense, err := os.ReadFile("LICENSE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agplLicense, err := boilerplatetemplates.LicenseText("agpl-3.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// agplHeadings holds only the title and section headings of the AGPL, which used to be
+	// enough to pass
+	var agplHeadings strings.Builder
+
+	for _, line := range strings.Split(agplLicense, "\n") {
+		if strings.Contains(line, "GNU AFFERO") || strings.HasPrefix(strings.TrimSpace(line), "0. Definitions") {
+			agplHeadings.WriteString(line + "\n")
+		}
+	}
+
+	tests := map[string]struct {
+		filename  string
+		contents  string
+		license   string
+		expectErr bool
+	}{
+		"apache license": {
+			filename: "LICENSE",
+			contents: string(apacheLicense),
+			license:  "apache-2.0",
+		},
+		"mit license in markdown": {
+			filename: "LICENSE.md",
+			contents: mitLicense,
+			license:  "MIT",
+		},
+		"bsd license with a named copyright holder": {
+			filename: "LICENSE",
+			contents: goLicense,
+			license:  "bsd-3-clause",
+		},
+		"full agpl license": {
+			filename: "LICENSE",
+			contents: strings.ReplaceAll(agplLicense, "<<ANY>>", "https://"),
+			license:  "agpl-3.0",
+		},
+		"agpl license headings without the terms": {
+			filename:  "LICENSE",
+			contents:  agplHeadings.String(),
+			license:   "agpl-3.0",
+			expectErr: true,
+		},
+		"license which doesn't match the headers": {
+			filename:  "LICENSE",
+			contents:  mitLicense,
+			license:   "apache-2.0",
+			expectErr: true,
+		},
+		"truncated license": {
+			filename:  "LICENSE",
+			contents:  string(apacheLicense[:len(apacheLicense)/2]),
+			license:   "apache-2.0",
+			expectErr: true,
+		},
+		"no license file": {
+			filename:  "README.md",
+			contents:  "# Example\n",
+			license:   "apache-2.0",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			if err := os.WriteFile(filepath.Join(dir, test.filename), []byte(test.contents), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := checkLicenseFile(dir, test.license)
+			if (err != nil) != test.expectErr {
+				t.Errorf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+		})
+	}
+}
+
+func Test_rootLicense(t *testing.T) {
+	tests := map[string]struct {
+		config          string
+		withConfigs     bool
+		expectedLicense string
+	}{
+		"no per-directory config": {
+			expectedLicense: "apache-2.0",
+		},
+		"root config without a license": {
+			config:          `{"author": "Example"}`,
+			withConfigs:     true,
+			expectedLicense: "apache-2.0",
+		},
+		"root config overriding the license": {
+			config:          `{"license": "MIT"}`,
+			withConfigs:     true,
+			expectedLicense: "mit",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+
+			if test.config != "" {
+				if err := os.WriteFile(filepath.Join(root, dirConfigFilename), []byte(test.config), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var resolver templateResolver
+
+			if test.withConfigs {
+				configs, err := newDirConfigs(root, effectiveConfig{license: "apache-2.0"}, boilerplatetemplates.FS, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				resolver.configs = configs
+			}
+
+			license, err := rootLicense(resolver, root, "apache-2.0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if license != test.expectedLicense {
+				t.Errorf("got license %q, wanted %q", license, test.expectedLicense)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func runLintTemplates(args []string) {
+	flags := flag.NewFlagSet("lint-templates", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "lint-templates [flags] <template-dir>", "Checks a directory of custom templates for mistakes, such as missing markers, trailing whitespace, tabs or comment syntax which doesn't match the built-in template for the same language, so that broken templates can be fixed before they're used.")
+
+	global := addGlobalFlags(flags)
+
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	if flags.NArg() != 1 {
+		fatal(env.logger, "usage: boilersuite lint-templates [flags] <template-dir>")
+	}
+
+	issues, err := boilersuite.LintTemplates(os.DirFS(flags.Arg(0)), env.templates)
+	if err != nil {
+		fatal(env.logger, "failed to lint templates", "dir", flags.Arg(0), "err", err)
+	}
+
+	writeTemplateLintIssues(os.Stdout, flags.Arg(0), issues)
+
+	if len(issues) > 0 {
+		env.exit(1)
+	}
+}
+
+// writeTemplateLintIssues writes each issue on its own line, naming files by their path in dir
+func writeTemplateLintIssues(w io.Writer, dir string, issues []boilersuite.TemplateLintIssue) {
+	for _, issue := range issues {
+		issue.File = filepath.Join(dir, issue.File)
+		fmt.Fprintln(w, issue)
+	}
+}
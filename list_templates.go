@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// listTemplates writes a table of every template which applies to files in dir, including which
+// file backs each one and whether it was embedded in boilersuite or overridden by a template dir
+func listTemplates(w io.Writer, configs *dirConfigs, dir string) error {
+	cfg, err := configs.forDir(dir)
+	if err != nil {
+		return err
+	}
+
+	templates, err := configs.templatesFor(cfg)
+	if err != nil {
+		return err
+	}
+
+	overridden := make(map[string]bool)
+
+	if cfg.templateDir != "" {
+		overrides, err := cfg.loadTemplateDir(cfg.author)
+		if err != nil {
+			return err
+		}
+
+		for name := range overrides {
+			overridden[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "TYPE\tKIND\tSOURCE\tTEMPLATE\tALTERNATIVES")
+
+	for _, name := range names {
+		// aliases are backed by the file of the template they refer to
+		target, isAlias := cfg.extAliases[name]
+		if !isAlias {
+			target = name
+		}
+
+		filename := "boilerplate." + target + ".boilertmpl"
+
+		source, path := "embedded", filename
+		if overridden[target] {
+			source, path = "override", filepath.Join(cfg.templateDir, filename)
+		}
+
+		if isAlias {
+			source = "alias"
+		}
+
+		kind := "block"
+		if templates[name].Kind() == boilersuite.TemplateKindLine {
+			kind = "line"
+		}
+
+		alternatives := strings.Join(cfg.alternatives[name], ",")
+		if alternatives == "" {
+			alternatives = "-"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, kind, source, path, alternatives)
+	}
+
+	if len(cfg.additionalAuthors) > 0 {
+		fmt.Fprintf(tw, "\nexpected author %q; also accepting %s\n", cfg.author, strings.Join(cfg.additionalAuthors, ", "))
+	}
+
+	return tw.Flush()
+}
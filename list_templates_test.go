@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_listTemplates(t *testing.T) {
+	root := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(root, "templates"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, dirConfigFilename), []byte(`{"templateDir": "templates", "alternatives": {"go": ["go-spdx"]}, "extAliases": {"bats": "bash"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "templates", "boilerplate.go-spdx.boilertmpl"), []byte("// Copyright <<YEAR>> <<AUTHOR>>\n// SPDX-License-Identifier: Apache-2.0\n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, "cert-manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configs, err := newDirConfigs(root, effectiveConfig{author: "cert-manager"}, boilerplatetemplates.FS, templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := listTemplates(&buf, configs, root); err != nil {
+		t.Fatalf("failed to list templates: %s", err)
+	}
+
+	expectedLines := []string{
+		`(?m)^go\s+block\s+embedded\s+boilerplate\.go\.boilertmpl\s+go-spdx$`,
+		`(?m)^go-spdx\s+block\s+override\s+\S+boilerplate\.go-spdx\.boilertmpl\s+-$`,
+		`(?m)^ini\s+line\s+embedded\s+`,
+		`(?m)^bats\s+block\s+alias\s+boilerplate\.bash\.boilertmpl\s+-$`,
+	}
+
+	for _, expected := range expectedLines {
+		if !regexp.MustCompile(expected).MatchString(buf.String()) {
+			t.Errorf("expected output to match %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
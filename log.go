@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// newLogger returns a logger which writes diagnostics to w at or above the given level, either as
+// logfmt-style text or as JSON lines
+func newLogger(w io.Writer, level string, format string) (*slog.Logger, error) {
+	var minLevel slog.Level
+
+	if err := minLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q; must be one of \"debug\", \"info\", \"warn\" or \"error\"", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: minLevel}
+
+	switch format {
+	case logFormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+
+	case logFormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+
+	default:
+		return nil, fmt.Errorf("invalid log format %q; must be %q or %q", format, logFormatText, logFormatJSON)
+	}
+}
+
+// defaultLogger returns the logger used before flags have been parsed
+func defaultLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, nil))
+}
+
+// exitCode is the value fatal and environment.exit panic with. Unwinding rather than exiting straight
+// away lets deferred calls, such as the one which closes the environment and writes any profiles,
+// run first.
+type exitCode int
+
+// fatal logs msg and its attributes as an error and exits once deferred calls have run. It relies
+// on exitOnFatal being deferred by main.
+func fatal(logger *slog.Logger, msg string, args ...any) {
+	logger.Error(msg, args...)
+	panic(exitCode(1))
+}
+
+// exitOnFatal exits with the code passed to panic by fatal or environment.exit, and must be deferred
+// by main. Any other panic is passed on.
+func exitOnFatal() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	if code, ok := r.(exitCode); ok {
+		os.Exit(int(code))
+	}
+
+	panic(r)
+}
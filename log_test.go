@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_newLogger(t *testing.T) {
+	tests := map[string]struct {
+		level     string
+		format    string
+		expectErr bool
+		expected  []string
+		unwanted  []string
+	}{
+		"info hides debug": {
+			level:    "info",
+			format:   logFormatText,
+			expected: []string{`level=INFO msg="info message" path=a.go`, `level=WARN msg="warn message"`},
+			unwanted: []string{"debug message"},
+		},
+		"debug shows everything": {
+			level:    "DEBUG",
+			format:   logFormatText,
+			expected: []string{`level=DEBUG msg="debug message"`, `level=INFO msg="info message"`},
+		},
+		"warn hides info": {
+			level:    "warn",
+			format:   logFormatText,
+			expected: []string{`level=WARN msg="warn message"`},
+			unwanted: []string{"info message", "debug message"},
+		},
+		"json": {
+			level:    "info",
+			format:   logFormatJSON,
+			expected: []string{`"level":"INFO","msg":"info message","path":"a.go"`},
+		},
+		"invalid level": {
+			level:     "loud",
+			format:    logFormatText,
+			expectErr: true,
+		},
+		"invalid format": {
+			level:     "info",
+			format:    "xml",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			logger, err := newLogger(&out, test.level, test.format)
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error but got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			logger.Debug("debug message")
+			logger.Info("info message", "path", "a.go")
+			logger.Warn("warn message")
+
+			for _, expected := range test.expected {
+				if !strings.Contains(out.String(), expected) {
+					t.Errorf("expected output to contain %q but got:\n%s", expected, out.String())
+				}
+			}
+
+			for _, unwanted := range test.unwanted {
+				if strings.Contains(out.String(), unwanted) {
+					t.Errorf("expected output not to contain %q but got:\n%s", unwanted, out.String())
+				}
+			}
+		})
+	}
+}
@@ -17,15 +17,15 @@ limitations under the License.
 package main
 
 import (
-	"embed"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"io/fs"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
-	"runtime/pprof"
 	"strings"
 
 	"github.com/cert-manager/boilersuite/internal/boilersuite"
@@ -36,127 +36,269 @@ const (
 	defaultAuthor = "cert-manager"
 )
 
-var (
-	alwaysSkippedDirs = []string{".git", "_bin", "bin", "node_modules", "vendor", "third_party", "staging"}
-)
+// command is a subcommand of boilersuite, such as "check"
+type command struct {
+	name        string
+	description string
+	run         func(args []string)
+}
 
-//go:embed boilerplate-templates/*.boilertmpl
-var boilerplateTemplateDir embed.FS
+var commands = []command{
+	{name: "check", description: "Validates the boilerplate in a directory or file", run: func(args []string) { runCheck(args, false) }},
+	{name: "fix", description: "Adds missing boilerplate to files", run: runFix},
+	{name: "bump-year", description: "Updates the copyright year in files which have valid boilerplate", run: runBumpYear},
+	{name: "migrate-author", description: "Replaces the copyright holder in the headers of files", run: runMigrateAuthor},
+	{name: "list", description: "Prints the files which would be checked", run: runList},
+	{name: "suppressions", description: "Lists every skip_license_check marker along with its expiry and reason", run: runSuppressions},
+	{name: "templates", description: "Prints the templates which apply to a directory and where each comes from", run: runTemplates},
+	{name: "lint-templates", description: "Checks a directory of custom templates for mistakes", run: runLintTemplates},
+	{name: "reuse", description: "Checks copyright and licensing information against the REUSE specification", run: runREUSE},
+	{name: "inventory", description: "Prints the copyright holders, years and licenses found in every file", run: runInventory},
+	{name: "explain", description: "Shows how a template is chosen for each given file", run: runExplain},
+	{name: "version", description: "Prints the version of boilersuite", run: runVersion},
+}
 
 func main() {
-	logger := log.New(os.Stdout, "", log.LstdFlags)
-	verboseLogger := log.New(io.Discard, "", 0)
+	defer exitOnFatal()
 
-	skipFlag := flag.String("skip", "", "Space-separated list of prefixes for paths which shouldn't be checked. Spaces in prefixes not supported.")
-	authorFlag := flag.String("author", defaultAuthor, fmt.Sprintf("The expected author for files, which will be substituted for the %q marker in templates", boilersuite.AuthorMarkerRegex))
-	verboseFlag := flag.Bool("verbose", false, "If set, prints verbose output")
-	cpuProfile := flag.String("cpuprofile", "", "If set, writes CPU profiling information to the given filename")
-	printVersion := flag.Bool("version", false, "If set, prints the version and exits")
+	args := os.Args[1:]
 
-	flag.Parse()
+	if len(args) > 0 {
+		switch args[0] {
+		case "help", "-h", "-help", "--help":
+			printUsage(os.Stdout)
+			return
+		}
 
-	if *printVersion {
-		logger.Printf("version: %s", version.AppVersion)
-		logger.Printf(" commit: %s", version.AppGitCommit)
-		os.Exit(0)
+		if cmd, ok := findCommand(args[0]); ok {
+			cmd.run(args[1:])
+			return
+		}
 	}
 
-	if flag.NArg() != 1 {
-		logger.Fatalf("usage: %s [--version] [--skip \"paths to skip\"] [--author \"example\"] [--verbose] <path-to-dir>", os.Args[0])
+	// running without a subcommand is the same as "check", which keeps existing scripts working
+	runCheck(args, true)
+}
+
+// findCommand returns the command with the given name, if there is one
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
 	}
 
-	var skippedDirs []string
+	return command{}, false
+}
 
-	if skipFlag != nil && len(*skipFlag) > 0 {
-		skippedDirs = strings.Fields(*skipFlag)
-	}
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "usage: boilersuite <command> [flags] [args]\n\ncommands:\n")
 
-	if *verboseFlag {
-		verboseLogger = log.New(os.Stdout, "[VERBOSE] ", log.LstdFlags)
+	// descriptions are aligned two spaces after the longest command name
+	width := len("help")
+	for _, cmd := range commands {
+		width = max(width, len(cmd.name))
 	}
 
-	if *cpuProfile != "" {
-		f, err := os.Create(*cpuProfile)
-		if err != nil {
-			logger.Fatal(err)
-		}
+	width += 2
 
-		err = pprof.StartCPUProfile(f)
-		if err != nil {
-			logger.Fatal(err)
-		}
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-*s%s\n", width, cmd.name, cmd.description)
+	}
+
+	fmt.Fprintf(w, "  %-*s%s\n", width, "help", "Prints this message")
+	fmt.Fprintf(w, "\nIf no command is given, \"check\" is run. Use \"boilersuite <command> --help\" to see the flags for a command.\n")
+}
 
-		defer pprof.StopCPUProfile()
+// commandUsage returns a function suitable for flag.FlagSet.Usage which describes a command and its flags
+func commandUsage(flags *flag.FlagSet, usage string, description string) func() {
+	return func() {
+		fmt.Fprintf(flags.Output(), "usage: boilersuite %s\n\n%s\n\nflags:\n", usage, description)
+		flags.PrintDefaults()
 	}
+}
 
-	templates, err := boilersuite.LoadTemplates(boilerplateTemplateDir, *authorFlag)
-	if err != nil {
-		logger.Fatalf("failed to load templates: %s", err.Error())
+func runList(args []string) {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "list [flags] <path-to-validate>", "Prints the files which would be checked, one per line, without validating anything. Use with --verbose to see why other files are skipped.")
+
+	global := addGlobalFlags(flags)
+	selection := addTargetFlags(flags)
+
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	selection.checkArgs(flags, env.logger, "boilersuite list")
+
+	printTargets(env, selection.find(flags, env).targets)
+}
+
+func printTargets(env *environment, targets []target) {
+	for _, t := range targets {
+		fmt.Println(t.path)
 	}
 
-	targetBase := flag.Arg(0)
+	env.logger.Debug("listed files which would be checked", "files", len(targets))
+}
+
+func runSuppressions(args []string) {
+	flags := flag.NewFlagSet("suppressions", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "suppressions [flags] <path-to-validate>", "Lists every skip_license_check marker in the selected files along with its expiry and reason.")
+
+	global := addGlobalFlags(flags)
+	selection := addTargetFlags(flags)
+
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	selection.checkArgs(flags, env.logger, "boilersuite suppressions")
 
-	dir, err := isDir(targetBase)
+	printSuppressions(env, selection.find(flags, env).targets)
+}
+
+func printSuppressions(env *environment, targets []target) {
+	found, err := listSuppressions(os.Stdout, targets)
 	if err != nil {
-		// couldn't check if the base was a dir or not
-		logger.Fatalf("target invalid: %s", err)
+		fatal(env.logger, "failed to list suppressions", "err", err)
 	}
 
-	var targets []target
+	env.logger.Debug("listed suppressions", "suppressions", found, "files", len(targets))
+}
 
-	if dir {
-		targets, err = getTargets(targetBase, templates, skippedDirs, verboseLogger)
-		if err != nil {
-			logger.Fatalf("failed to list targets in dir %q: %s", targetBase, err.Error())
-		}
-	} else {
-		contents, err := os.ReadFile(targetBase)
-		if err != nil {
-			logger.Fatalf("failed to read %q: %s", targetBase, err.Error())
-		}
+func runTemplates(args []string) {
+	flags := flag.NewFlagSet("templates", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "templates [flags] [<path-to-dir>]", "Prints the templates which apply to the given directory (or the working directory if none is given) and where each comes from.")
+
+	global := addGlobalFlags(flags)
 
-		targets = []target{target{
-			path:     targetBase,
-			contents: string(contents),
-		}}
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	if flags.NArg() > 1 {
+		fatal(env.logger, "usage: boilersuite templates [flags] [<path-to-dir>]")
 	}
 
-	if len(targets) == 0 {
-		return
+	listTemplatesIn(env, flags.Arg(0))
+}
+
+func listTemplatesIn(env *environment, dir string) {
+	if dir == "" {
+		dir = "."
 	}
 
-	validationErrors := make([]error, 0)
+	env.useConfigsFrom(dir)
 
-	for _, t := range targets {
-		tmpl, ok := templates.TemplateFor(t.path)
-		if !ok {
-			panic("failed to get a template for a target which was already processed")
-		}
+	if err := listTemplates(os.Stdout, env.resolver.configs, dir); err != nil {
+		fatal(env.logger, "failed to list templates", "err", err)
+	}
+}
 
-		err := tmpl.Validate(t.contents)
-		if err != nil {
-			validationErrors = append(validationErrors, fmt.Errorf("invalid boilerplate in %q: %w", t.path, err))
-			continue
-		}
+func runExplain(args []string) {
+	flags := flag.NewFlagSet("explain", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "explain [flags] <file>...", "Prints every strategy which was tried for choosing a template for each file, and the template which was chosen.")
+
+	global := addGlobalFlags(flags)
 
-		verboseLogger.Printf("validated %q successfully", t.path)
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	explainPaths(env, flags.Args())
+}
+
+func explainPaths(env *environment, paths []string) {
+	if len(paths) == 0 {
+		fatal(env.logger, "usage: boilersuite explain [flags] <file>...")
 	}
 
-	if len(validationErrors) == 0 {
-		verboseLogger.Printf("all files validated successfully")
-		return
+	env.useConfigsFrom(".")
+
+	matched := explainResolution(os.Stdout, env.resolver, paths)
+
+	if err := env.resolver.err(); err != nil {
+		fatal(env.logger, "failed to load config", "err", err)
 	}
 
-	for _, validationErr := range validationErrors {
-		logger.Println(validationErr)
+	if !matched {
+		env.exit(1)
 	}
+}
+
+func runVersion(args []string) {
+	flags := flag.NewFlagSet("version", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "version", "Prints the version of boilersuite.")
+
+	_ = flags.Parse(args)
 
-	logger.Fatalln("at least one file had errors")
+	printVersionInfo()
+}
+
+func printVersionInfo() {
+	fmt.Printf("version: %s\n", version.AppVersion)
+	fmt.Printf(" commit: %s\n", version.AppGitCommit)
 }
 
 type target struct {
-	path     string
-	contents string
+	path string
+	tmpl boilersuite.BoilerplateTemplate
+
+	// contents is set for targets which aren't read from disk, e.g. when reading from stdin
+	contents []byte
+}
+
+func (t target) read() ([]byte, error) {
+	if t.contents != nil {
+		return t.contents, nil
+	}
+
+	return os.ReadFile(t.path)
+}
+
+// readText returns the target's contents as UTF-8, decoding UTF-16 and removing any byte order mark
+func (t target) readText() (string, error) {
+	contents, err := t.read()
+	if err != nil {
+		return "", err
+	}
+
+	text, _, err := boilersuite.DecodeText(contents)
+
+	return text, err
+}
+
+// readHead returns up to limit bytes from the start of the target, and whether that's all of it.
+// If limit isn't positive, the whole target is read.
+func (t target) readHead(limit int) ([]byte, bool, error) {
+	if t.contents != nil || limit <= 0 {
+		contents, err := t.read()
+		return contents, true, err
+	}
+
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer f.Close()
+
+	// read an extra byte to find out whether there's anything after the head
+	head := make([]byte, limit+1)
+
+	n, err := io.ReadFull(f, head)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return head[:n], true, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	return head[:limit], false, nil
 }
 
 func isDir(path string) (bool, error) {
@@ -168,73 +310,285 @@ func isDir(path string) (bool, error) {
 	return stat.IsDir(), nil
 }
 
-func getTargets(targetBase string, templates boilersuite.TemplateMap, skippedPrefixes []string, verboseLogger *log.Logger) ([]target, error) {
-	var targets []target
-
+func newSkipMap(skippedPrefixes []string) map[string]struct{} {
 	skipMap := make(map[string]struct{})
 
-	for _, skip := range append(skippedPrefixes, alwaysSkippedDirs...) {
+	for _, skip := range append(skippedPrefixes, boilersuite.AlwaysSkippedDirs...) {
 		skipMap[skip] = struct{}{}
 	}
 
-	err := filepath.WalkDir(targetBase, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
+	return skipMap
+}
+
+func getTargets(ctx context.Context, targetBase string, resolver templateResolver, skippedPrefixes []string, incremental *incrementalState, followSymlinks bool, logger *slog.Logger) ([]target, error) {
+	var targets []target
+
+	skipMap := newSkipMap(skippedPrefixes)
+
+	links, err := newSymlinkPolicy(targetBase, followSymlinks)
+	if err != nil {
+		return nil, err
+	}
+
+	// walk walks root, reporting paths as if they were under displayRoot. The two differ when walking the
+	// target of a followed symlink, and when following symlinks at all since the walk then starts from the
+	// resolved target directory so that loops can be detected.
+	var walk func(root string, displayRoot string) error
+
+	walk = func(root string, displayRoot string) error {
+		return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			resolvedPath := path
+			if root != displayRoot {
+				path = displayPath(root, displayRoot, path)
+			}
+
+			if d.Type()&fs.ModeSymlink != 0 {
+				linked, info, err := links.resolve(resolvedPath)
+				if err != nil {
+					logger.Debug("skipping broken symlink", "path", path, "err", err)
+					return nil
+				}
+
+				if !links.follow && !links.insideRoot(linked) {
+					logger.Debug("skipping symlink which points outside of the target directory; use --follow-symlinks to check it", "path", path, "target", linked)
+					return nil
+				}
+
+				if info.IsDir() {
+					if !links.follow {
+						logger.Debug("skipping symlink to a directory; use --follow-symlinks to check it", "path", path)
+						return nil
+					}
+
+					return walk(linked, path)
+				}
+			}
+
+			if d.IsDir() {
+				if links.follow && !links.visit(resolvedPath) {
+					logger.Debug("skipping directory which was already walked through another path", "path", path)
+					return fs.SkipDir
+				}
+
+				if isSkippedDir(path, skipMap) {
+					logger.Debug("skipping directory", "path", path)
+					return fs.SkipDir
+				}
+
+				if incremental != nil {
+					unchanged, err := incremental.observeDir(path, d)
+					if err != nil {
+						return err
+					}
+
+					if unchanged {
+						logger.Debug("skipping files in directory as it's unchanged since the last run", "path", path)
+					}
+				}
+
+				return nil
+			}
+
+			if incremental != nil && incremental.isUnchanged(filepath.Dir(path)) {
+				return nil
+			}
+
+			if isSkippedFile(targetBase, path) {
+				logger.Debug("skipping file", "path", path)
+				return nil
+			}
 
-		if d.IsDir() {
-			if isSkippedDir(path, skipMap) {
-				verboseLogger.Printf("skipping directory %q", path)
-				return fs.SkipDir
+			if resolver.exempt(path) {
+				logger.Debug("skipping file as its directory is exempt", "path", path)
+				return nil
 			}
 
+			tmpl, ok := resolver.templateFor(path)
+			if !ok {
+				// if there's no template for the given file, skip it
+				resolver.unknown.record(path)
+				return nil
+			}
+
+			targets = append(targets, target{
+				path: path,
+				tmpl: tmpl,
+			})
+
 			return nil
+		})
+	}
+
+	walkRoot := targetBase
+	if links.follow {
+		walkRoot = links.root
+	}
+
+	if err := walk(walkRoot, targetBase); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// filterTargets applies the same rules used when walking targetBase to an explicit list of paths, returning only
+// those paths which are inside targetBase and which would have been checked had targetBase been walked
+func filterTargets(ctx context.Context, targetBase string, paths []string, resolver templateResolver, skippedPrefixes []string, logger *slog.Logger) ([]target, error) {
+	var targets []target
+
+	skipMap := newSkipMap(skippedPrefixes)
+
+	absBase, err := filepath.Abs(targetBase)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+
+		rel, err := filepath.Rel(absBase, absPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			// not inside the target directory
+			continue
+		}
+
+		path = filepath.Join(targetBase, rel)
+
+		if dirPath := filepath.Dir(rel); dirPath != "." && isSkippedDirPath(dirPath, skipMap) {
+			logger.Debug("skipping file as it's in a skipped directory", "path", path)
+			continue
 		}
 
 		if isSkippedFile(targetBase, path) {
-			verboseLogger.Printf("skipping file %q", path)
-			return nil
+			logger.Debug("skipping file", "path", path)
+			continue
 		}
 
-		_, ok := templates.TemplateFor(path)
-		if !ok {
-			// if there's no template for the given file, skip it
-			return nil
+		if resolver.exempt(path) {
+			logger.Debug("skipping file as its directory is exempt", "path", path)
+			continue
 		}
 
-		contents, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("failed to read %q: %w", path, err)
+		tmpl, ok := resolver.templateFor(path)
+		if !ok {
+			resolver.unknown.record(path)
+			continue
 		}
 
 		targets = append(targets, target{
-			path:     path,
-			contents: string(contents),
+			path: path,
+			tmpl: tmpl,
 		})
+	}
 
-		return nil
-	})
+	return targets, nil
+}
 
+// readFileListFrom reads a NUL-delimited list of paths from the given file, or from stdin if path is "-"
+func readFileListFrom(path string) ([]string, error) {
+	if path == "-" {
+		return readFileList(os.Stdin)
+	}
+
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
 
-	return targets, nil
+	defer f.Close()
+
+	return readFileList(f)
 }
 
-func isSkippedFile(base string, path string) bool {
-	filename := filepath.Base(path)
+// readFileList reads a NUL-delimited list of paths, such as the output of "git ls-files -z". Using NUL as a
+// delimiter means that paths containing spaces, newlines or other unusual characters are handled safely.
+func readFileList(r io.Reader) ([]string, error) {
+	contents, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
 
-	if filename == "go.mod" || filename == "go.sum" || filename == "go.work" || filename == "go.work.sum" {
-		return true
+	for _, path := range strings.Split(string(contents), "\x00") {
+		if path != "" {
+			paths = append(paths, path)
+		}
 	}
 
-	if strings.HasPrefix(filename, "zz_generated") {
-		return true
+	return paths, nil
+}
+
+// fileListTargets returns targets for an explicit list of files, such as those passed by pre-commit hooks.
+// Unlike when walking a directory, files which have no matching template produce a warning rather than
+// being silently skipped.
+func fileListTargets(ctx context.Context, paths []string, resolver templateResolver, skippedPrefixes []string, logger *slog.Logger) ([]target, error) {
+	var targets []target
+
+	skipMap := newSkipMap(skippedPrefixes)
+
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		dir, err := isDir(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if dir {
+			return nil, fmt.Errorf("%q is a directory, but only files can be checked when using --files", path)
+		}
+
+		if dirPath := filepath.Dir(path); dirPath != "." && isSkippedDirPath(dirPath, skipMap) {
+			logger.Debug("skipping file as it's in a skipped directory", "path", path)
+			continue
+		}
+
+		if isSkippedFile(path, path) {
+			logger.Debug("skipping file", "path", path)
+			continue
+		}
+
+		if resolver.exempt(path) {
+			logger.Debug("skipping file as its directory is exempt", "path", path)
+			continue
+		}
+
+		tmpl, ok := resolver.templateFor(path)
+		if !ok {
+			logger.Warn("no template matches file so it wasn't checked", "path", path)
+			resolver.unknown.record(path)
+			continue
+		}
+
+		targets = append(targets, target{
+			path: path,
+			tmpl: tmpl,
+		})
 	}
 
-	return false
+	return targets, nil
+}
 
+func isSkippedFile(base string, path string) bool {
+	return boilersuite.IsSkippedFilename(filepath.Base(path))
 }
 
 func isSkippedDir(path string, allSkips map[string]struct{}) bool {
@@ -242,3 +596,14 @@ func isSkippedDir(path string, allSkips map[string]struct{}) bool {
 
 	return shouldSkip
 }
+
+// isSkippedDirPath returns true if any directory in the given relative path would be skipped
+func isSkippedDirPath(relPath string, allSkips map[string]struct{}) bool {
+	for _, component := range strings.Split(relPath, string(filepath.Separator)) {
+		if isSkippedDir(component, allSkips) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_filterTargets(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	paths := []string{
+		"repo/main.go",
+		"repo/hack/script.sh",
+		"repo/NOTES.txt",
+		"repo/go.mod",
+		"repo/vendor/example.com/lib/lib.go",
+		"repo/docs/skipme/file.go",
+		"other/main.go",
+	}
+
+	targets, err := filterTargets(context.Background(), "repo", paths, templateResolver{templates: templates}, []string{"skipme"}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("failed to filter targets: %s", err)
+	}
+
+	var targetPaths []string
+
+	for _, t := range targets {
+		targetPaths = append(targetPaths, t.path)
+	}
+
+	expected := []string{
+		filepath.Join("repo", "main.go"),
+		filepath.Join("repo", "hack", "script.sh"),
+	}
+
+	if !reflect.DeepEqual(targetPaths, expected) {
+		t.Errorf("got %v, wanted %v", targetPaths, expected)
+	}
+}
+
+func Test_readFileList(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected []string
+	}{
+		"empty": {
+			input:    "",
+			expected: nil,
+		},
+		"trailing NUL": {
+			input:    "a.go\x00b/c.sh\x00",
+			expected: []string{"a.go", "b/c.sh"},
+		},
+		"no trailing NUL": {
+			input:    "a.go\x00b/c.sh",
+			expected: []string{"a.go", "b/c.sh"},
+		},
+		"unusual characters": {
+			input:    "with space.go\x00with\nnewline.go\x00",
+			expected: []string{"with space.go", "with\nnewline.go"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			paths, err := readFileList(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(paths, test.expected) {
+				t.Errorf("got %q, wanted %q", paths, test.expected)
+			}
+		})
+	}
+}
+
+func Test_findCommand(t *testing.T) {
+	tests := map[string]struct {
+		name     string
+		expected bool
+	}{
+		"check": {
+			name:     "check",
+			expected: true,
+		},
+		"fix": {
+			name:     "fix",
+			expected: true,
+		},
+		"path is not a command": {
+			name:     ".",
+			expected: false,
+		},
+		"flag is not a command": {
+			name:     "--files",
+			expected: false,
+		},
+		"help is handled separately": {
+			name:     "help",
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd, ok := findCommand(test.name)
+			if ok != test.expected {
+				t.Fatalf("expected found=%v for %q but got %v", test.expected, test.name, ok)
+			}
+
+			if ok && cmd.name != test.name {
+				t.Errorf("expected command %q but got %q", test.name, cmd.name)
+			}
+		})
+	}
+}
+
+func Test_printUsage(t *testing.T) {
+	var out strings.Builder
+
+	printUsage(&out)
+
+	lines := strings.Split(out.String(), "\n")
+
+	// line returns the line of the usage which lists the named command
+	line := func(t *testing.T, name string) string {
+		for _, l := range lines {
+			if strings.HasPrefix(l, "  "+name+" ") {
+				return l
+			}
+		}
+
+		t.Fatalf("expected %q to be listed, separated from its description, in:\n%s", name, out.String())
+		return ""
+	}
+
+	// every description should start in the same column as the first one
+	column := strings.Index(line(t, "check"), "Validates")
+
+	tests := map[string]struct {
+		description string
+	}{
+		"check": {
+			description: "Validates the boilerplate in a directory or file",
+		},
+		"migrate-author": {
+			description: "Replaces the copyright holder in the headers of files",
+		},
+		"lint-templates": {
+			description: "Checks a directory of custom templates for mistakes",
+		},
+		"help": {
+			description: "Prints this message",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := line(t, name)
+
+			if !strings.HasPrefix(l, "  "+name+"  ") {
+				t.Errorf("expected at least two spaces after %q in %q", name, l)
+			}
+
+			if start := strings.Index(l, test.description); start != column {
+				t.Errorf("expected the description to start at column %d but it starts at %d in %q", column, start, l)
+			}
+		})
+	}
+}
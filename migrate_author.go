@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// runMigrateAuthor replaces the copyright holder in the headers of the selected files
+func runMigrateAuthor(args []string) {
+	flags := flag.NewFlagSet("migrate-author", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "migrate-author [flags] --from <old-holder> --to <new-holder> <path-to-migrate>", "Replaces the copyright holder in the headers of every file, e.g. after a project is donated to a foundation. Years and the rest of each header are kept.")
+
+	global := addGlobalFlags(flags)
+	selection := addTargetFlags(flags)
+
+	from := flags.String("from", "", "The copyright holder to replace, exactly as it appears in headers, e.g. \"Jetstack Ltd.\"")
+	to := flags.String("to", "", "The copyright holder which replaces the one given by --from, e.g. \"The cert-manager Authors.\"")
+
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	logger := env.logger
+
+	selection.checkArgs(flags, logger, "boilersuite migrate-author")
+
+	if *from == "" || *to == "" {
+		fatal(logger, "both --from and --to must be given")
+	}
+
+	// a file's copyright holder can be replaced whether or not a template matches it
+	env.resolver.unknown = &unknownFiles{}
+
+	set := selection.find(flags, env)
+
+	targets := set.targets
+	for _, path := range set.unknown {
+		targets = append(targets, target{path: path})
+	}
+
+	migrated, _ := rewriteTargets(env, targets, rewriteOptions{stdin: selection.stdin, skipNonUTF8: true, message: "migrated file"}, func(t target, text string) (string, bool, error) {
+		text, changed := boilersuite.MigrateCopyrightHolder(text, *from, *to)
+
+		return text, changed, nil
+	})
+
+	env.exitIfCancelled()
+
+	logger.Info("replaced copyright holder", "from", *from, "to", *to, "files", len(migrated))
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package boilerplate exposes boilersuite's functionality for use as a library, e.g. by code
+// generators which need to add boilerplate to their output.
+package boilerplate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+const defaultAuthor = "cert-manager"
+
+// FixTreeOptions configures FixTree
+type FixTreeOptions struct {
+	// Author is substituted for the <<AUTHOR>> marker in templates. Defaults to "cert-manager".
+	Author string
+
+	// Year is substituted for the <<YEAR>> marker in templates. Defaults to the current year.
+	Year int
+
+	// Templates holds the boilerplate templates to use at its root, e.g. os.DirFS("hack/boilerplate").
+	// Defaults to the templates bundled with boilersuite.
+	Templates fs.FS
+
+	// License names the license whose notice is used in place of the "license" fragment which the
+	// templates include, e.g. "mit". Defaults to "apache-2.0", which leaves the templates unchanged.
+	License string
+
+	// SkipDirs holds the names of extra directories which shouldn't be descended into. Some
+	// directories such as "vendor" are always skipped.
+	SkipDirs []string
+
+	// SkipGenerated leaves generated files unchanged. By default boilerplate is added to generated
+	// files, since FixTree is intended to be run on the output of code generators.
+	SkipGenerated bool
+}
+
+// FixTree walks the directory at root and adds boilerplate to every text file which has a matching
+// template and which doesn't already have valid boilerplate. It returns the paths of all files
+// which were changed.
+//
+// Files which already have boilerplate that doesn't match the template, or which declare a license
+// that's incompatible with opts.License, aren't changed, and are reported in the returned error
+// after every other file has been processed.
+func FixTree(root string, opts FixTreeOptions) ([]string, error) {
+	if opts.Author == "" {
+		opts.Author = defaultAuthor
+	}
+
+	if opts.Year == 0 {
+		opts.Year = time.Now().Year()
+	}
+
+	if opts.Templates == nil {
+		opts.Templates = boilerplatetemplates.FS
+	}
+
+	if opts.License == "" {
+		opts.License = boilerplatetemplates.DefaultLicense
+	}
+
+	notice, err := boilerplatetemplates.LicenseNotice(opts.License)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := boilersuite.LoadTemplatesForLicense(opts.Templates, opts.Author, notice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	licensePolicy, err := boilersuite.LicensePolicyFor(opts.License)
+	if err != nil {
+		return nil, err
+	}
+
+	templates = templates.WithLicensePolicy(licensePolicy)
+
+	skipDirs := make(map[string]struct{})
+
+	for _, dir := range append(opts.SkipDirs, boilersuite.AlwaysSkippedDirs...) {
+		skipDirs[dir] = struct{}{}
+	}
+
+	fixOpts := boilersuite.FixOptions{
+		Year:             opts.Year,
+		IncludeGenerated: !opts.SkipGenerated,
+	}
+
+	var fixed []string
+	var fixErrors []string
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if _, skip := skipDirs[d.Name()]; skip && path != root {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.Type().IsRegular() || boilersuite.IsSkippedFilename(d.Name()) {
+			return nil
+		}
+
+		tmpl, ok := templates.TemplateFor(path)
+		if !ok {
+			return nil
+		}
+
+		changed, err := fixFile(path, tmpl, fixOpts)
+		if err != nil {
+			fixErrors = append(fixErrors, fmt.Sprintf("%s: %s", path, err))
+			return nil
+		}
+
+		if changed {
+			fixed = append(fixed, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fixed, err
+	}
+
+	if len(fixErrors) > 0 {
+		return fixed, fmt.Errorf("failed to fix %d files:\n%s", len(fixErrors), strings.Join(fixErrors, "\n"))
+	}
+
+	return fixed, nil
+}
+
+func fixFile(path string, tmpl boilersuite.BoilerplateTemplate, opts boilersuite.FixOptions) (bool, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	if boilersuite.IsBinary(contents) {
+		return false, nil
+	}
+
+	text, encoding, err := boilersuite.DecodeText(contents)
+	if err != nil {
+		return false, err
+	}
+
+	fixed, err := tmpl.Fix(text, opts)
+	if err != nil {
+		return false, err
+	}
+
+	if fixed == text {
+		return false, nil
+	}
+
+	// os.WriteFile keeps the permissions of existing files, and the file keeps its encoding
+	return true, os.WriteFile(path, boilersuite.EncodeText(fixed, encoding), 0o644)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boilerplate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const expectedGoHeader = `/*
+Copyright 2026 The example Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+`
+
+func Test_FixTree(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		"main.go":                "package main\n",
+		"generated.go":           "// Code generated by client-gen. DO NOT EDIT.\n\npackage main\n",
+		"hack/script.sh":         "#!/usr/bin/env bash\necho hello\n",
+		"go.mod":                 "module example.com/example\n",
+		"NOTES.txt":              "example\n",
+		"vendor/example/main.go": "package example\n",
+		"existing.go":            "// Copyright 2020 Someone Else\n\npackage main\n",
+	}
+
+	for name, contents := range files {
+		path := filepath.Join(root, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fixed, err := FixTree(root, FixTreeOptions{Author: "example", Year: 2026})
+	if err == nil || !strings.Contains(err.Error(), "existing.go") {
+		t.Errorf("expected an error for the file with existing boilerplate but got: %v", err)
+	}
+
+	expectedFixed := []string{
+		filepath.Join(root, "generated.go"),
+		filepath.Join(root, "hack", "script.sh"),
+		filepath.Join(root, "main.go"),
+	}
+
+	if !reflect.DeepEqual(fixed, expectedFixed) {
+		t.Errorf("fixed=%v, expected=%v", fixed, expectedFixed)
+	}
+
+	for name, contents := range files {
+		actual, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wasFixed := string(actual) != contents
+
+		switch name {
+		case "main.go", "generated.go":
+			if !strings.HasPrefix(string(actual), expectedGoHeader) || !strings.HasSuffix(string(actual), "*/\n\n"+contents) {
+				t.Errorf("unexpected contents for %s:\n%s", name, actual)
+			}
+
+		case "hack/script.sh":
+			if !strings.HasPrefix(string(actual), "#!/usr/bin/env bash\n\n# Copyright 2026 The example Authors.\n") {
+				t.Errorf("unexpected contents for %s:\n%s", name, actual)
+			}
+
+		default:
+			if wasFixed {
+				t.Errorf("expected %s not to be changed but got:\n%s", name, actual)
+			}
+		}
+	}
+
+	fixedAgain, err := FixTree(root, FixTreeOptions{Author: "example", Year: 2026})
+	if len(fixedAgain) != 0 {
+		t.Errorf("expected running FixTree twice to be a no-op but fixed %v (err=%v)", fixedAgain, err)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// startProfiling starts CPU profiling and execution tracing if their paths are set, and arranges for
+// them along with any heap profile to be written when the environment is closed
+func (env *environment) startProfiling(cpuProfile string, memProfile string, tracePath string) {
+	if cpuProfile != "" {
+		f := env.createProfile(cpuProfile)
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			fatal(env.logger, "failed to start CPU profile", "err", err)
+		}
+
+		env.closers = append(env.closers, func() {
+			pprof.StopCPUProfile()
+			env.closeProfile(f)
+		})
+	}
+
+	if tracePath != "" {
+		f := env.createProfile(tracePath)
+
+		if err := trace.Start(f); err != nil {
+			fatal(env.logger, "failed to start execution trace", "err", err)
+		}
+
+		env.closers = append(env.closers, func() {
+			trace.Stop()
+			env.closeProfile(f)
+		})
+	}
+
+	if memProfile != "" {
+		// create the file up front so that an invalid path is reported before doing any work
+		f := env.createProfile(memProfile)
+
+		env.closers = append(env.closers, func() {
+			// get up-to-date statistics
+			runtime.GC()
+
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				env.logger.Error("failed to write heap profile", "path", memProfile, "err", err)
+			}
+
+			env.closeProfile(f)
+		})
+	}
+}
+
+func (env *environment) createProfile(path string) *os.File {
+	f, err := os.Create(path)
+	if err != nil {
+		fatal(env.logger, "failed to create profile", "path", path, "err", err)
+	}
+
+	return f
+}
+
+func (env *environment) closeProfile(f *os.File) {
+	if err := f.Close(); err != nil {
+		env.logger.Error("failed to write profile", "path", f.Name(), "err", err)
+	}
+}
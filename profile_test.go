@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_startProfiling(t *testing.T) {
+	dir := t.TempDir()
+
+	cpuProfile := filepath.Join(dir, "cpu.out")
+	memProfile := filepath.Join(dir, "mem.out")
+	tracePath := filepath.Join(dir, "trace.out")
+
+	env := &environment{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	env.startProfiling(cpuProfile, memProfile, tracePath)
+	env.close()
+
+	for _, path := range []string{cpuProfile, memProfile, tracePath} {
+		stat, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("expected %q to be written: %s", path, err)
+		}
+
+		if stat.Size() == 0 {
+			t.Errorf("expected %q not to be empty", path)
+		}
+	}
+}
+
+func Test_fatalWritesProfiles(t *testing.T) {
+	tests := map[string]struct {
+		fail         func(env *environment)
+		expectedCode exitCode
+	}{
+		"fatal": {
+			fail: func(env *environment) {
+				fatal(env.logger, "failed to do something")
+			},
+			expectedCode: 1,
+		},
+		"exit": {
+			fail: func(env *environment) {
+				env.exit(3)
+			},
+			expectedCode: 3,
+		},
+		"cancelled": {
+			fail: func(env *environment) {
+				ctx, cancel := context.WithCancelCause(context.Background())
+				cancel(errInterrupted)
+
+				env.ctx = ctx
+				env.exitIfCancelled()
+			},
+			expectedCode: exitCodeInterrupted,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			memProfile := filepath.Join(t.TempDir(), "mem.out")
+
+			env := &environment{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+			// runCommand stands in for a command, which defers closing its environment before
+			// anything can fail
+			runCommand := func() (code exitCode) {
+				defer func() {
+					code, _ = recover().(exitCode)
+				}()
+
+				env.startProfiling("", memProfile, "")
+				defer env.close()
+
+				test.fail(env)
+
+				return 0
+			}
+
+			if code := runCommand(); code != test.expectedCode {
+				t.Errorf("got exit code %d, wanted %d", code, test.expectedCode)
+			}
+
+			stat, err := os.Stat(memProfile)
+			if err != nil {
+				t.Fatalf("expected the profile to be written: %s", err)
+			}
+
+			if stat.Size() == 0 {
+				t.Errorf("expected the profile not to be empty")
+			}
+		})
+	}
+}
+
+func Test_loadFailureWritesProfiles(t *testing.T) {
+	memProfile := filepath.Join(t.TempDir(), "mem.out")
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	global := addGlobalFlags(flags)
+
+	if err := flags.Parse([]string{"--memprofile", memProfile, "--license", "not-a-license"}); err != nil {
+		t.Fatal(err)
+	}
+
+	code := func() (code exitCode) {
+		defer func() {
+			code, _ = recover().(exitCode)
+		}()
+
+		global.load(flags)
+
+		return 0
+	}()
+
+	if code != 1 {
+		t.Errorf("got exit code %d, wanted 1", code)
+	}
+
+	stat, err := os.Stat(memProfile)
+	if err != nil {
+		t.Fatalf("expected the profile to be written: %s", err)
+	}
+
+	if stat.Size() == 0 {
+		t.Errorf("expected the profile not to be empty")
+	}
+}
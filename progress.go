@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+const (
+	progressAuto  = "auto"
+	progressTTY   = "tty"
+	progressPlain = "plain"
+	progressNone  = "none"
+)
+
+const (
+	// ttyProgressInterval limits how often the progress line is redrawn on a terminal
+	ttyProgressInterval = 100 * time.Millisecond
+
+	// plainProgressInterval is how often a status line is printed in plain mode, which is
+	// intended for CI logs where each update adds a line
+	plainProgressInterval = 10 * time.Second
+)
+
+// progressReporter prints how many files have been checked so that long runs don't look hung.
+// A nil progressReporter does nothing.
+type progressReporter struct {
+	w     io.Writer
+	tty   bool
+	total int
+	done  int
+
+	interval time.Duration
+	last     time.Time
+	now      func() time.Time
+}
+
+func validProgressMode(mode string) error {
+	switch mode {
+	case progressAuto, progressTTY, progressPlain, progressNone:
+		return nil
+
+	default:
+		return fmt.Errorf("invalid --progress %q; must be %q, %q, %q or %q", mode, progressAuto, progressTTY, progressPlain, progressNone)
+	}
+}
+
+// newProgressReporter returns a reporter for the given valid --progress mode which writes to f, or
+// nil if progress shouldn't be reported. In "auto" mode, progress is only reported if f is a terminal.
+func newProgressReporter(mode string, f *os.File, total int) *progressReporter {
+	if mode == progressNone || (mode == progressAuto && !isTerminal(f)) {
+		return nil
+	}
+
+	p := &progressReporter{
+		w:        f,
+		tty:      mode != progressPlain,
+		total:    total,
+		interval: plainProgressInterval,
+		now:      time.Now,
+	}
+
+	if p.tty {
+		p.interval = ttyProgressInterval
+	}
+
+	p.last = p.now()
+
+	return p
+}
+
+// increment records that another file was checked, printing an update if one is due
+func (p *progressReporter) increment() {
+	if p == nil {
+		return
+	}
+
+	p.done++
+
+	if now := p.now(); now.Sub(p.last) >= p.interval || p.done == p.total {
+		p.last = now
+		p.print()
+	}
+}
+
+func (p *progressReporter) print() {
+	percent := 100
+	if p.total > 0 {
+		percent = p.done * 100 / p.total
+	}
+
+	if p.tty {
+		// redraw the same line in place
+		fmt.Fprintf(p.w, "\r\x1b[Kchecked %d/%d files (%d%%)", p.done, p.total, percent)
+		return
+	}
+
+	fmt.Fprintf(p.w, "checked %d of %d files (%d%%)\n", p.done, p.total, percent)
+}
+
+// finish clears the progress line from a terminal so that it doesn't mix with later output
+func (p *progressReporter) finish() {
+	if p == nil || !p.tty {
+		return
+	}
+
+	fmt.Fprint(p.w, "\r\x1b[K")
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func Test_progressReporter(t *testing.T) {
+	tests := map[string]struct {
+		tty      bool
+		expected string
+	}{
+		"plain": {
+			tty:      false,
+			expected: "checked 2 of 4 files (50%)\nchecked 4 of 4 files (100%)\n",
+		},
+		"tty": {
+			tty:      true,
+			expected: "\r\x1b[Kchecked 2/4 files (50%)\r\x1b[Kchecked 4/4 files (100%)\r\x1b[K",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			p := &progressReporter{
+				w:        &out,
+				tty:      test.tty,
+				total:    4,
+				interval: 10 * time.Second,
+				last:     clock,
+				now:      func() time.Time { return clock },
+			}
+
+			// the first file is checked before an update is due
+			clock = clock.Add(5 * time.Second)
+			p.increment()
+
+			clock = clock.Add(5 * time.Second)
+			p.increment()
+
+			// the last file is always reported
+			p.increment()
+			p.increment()
+
+			p.finish()
+
+			if out.String() != test.expected {
+				t.Errorf("got %q, wanted %q", out.String(), test.expected)
+			}
+		})
+	}
+}
+
+func Test_nilProgressReporter(t *testing.T) {
+	var p *progressReporter
+
+	// shouldn't panic
+	p.increment()
+	p.finish()
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// outputRDJSON is the Reviewdog Diagnostic Format, which lets reviewdog post failures as inline
+// review comments with suggested fixes
+const outputRDJSON = "rdjson"
+
+// rdjsonResult is the root of a Reviewdog Diagnostic Format report
+type rdjsonResult struct {
+	Source      rdjsonSource       `json:"source"`
+	Severity    string             `json:"severity"`
+	Diagnostics []rdjsonDiagnostic `json:"diagnostics"`
+}
+
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type rdjsonDiagnostic struct {
+	Message     string             `json:"message"`
+	Location    rdjsonLocation     `json:"location"`
+	Severity    string             `json:"severity"`
+	Code        *rdjsonCode        `json:"code,omitempty"`
+	Suggestions []rdjsonSuggestion `json:"suggestions,omitempty"`
+}
+
+type rdjsonLocation struct {
+	Path  string       `json:"path"`
+	Range *rdjsonRange `json:"range,omitempty"`
+}
+
+type rdjsonRange struct {
+	Start rdjsonPosition  `json:"start"`
+	End   *rdjsonPosition `json:"end,omitempty"`
+}
+
+// rdjsonPosition is a position in a file, where both line and column start at 1
+type rdjsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+type rdjsonCode struct {
+	Value string `json:"value"`
+}
+
+type rdjsonSuggestion struct {
+	Range rdjsonRange `json:"range"`
+	Text  string      `json:"text"`
+}
+
+// fixSuggestion replaces the lines from startLine up to but not including endLine, both starting
+// at 1, with text
+type fixSuggestion struct {
+	startLine int
+	endLine   int
+	text      string
+}
+
+// suggestFix returns the change which "boilersuite fix" would make to text, or nil if it can't fix
+// the file
+func suggestFix(tmpl boilersuite.BoilerplateTemplate, text string, year int) *fixSuggestion {
+	fixed, err := tmpl.Fix(text, boilersuite.FixOptions{Year: year})
+	if err != nil || fixed == text {
+		return nil
+	}
+
+	before := strings.SplitAfter(text, "\n")
+	after := strings.SplitAfter(fixed, "\n")
+
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix && before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+
+	return &fixSuggestion{
+		startLine: prefix + 1,
+		endLine:   len(before) - suffix + 1,
+		text:      strings.Join(after[prefix:len(after)-suffix], ""),
+	}
+}
+
+// writeRDJSONReport writes the failures in the report in the Reviewdog Diagnostic Format
+func writeRDJSONReport(w io.Writer, r report) error {
+	result := rdjsonResult{
+		Source: rdjsonSource{
+			Name: "boilersuite",
+			URL:  "https://github.com/cert-manager/boilersuite",
+		},
+		Severity:    "ERROR",
+		Diagnostics: make([]rdjsonDiagnostic, 0, len(r.Failures)),
+	}
+
+	for _, failure := range r.Failures {
+		diagnostic := rdjsonDiagnostic{
+			Message:  failure.Message,
+			Location: rdjsonLocation{Path: failure.Path},
+			Severity: "ERROR",
+		}
+
+		if failure.Kind != "" {
+			diagnostic.Code = &rdjsonCode{Value: failure.Kind}
+		}
+
+		if failure.Path != "" {
+			diagnostic.Location.Range = &rdjsonRange{
+				Start: rdjsonPosition{Line: max(failure.Line, 1), Column: 1},
+			}
+		}
+
+		if s := failure.suggestion; s != nil {
+			diagnostic.Suggestions = []rdjsonSuggestion{
+				{
+					Range: rdjsonRange{
+						Start: rdjsonPosition{Line: s.startLine, Column: 1},
+						End:   &rdjsonPosition{Line: s.endLine, Column: 1},
+					},
+					Text: s.text,
+				},
+			}
+		}
+
+		result.Diagnostics = append(result.Diagnostics, diagnostic)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(result)
+}
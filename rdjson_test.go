@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_suggestFix(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	tmpl := templates["sh"]
+
+	valid, err := tmpl.Fix("echo hello\n", boilersuite.FixOptions{Year: 2024})
+	if err != nil {
+		t.Fatalf("failed to add boilerplate: %s", err)
+	}
+
+	header := valid[:len(valid)-len("echo hello\n")]
+
+	tests := map[string]struct {
+		input            string
+		expectSuggestion *fixSuggestion
+	}{
+		"missing header": {
+			input:            "echo hello\n",
+			expectSuggestion: &fixSuggestion{startLine: 1, endLine: 1, text: header},
+		},
+		"missing header after shebang": {
+			input:            "#!/usr/bin/env bash\necho hello\n",
+			expectSuggestion: &fixSuggestion{startLine: 2, endLine: 2, text: "\n" + header},
+		},
+		"valid header": {
+			input:            valid,
+			expectSuggestion: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			suggestion := suggestFix(tmpl, test.input, 2024)
+
+			if (suggestion == nil) != (test.expectSuggestion == nil) {
+				t.Fatalf("got suggestion %+v, expected %+v", suggestion, test.expectSuggestion)
+			}
+
+			if suggestion != nil && *suggestion != *test.expectSuggestion {
+				t.Errorf("got suggestion %+v, expected %+v", *suggestion, *test.expectSuggestion)
+			}
+		})
+	}
+}
+
+func Test_writeRDJSONReport(t *testing.T) {
+	validationErrors := []error{
+		&fileError{
+			path: "a.sh",
+			err: &boilersuite.ValidationError{
+				Reason: "does not start with expected template type",
+				Kind:   boilersuite.ErrorKindWrongAuthor,
+				Line:   2,
+			},
+		},
+		&fileError{
+			path:       "b.sh",
+			err:        &boilersuite.ValidationError{Reason: "does not start with expected template type", Kind: boilersuite.ErrorKindMissingBoilerplate},
+			suggestion: &fixSuggestion{startLine: 1, endLine: 1, text: "# header\n"},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	if err := writeReport(&buf, outputRDJSON, newReport(2, validationErrors, noDeviations)); err != nil {
+		t.Fatalf("failed to write report: %s", err)
+	}
+
+	var result rdjsonResult
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("report isn't valid JSON: %s", err)
+	}
+
+	if len(result.Diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics but got %d", len(result.Diagnostics))
+	}
+
+	first := result.Diagnostics[0]
+	if first.Location.Path != "a.sh" || first.Location.Range.Start.Line != 2 || first.Code.Value != "wrong-author" {
+		t.Errorf("unexpected first diagnostic: %+v", first)
+	}
+
+	if len(first.Suggestions) != 0 {
+		t.Errorf("expected no suggestions for a file which can't be fixed, got %+v", first.Suggestions)
+	}
+
+	second := result.Diagnostics[1]
+	if len(second.Suggestions) != 1 || second.Suggestions[0].Text != "# header\n" || second.Suggestions[0].Range.End.Line != 1 {
+		t.Errorf("unexpected suggestions for second diagnostic: %+v", second.Suggestions)
+	}
+}
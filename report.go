@@ -0,0 +1,330 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputCSV  = "csv"
+)
+
+const (
+	// failureKindNonUTF8 is the kind of failure for files which aren't valid UTF-8
+	failureKindNonUTF8 = "non-utf8"
+
+	// failureKindNoTemplate is the kind of failure for files which no template matches, reported
+	// when --strict-unknown is set
+	failureKindNoTemplate = "no-template"
+
+	// failureKindIncompatibleLicense is the kind of failure for files which declare a license that's
+	// incompatible with the project's license, and so need reviewing rather than fixing
+	failureKindIncompatibleLicense = "incompatible-license"
+
+	// failureKindStaleYear is the kind of failure for changed files whose copyright year wasn't
+	// updated, reported when --require-current-year is set
+	failureKindStaleYear = "stale-year"
+)
+
+// noDeviations is the deviation threshold which disables classifying failures by how they deviate
+// from their template, since no header can be that similar to it
+var noDeviations = math.Inf(1)
+
+// fileError records that the file at path failed validation
+type fileError struct {
+	path string
+	err  error
+
+	// suggestion is the change which would fix the file, which is only worked out for output
+	// formats which can show it
+	suggestion *fixSuggestion
+
+	// copyright holds the copyright holders and years in the file, which are only worked out for
+	// output formats which show them
+	copyright *copyrightDetails
+}
+
+func (e *fileError) Error() string {
+	return fmt.Sprintf("invalid boilerplate in %q: %s", e.path, e.err)
+}
+
+func (e *fileError) Unwrap() error {
+	return e.err
+}
+
+// reportFailure is a single failure in a JSON report
+type reportFailure struct {
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+
+	// Kind categorizes the failure, such as "missing-boilerplate", "wrong-author", "wrong-year",
+	// "malformed-header", "too-short" or "misplaced" for headers which don't match their template,
+	// or "non-utf8" for files which aren't valid text
+	Kind string `json:"kind,omitempty"`
+
+	// Similarity, Line, Found and Expected are set when a header was compared against its
+	// template, so that bots can decide whether a failure is trivial enough to fix automatically
+	Similarity *float64 `json:"similarity,omitempty"`
+	Line       int      `json:"line,omitempty"`
+	Found      string   `json:"found,omitempty"`
+	Expected   string   `json:"expected,omitempty"`
+
+	// Deviation is set when --fuzzy is given and the header was similar enough to its template
+	// to classify how it differs
+	Deviation *reportDeviation `json:"deviation,omitempty"`
+
+	suggestion *fixSuggestion
+	copyright  *copyrightDetails
+}
+
+// reportDeviation describes how a header differs from its template in a JSON report
+type reportDeviation struct {
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+}
+
+// report is the JSON representation of the outcome of a run
+type report struct {
+	Checked int `json:"checked"`
+	Failed  int `json:"failed"`
+
+	// Sample and Seed are set if only a sample of the files was checked, so that the same sample
+	// can be checked again with --sample and --sample-seed
+	Sample string `json:"sample,omitempty"`
+	Seed   int64  `json:"seed,omitempty"`
+
+	Failures []reportFailure `json:"failures"`
+
+	// passed lists the files which passed, which is only recorded for output formats which
+	// show them
+	passed []string
+}
+
+func newReport(checked int, validationErrors []error, deviationThreshold float64) report {
+	r := report{
+		Checked:  checked,
+		Failed:   len(validationErrors),
+		Failures: make([]reportFailure, 0, len(validationErrors)),
+	}
+
+	for _, validationErr := range validationErrors {
+		r.Failures = append(r.Failures, newReportFailure(validationErr, deviationThreshold))
+	}
+
+	return r
+}
+
+// newReportFailure describes a single failure for a report
+func newReportFailure(validationErr error, deviationThreshold float64) reportFailure {
+	failure := reportFailure{
+		Message: validationErr.Error(),
+	}
+
+	var fileErr *fileError
+	if errors.As(validationErr, &fileErr) {
+		failure.Path = fileErr.path
+		failure.Message = fileErr.err.Error()
+		failure.suggestion = fileErr.suggestion
+		failure.copyright = fileErr.copyright
+	}
+
+	failure.Kind = failureKind(validationErr)
+
+	var mismatch *boilersuite.ValidationError
+	if errors.As(validationErr, &mismatch) && mismatch.Line > 0 {
+		similarity := mismatch.Similarity
+
+		failure.Similarity = &similarity
+		failure.Line = mismatch.Line
+		failure.Found = mismatch.Found
+		failure.Expected = mismatch.Expected
+	}
+
+	if deviation, ok := failureDeviation(validationErr, deviationThreshold); ok {
+		failure.Deviation = &reportDeviation{
+			Kind:        string(deviation.Kind),
+			Description: deviation.Description(),
+		}
+	}
+
+	return failure
+}
+
+// failureKind returns the kind of the given failure, or an empty string if it has no kind
+func failureKind(err error) string {
+	var incompatible *boilersuite.IncompatibleLicenseError
+
+	var mismatch *boilersuite.ValidationError
+
+	var restored *restoredError
+
+	switch {
+	case errors.As(err, &restored):
+		return restored.kind
+
+	case isEncodingError(err):
+		return failureKindNonUTF8
+
+	case errors.Is(err, errNoTemplate):
+		return failureKindNoTemplate
+
+	case errors.Is(err, errStaleYear):
+		return failureKindStaleYear
+
+	case errors.As(err, &incompatible):
+		return failureKindIncompatibleLicense
+
+	case errors.As(err, &mismatch):
+		return string(mismatch.Kind)
+
+	default:
+		return ""
+	}
+}
+
+// failureDeviation returns how the header of the given failure deviates from its template, if it
+// was compared against a template and was at least deviationThreshold similar to it
+func failureDeviation(err error, deviationThreshold float64) (boilersuite.Deviation, bool) {
+	var mismatch *boilersuite.ValidationError
+	if !errors.As(err, &mismatch) {
+		return boilersuite.Deviation{}, false
+	}
+
+	return mismatch.Deviation(deviationThreshold)
+}
+
+// isEncodingError returns true if err is because a file isn't valid text
+func isEncodingError(err error) bool {
+	var encodingErr *boilersuite.EncodingError
+
+	return errors.As(err, &encodingErr)
+}
+
+// summary returns a single line describing the outcome of the run, such as "checked 10 files, 2 failures"
+func (r report) summary() string {
+	return fmt.Sprintf("checked %s, %s", plural(r.Checked, "file"), plural(r.Failed, "failure"))
+}
+
+// coloredSummary returns the summary, colorized according to whether there were failures
+func (r report) coloredSummary(p palette) string {
+	if r.Failed > 0 {
+		return p.failure(r.summary())
+	}
+
+	return p.success(r.summary())
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// writeReport writes the report to w in the given machine-readable format
+func writeReport(w io.Writer, format string, r report) error {
+	switch format {
+	case outputRDJSON:
+		return writeRDJSONReport(w, r)
+
+	case outputTAP:
+		return writeTAPReport(w, r)
+
+	case outputCSV:
+		return writeCSVReport(w, r)
+
+	default:
+		return writeJSONReport(w, r)
+	}
+}
+
+func writeJSONReport(w io.Writer, r report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// printValidationErrors prints each error followed by its kind, along with a suggestion for any
+// header which was a near miss for its template. Headers which are at least deviationThreshold
+// similar to their template are described by how they deviate from it instead, followed by a count
+// of each kind of deviation.
+func printValidationErrors(w io.Writer, p palette, validationErrors []error, deviationThreshold float64) {
+	deviationCounts := map[boilersuite.DeviationKind]int{}
+
+	for _, validationErr := range validationErrors {
+		message := validationErr.Error()
+		if kind := failureKind(validationErr); kind != "" {
+			message += " [" + kind + "]"
+		}
+
+		fmt.Fprintln(w, p.failure(message))
+
+		var mismatch *boilersuite.ValidationError
+		if !errors.As(validationErr, &mismatch) {
+			continue
+		}
+
+		if deviation, ok := mismatch.Deviation(deviationThreshold); ok {
+			deviationCounts[deviation.Kind]++
+
+			fmt.Fprintln(w, p.warning(fmt.Sprintf("  %s (%.0f%% similar to the template): %s", deviation.Kind, mismatch.Similarity*100, deviation.Description())))
+		} else if mismatch.IsNearMiss() {
+			fmt.Fprintln(w, p.warning(fmt.Sprintf("  near miss (%.0f%% similar to the template): %s", mismatch.Similarity*100, mismatch.Suggestion())))
+		}
+	}
+
+	if len(deviationCounts) > 0 {
+		fmt.Fprintln(w, "deviations: "+deviationSummary(deviationCounts))
+	}
+}
+
+// deviationSummary returns the count of each kind of deviation, most common first, such as
+// "12 whitespace, 3 license-url"
+func deviationSummary(counts map[boilersuite.DeviationKind]int) string {
+	kinds := make([]boilersuite.DeviationKind, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+
+	sort.Slice(kinds, func(i, j int) bool {
+		if counts[kinds[i]] != counts[kinds[j]] {
+			return counts[kinds[i]] > counts[kinds[j]]
+		}
+
+		return kinds[i] < kinds[j]
+	})
+
+	parts := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// copyrightDetails records who a file's header is expected to credit, along with the holders and
+// years it actually names
+type copyrightDetails struct {
+	expectedHolder string
+	found          boilersuite.CopyrightInfo
+}
+
+func newCopyrightDetails(tmpl boilersuite.BoilerplateTemplate, text string) *copyrightDetails {
+	return &copyrightDetails{
+		expectedHolder: tmpl.ExpectedHolder(),
+		found:          boilersuite.FindCopyrightInfo(text),
+	}
+}
+
+// writeCSVReport writes a CSV row for each failure, with the copyright holder the file's header
+// should name alongside those it does name, joining multiple values in a column with "; "
+func writeCSVReport(w io.Writer, r report) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"path", "kind", "expected_author", "found_author", "year"}); err != nil {
+		return err
+	}
+
+	for _, failure := range r.Failures {
+		row := []string{failure.Path, failure.Kind, "", "", ""}
+
+		if details := failure.copyright; details != nil {
+			row[2] = details.expectedHolder
+			row[3] = strings.Join(details.found.Holders, "; ")
+			row[4] = strings.Join(details.found.Years, "; ")
+		}
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
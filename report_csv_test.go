@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_writeCSVReport(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	wrongAuthor := "# Copyright 2021 The other Authors.\n\necho hello\n"
+
+	validationErrors := []error{
+		&fileError{
+			path:      "a.sh",
+			err:       &boilersuite.ValidationError{Reason: "does not start with expected template type", Kind: boilersuite.ErrorKindWrongAuthor},
+			copyright: newCopyrightDetails(templates["sh"], wrongAuthor),
+		},
+		&fileError{
+			path: "b.sh",
+			err:  &boilersuite.ValidationError{Reason: "does not start with expected template type", Kind: boilersuite.ErrorKindMissingBoilerplate},
+		},
+		errors.New("something else went wrong"),
+	}
+
+	expected := `path,kind,expected_author,found_author,year
+a.sh,wrong-author,The cert-manager Authors,The other Authors,2021
+b.sh,missing-boilerplate,,,
+,,,,
+`
+
+	var buf bytes.Buffer
+
+	if err := writeReport(&buf, outputCSV, newReport(3, validationErrors, noDeviations)); err != nil {
+		t.Fatalf("failed to write report: %s", err)
+	}
+
+	if buf.String() != expected {
+		t.Errorf("got:\n%s\nwanted:\n%s", buf.String(), expected)
+	}
+}
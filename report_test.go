@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_writeJSONReport(t *testing.T) {
+	validationErrors := []error{
+		&fileError{
+			path: "a.go",
+			err: &boilersuite.ValidationError{
+				Reason:     "does not start with expected template type",
+				Kind:       boilersuite.ErrorKindWrongAuthor,
+				Similarity: 0.95,
+				Line:       2,
+				Found:      "cert manager",
+				Expected:   "cert-manager",
+			},
+		},
+		&fileError{
+			path: "b.go",
+			err:  &boilersuite.EncodingError{Encoding: boilersuite.EncodingUTF8, Line: 3},
+		},
+		errors.New("something else went wrong"),
+	}
+
+	expected := `{
+  "checked": 3,
+  "failed": 3,
+  "failures": [
+    {
+      "path": "a.go",
+      "message": "does not start with expected template type",
+      "kind": "wrong-author",
+      "similarity": 0.95,
+      "line": 2,
+      "found": "cert manager",
+      "expected": "cert-manager"
+    },
+    {
+      "path": "b.go",
+      "message": "file isn't valid UTF-8: invalid byte sequence on line 3",
+      "kind": "non-utf8"
+    },
+    {
+      "message": "something else went wrong"
+    }
+  ]
+}
+`
+
+	var buf bytes.Buffer
+
+	if err := writeJSONReport(&buf, newReport(3, validationErrors, noDeviations)); err != nil {
+		t.Fatalf("failed to write report: %s", err)
+	}
+
+	if buf.String() != expected {
+		t.Errorf("got:\n%s\nwanted:\n%s", buf.String(), expected)
+	}
+}
+
+func Test_writeJSONReportSample(t *testing.T) {
+	expected := `{
+  "checked": 2,
+  "failed": 0,
+  "sample": "5%",
+  "seed": 42,
+  "failures": []
+}
+`
+
+	results := newReport(2, nil, noDeviations)
+	results.Sample, results.Seed = "5%", 42
+
+	var buf bytes.Buffer
+
+	if err := writeJSONReport(&buf, results); err != nil {
+		t.Fatalf("failed to write report: %s", err)
+	}
+
+	if buf.String() != expected {
+		t.Errorf("got:\n%s\nwanted:\n%s", buf.String(), expected)
+	}
+}
+
+func Test_reportHidesYearMarker(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	tmpl := templates["sh"]
+
+	valid, err := tmpl.Fix("echo hello\n", boilersuite.FixOptions{Year: 2024})
+	if err != nil {
+		t.Fatalf("failed to add boilerplate: %s", err)
+	}
+
+	// the differing words on the copyright line include its year
+	contents := strings.Replace(valid, "# Copyright 2024 The cert-manager Authors.\n", "# Copyright 2024\n", 1)
+
+	validationErr := tmpl.Validate(contents)
+	if validationErr == nil {
+		t.Fatalf("expected %q to fail validation", contents)
+	}
+
+	validationErrors := []error{&fileError{path: "a.sh", err: validationErr, suggestion: suggestFix(tmpl, contents, 2026)}}
+
+	var buf bytes.Buffer
+
+	printValidationErrors(&buf, palette{}, validationErrors, boilersuite.NearMissSimilarity)
+
+	for _, format := range []string{outputJSON, outputRDJSON, outputTAP} {
+		if err := writeReport(&buf, format, newReport(1, validationErrors, boilersuite.NearMissSimilarity)); err != nil {
+			t.Fatalf("failed to write %s report: %s", format, err)
+		}
+	}
+
+	if !strings.Contains(buf.String(), `"expected": "YEAR The cert-manager Authors."`) {
+		t.Errorf("expected the JSON report to show the expected text, got:\n%s", buf.String())
+	}
+
+	if strings.Contains(buf.String(), "<<") {
+		t.Errorf("expected no template markers to be shown to users, got:\n%s", buf.String())
+	}
+}
+
+func Test_reportSummary(t *testing.T) {
+	tests := map[string]struct {
+		checked  int
+		failed   int
+		expected string
+	}{
+		"no failures": {
+			checked:  4312,
+			expected: "checked 4312 files, 0 failures",
+		},
+		"some failures": {
+			checked:  4312,
+			failed:   7,
+			expected: "checked 4312 files, 7 failures",
+		},
+		"singular": {
+			checked:  1,
+			failed:   1,
+			expected: "checked 1 file, 1 failure",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			validationErrors := make([]error, test.failed)
+			for i := range validationErrors {
+				validationErrors[i] = errors.New("invalid")
+			}
+
+			summary := newReport(test.checked, validationErrors, noDeviations).summary()
+			if summary != test.expected {
+				t.Errorf("got %q, wanted %q", summary, test.expected)
+			}
+		})
+	}
+}
+
+func Test_printValidationErrors(t *testing.T) {
+	validationErrors := []error{
+		&fileError{
+			path: "a.go",
+			err:  &boilersuite.ValidationError{Reason: "does not start with expected template type", Kind: boilersuite.ErrorKindMissingBoilerplate},
+		},
+		errors.New("something else went wrong"),
+	}
+
+	expected := `invalid boilerplate in "a.go": does not start with expected template type [missing-boilerplate]
+something else went wrong
+`
+
+	var buf bytes.Buffer
+
+	printValidationErrors(&buf, palette{}, validationErrors, noDeviations)
+
+	if buf.String() != expected {
+		t.Errorf("got:\n%s\nwanted:\n%s", buf.String(), expected)
+	}
+}
+
+func Test_printValidationErrorsDeviations(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	tmpl := templates["sh"]
+
+	valid, err := tmpl.Fix("echo hello\n", boilersuite.FixOptions{Year: 2024})
+	if err != nil {
+		t.Fatalf("failed to add boilerplate: %s", err)
+	}
+
+	// each replacement turns the valid header into a near miss
+	replacements := [][2]string{
+		{"Authors.\n", "Authors.  \n"},
+		{"Licensed under", "licensed under"},
+		{"Authors.\n", "Authors. \n"},
+	}
+
+	var validationErrors []error
+
+	for i, replacement := range replacements {
+		contents := strings.Replace(valid, replacement[0], replacement[1], 1)
+
+		validationErr := tmpl.Validate(contents)
+		if validationErr == nil {
+			t.Fatalf("expected %q to fail validation", replacement[1])
+		}
+
+		validationErrors = append(validationErrors, &fileError{path: fmt.Sprintf("%d.sh", i), err: validationErr})
+	}
+
+	var buf bytes.Buffer
+
+	printValidationErrors(&buf, palette{}, validationErrors, boilersuite.NearMissSimilarity)
+
+	if !strings.HasSuffix(buf.String(), "deviations: 2 whitespace, 1 case\n") {
+		t.Errorf("expected output to end with a count of each deviation, got:\n%s", buf.String())
+	}
+
+	results := newReport(len(replacements), validationErrors, boilersuite.NearMissSimilarity)
+
+	deviation := results.Failures[0].Deviation
+	if deviation == nil || deviation.Kind != string(boilersuite.DeviationWhitespace) {
+		t.Errorf("expected a whitespace deviation in the report, got %+v", deviation)
+	}
+
+	if newReport(len(replacements), validationErrors, noDeviations).Failures[0].Deviation != nil {
+		t.Errorf("expected no deviation in the report when --fuzzy isn't set")
+	}
+}
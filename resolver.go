@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// templateResolver chooses the template which a file should be validated against
+type templateResolver struct {
+	templates boilersuite.TemplateMap
+
+	// generatorSuffixes holds suffixes which identify templates used by generators, such as
+	// ".gotmpl". Such files are validated using the template of the file they generate.
+	generatorSuffixes []string
+
+	// rules controls how a template is chosen for a path. If empty, boilersuite.DefaultResolutionRules
+	// is used.
+	rules []boilersuite.ResolutionRule
+
+	// configs holds per-directory configuration. If nil, templates is used for every file.
+	configs *dirConfigs
+
+	// unknown collects files without a template which were found while selecting targets, if it's set
+	unknown *unknownFiles
+}
+
+// parseResolutionRules builds the rules used by a templateResolver from a space-separated list of
+// user-defined "<glob>=<template>" rules, which are always tried first, and a space-separated list of
+// strategies. If order is empty, the default strategies are used.
+func parseResolutionRules(userRules string, order string) ([]boilersuite.ResolutionRule, error) {
+	var rules []boilersuite.ResolutionRule
+
+	for _, raw := range strings.Fields(userRules) {
+		rule, err := boilersuite.ParseResolutionRule(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if rule.Strategy != boilersuite.ResolvePattern {
+			return nil, fmt.Errorf("template rule %q must be of the form <glob>=<template>", raw)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if order == "" {
+		return append(rules, boilersuite.DefaultResolutionRules...), nil
+	}
+
+	for _, raw := range strings.Fields(order) {
+		rule, err := boilersuite.ParseResolutionRule(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if rule.Strategy == boilersuite.ResolvePattern {
+			return nil, fmt.Errorf("resolution order entry %q must be a strategy; use --template-rules for patterns", raw)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// parseAlternatives parses a space-separated list of "<template>=<alternative>,<alternative>"
+// entries, e.g. "go=go-spdx,go-kubernetes sh=sh-spdx"
+func parseAlternatives(s string) (map[string][]string, error) {
+	alternatives := make(map[string][]string)
+
+	for _, entry := range strings.Fields(s) {
+		name, list, ok := strings.Cut(entry, "=")
+		if !ok || name == "" || list == "" {
+			return nil, fmt.Errorf("invalid alternatives %q; expected <template>=<alternative>,<alternative>", entry)
+		}
+
+		alternatives[name] = append(alternatives[name], strings.Split(list, ",")...)
+	}
+
+	return alternatives, nil
+}
+
+// parseExtAliases parses a comma-separated list of "<alias>=<template>" entries, e.g.
+// "bats=bash,zsh=sh". A leading dot on either side is ignored, so ".bats=.bash" works too.
+func parseExtAliases(s string) (map[string]string, error) {
+	aliases := make(map[string]string)
+
+	for _, entry := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		alias, target, ok := strings.Cut(entry, "=")
+
+		alias, target = strings.TrimPrefix(alias, "."), strings.TrimPrefix(target, ".")
+		if !ok || alias == "" || target == "" {
+			return nil, fmt.Errorf("invalid alias %q; expected <alias>=<template>", entry)
+		}
+
+		aliases[alias] = target
+	}
+
+	return aliases, nil
+}
+
+func (r templateResolver) resolutionRules() []boilersuite.ResolutionRule {
+	if len(r.rules) == 0 {
+		return boilersuite.DefaultResolutionRules
+	}
+
+	return r.rules
+}
+
+// templateFor returns the template which should be used for validating the file at path
+func (r templateResolver) templateFor(path string) (boilersuite.BoilerplateTemplate, bool) {
+	if output, ok := r.generatorOutput(path); ok {
+		path = output
+	}
+
+	templates, ok := r.templatesFor(path)
+	if !ok {
+		return boilersuite.BoilerplateTemplate{}, false
+	}
+
+	if rule, ok := r.configRule(path); ok {
+		if rule.Template == noTemplate {
+			return boilersuite.BoilerplateTemplate{}, false
+		}
+
+		tmpl, ok := templates[rule.Template]
+		if !ok {
+			r.configs.recordErr(fmt.Errorf("rule for %q in %q refers to unknown template %q", rule.Pattern, rule.source, rule.Template))
+		}
+
+		return tmpl, ok
+	}
+
+	return templates.TemplateForRules(path, r.resolutionRules())
+}
+
+// configRule returns the rule from per-directory configuration which chooses the template for the
+// file at path, if there is one
+func (r templateResolver) configRule(path string) (pathRule, bool) {
+	if r.configs == nil {
+		return pathRule{}, false
+	}
+
+	cfg, _, ok := r.configs.lookup(path)
+	if !ok {
+		return pathRule{}, false
+	}
+
+	return cfg.ruleFor(path)
+}
+
+// templatesFor returns the templates which apply to the file at path, taking per-directory
+// configuration into account. It returns false if the file is exempt from checks.
+func (r templateResolver) templatesFor(path string) (boilersuite.TemplateMap, bool) {
+	if r.configs == nil {
+		return r.templates, true
+	}
+
+	cfg, templates, ok := r.configs.lookup(path)
+	if !ok || cfg.exempt {
+		return nil, false
+	}
+
+	return templates, true
+}
+
+// exempt returns true if the file at path is in a directory which is configured to be exempt from
+// checks, or if a rule says it shouldn't have a template
+func (r templateResolver) exempt(path string) bool {
+	if r.configs == nil {
+		return false
+	}
+
+	cfg, _, ok := r.configs.lookup(path)
+	if !ok {
+		return false
+	}
+
+	if cfg.exempt {
+		return true
+	}
+
+	rule, ok := cfg.ruleFor(path)
+
+	return ok && rule.Template == noTemplate
+}
+
+// err returns the first error encountered while loading per-directory configuration
+func (r templateResolver) err() error {
+	if r.configs == nil {
+		return nil
+	}
+
+	return r.configs.err
+}
+
+// generatorOutput returns the path of the file which is generated from the given path, if the path
+// is a generator template. For example, "deploy/crds.yaml.gotmpl" generates "deploy/crds.yaml".
+func (r templateResolver) generatorOutput(path string) (string, bool) {
+	for _, suffix := range r.generatorSuffixes {
+		if strings.HasSuffix(path, suffix) && filepath.Base(path) != suffix {
+			return strings.TrimSuffix(path, suffix), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+const (
+	// reuseLicensesDir is the directory which holds the text of every license used in a project
+	// which follows the REUSE specification
+	reuseLicensesDir = "LICENSES"
+
+	// reuseCompanionSuffix is the suffix of files which hold the copyright and licensing
+	// information for a file that can't contain comments, such as an image
+	reuseCompanionSuffix = ".license"
+
+	// reuseSearchLimit is the most bytes at the start of each file which are searched for REUSE tags
+	reuseSearchLimit = 16 * 1024
+)
+
+// reuseIgnoredPrefixes are the start of the names of files which the REUSE specification doesn't
+// require to have copyright and licensing information
+var reuseIgnoredPrefixes = []string{"LICENSE", "LICENCE", "COPYING"}
+
+func runREUSE(args []string) {
+	flags := flag.NewFlagSet("reuse", flag.ExitOnError)
+	flags.Usage = commandUsage(flags, "reuse [flags] <path-to-dir>", "Checks that every file in a directory has copyright and licensing information following the REUSE specification (https://reuse.software/spec/), and prints a compliance report.")
+
+	global := addGlobalFlags(flags)
+	selection := addTargetFlags(flags)
+
+	_ = flags.Parse(args)
+
+	env := global.load(flags)
+	defer env.close()
+
+	selection.checkArgs(flags, env.logger, "boilersuite reuse")
+
+	// every file is covered by the specification, not just those with a template
+	env.resolver.unknown = &unknownFiles{}
+
+	set := selection.find(flags, env)
+	if !set.dir {
+		fatal(env.logger, "boilersuite reuse must be given a directory")
+	}
+
+	paths := set.unknown
+	for _, t := range set.targets {
+		paths = append(paths, t.path)
+	}
+
+	report, err := checkREUSE(set.base, paths)
+	if err != nil {
+		fatal(env.logger, "failed to check REUSE compliance", "err", err)
+	}
+
+	report.write(os.Stdout)
+
+	if !report.compliant() {
+		env.exit(1)
+	}
+}
+
+// reuseReport is the outcome of checking a directory against the REUSE specification
+type reuseReport struct {
+	checked int
+
+	// noLicensesDir is set if the directory has no LICENSES directory at all
+	noLicensesDir bool
+
+	// licenseFiles holds the identifiers of licenses which have a file in the LICENSES directory
+	licenseFiles map[string]bool
+
+	// usedLicenses maps the identifier of each license which is used to the files using it
+	usedLicenses map[string][]string
+
+	missingCopyright []string
+	missingLicense   []string
+
+	// uncommentable holds files which can't contain comments and have no ".license" file
+	uncommentable []string
+}
+
+// checkREUSE checks the given files, all of which must be in base, against the REUSE specification
+func checkREUSE(base string, paths []string) (reuseReport, error) {
+	report := reuseReport{
+		licenseFiles: make(map[string]bool),
+		usedLicenses: make(map[string][]string),
+	}
+
+	entries, err := os.ReadDir(filepath.Join(base, reuseLicensesDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		report.noLicensesDir = true
+	} else if err != nil {
+		return reuseReport{}, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			report.licenseFiles[strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))] = true
+		}
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if reuseIgnored(base, path) {
+			continue
+		}
+
+		info, ok, err := reuseInfoFor(path)
+		if err != nil {
+			return reuseReport{}, err
+		}
+
+		if !ok {
+			// empty files don't need any information
+			continue
+		}
+
+		report.checked++
+
+		if info == nil {
+			report.uncommentable = append(report.uncommentable, path)
+			continue
+		}
+
+		if len(info.Copyrights) == 0 {
+			report.missingCopyright = append(report.missingCopyright, path)
+		}
+
+		if len(info.Licenses) == 0 {
+			report.missingLicense = append(report.missingLicense, path)
+		}
+
+		for _, expression := range info.Licenses {
+			for _, id := range boilersuite.LicenseIdentifiers(expression) {
+				report.usedLicenses[id] = append(report.usedLicenses[id], path)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// reuseInfoFor returns the REUSE information for the file at path, taken from its ".license" file
+// if it has one. The information is nil if the file can't contain comments and has no ".license"
+// file, and false is returned for empty files.
+func reuseInfoFor(path string) (*boilersuite.REUSEInfo, bool, error) {
+	companion, err := target{path: path + reuseCompanionSuffix}.readText()
+	if err == nil {
+		info := boilersuite.FindREUSEInfo(companion)
+		return &info, true, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, false, err
+	}
+
+	head, _, err := target{path: path}.readHead(reuseSearchLimit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(head) == 0 {
+		return nil, false, nil
+	}
+
+	if boilersuite.IsBinary(head) {
+		return nil, true, nil
+	}
+
+	text, _, err := boilersuite.DecodeText(head)
+	if err != nil {
+		return nil, true, nil
+	}
+
+	info := boilersuite.FindREUSEInfo(text)
+
+	return &info, true, nil
+}
+
+// reuseIgnored reports whether the REUSE specification exempts the file at path from needing
+// copyright and licensing information
+func reuseIgnored(base string, path string) bool {
+	rel, err := filepath.Rel(base, path)
+	if err == nil && strings.HasPrefix(filepath.ToSlash(rel), reuseLicensesDir+"/") {
+		return true
+	}
+
+	name := filepath.Base(path)
+	if strings.HasSuffix(name, reuseCompanionSuffix) || strings.HasSuffix(name, ".spdx") {
+		return true
+	}
+
+	for _, prefix := range reuseIgnoredPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// missingLicenses returns the identifiers of licenses which are used but have no file in the
+// LICENSES directory
+func (r reuseReport) missingLicenses() []string {
+	var missing []string
+
+	for id := range r.usedLicenses {
+		if !r.licenseFiles[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	sort.Strings(missing)
+
+	return missing
+}
+
+// unusedLicenses returns the identifiers of licenses which have a file in the LICENSES directory
+// but aren't used by any file
+func (r reuseReport) unusedLicenses() []string {
+	var unused []string
+
+	for id := range r.licenseFiles {
+		if _, ok := r.usedLicenses[id]; !ok {
+			unused = append(unused, id)
+		}
+	}
+
+	sort.Strings(unused)
+
+	return unused
+}
+
+func (r reuseReport) compliant() bool {
+	return !r.noLicensesDir &&
+		len(r.missingLicenses()) == 0 &&
+		len(r.unusedLicenses()) == 0 &&
+		len(r.missingCopyright) == 0 &&
+		len(r.missingLicense) == 0 &&
+		len(r.uncommentable) == 0
+}
+
+// write writes the report in the same layout as the reference REUSE tool's "lint" command
+func (r reuseReport) write(w io.Writer) {
+	if r.noLicensesDir {
+		fmt.Fprintf(w, "# MISSING LICENSES DIRECTORY\n\nThere is no %s/ directory holding the text of each license.\n\n", reuseLicensesDir)
+	}
+
+	if missing := r.missingLicenses(); len(missing) > 0 {
+		fmt.Fprintf(w, "# MISSING LICENSES\n\n")
+
+		for _, id := range missing {
+			fmt.Fprintf(w, "'%s' found in:\n", id)
+			writeList(w, r.usedLicenses[id])
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	if unused := r.unusedLicenses(); len(unused) > 0 {
+		fmt.Fprintf(w, "# UNUSED LICENSES\n\nThe following licenses are not used:\n")
+		writeList(w, unused)
+		fmt.Fprintln(w)
+	}
+
+	if len(r.missingCopyright) > 0 || len(r.missingLicense) > 0 || len(r.uncommentable) > 0 {
+		fmt.Fprintf(w, "# MISSING COPYRIGHT AND LICENSING INFORMATION\n\n")
+
+		if len(r.missingCopyright) > 0 {
+			fmt.Fprintf(w, "The following files have no copyright information:\n")
+			writeList(w, r.missingCopyright)
+		}
+
+		if len(r.missingLicense) > 0 {
+			fmt.Fprintf(w, "The following files have no licensing information:\n")
+			writeList(w, r.missingLicense)
+		}
+
+		if len(r.uncommentable) > 0 {
+			fmt.Fprintf(w, "The following files can't contain comments, and need a %q file alongside them:\n", reuseCompanionSuffix)
+			writeList(w, r.uncommentable)
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	used := make([]string, 0, len(r.usedLicenses))
+	for id := range r.usedLicenses {
+		used = append(used, id)
+	}
+
+	sort.Strings(used)
+
+	withCopyright := r.checked - len(r.uncommentable) - len(r.missingCopyright)
+	withLicense := r.checked - len(r.uncommentable) - len(r.missingLicense)
+
+	fmt.Fprintf(w, "# SUMMARY\n\n")
+	fmt.Fprintf(w, "* Missing licenses: %s\n", strings.Join(r.missingLicenses(), ", "))
+	fmt.Fprintf(w, "* Unused licenses: %s\n", strings.Join(r.unusedLicenses(), ", "))
+	fmt.Fprintf(w, "* Used licenses: %s\n", strings.Join(used, ", "))
+	fmt.Fprintf(w, "* Files with copyright information: %d / %d\n", withCopyright, r.checked)
+	fmt.Fprintf(w, "* Files with license information: %d / %d\n", withLicense, r.checked)
+	fmt.Fprintln(w)
+
+	if r.compliant() {
+		fmt.Fprintf(w, "Congratulations! Your project is compliant with the REUSE Specification :-)\n")
+	} else {
+		fmt.Fprintf(w, "Unfortunately, your project is not compliant with the REUSE Specification :-(\n")
+	}
+}
+
+// writeList writes each item as a Markdown list entry
+func writeList(w io.Writer, items []string) {
+	for _, item := range items {
+		fmt.Fprintf(w, "* %s\n", item)
+	}
+}
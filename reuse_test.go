@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_checkREUSE(t *testing.T) {
+	const header = "// SPDX-FileCopyrightText: 2024 The cert-manager Authors\n// SPDX-License-Identifier: Apache-2.0\n\npackage main\n"
+
+	tests := map[string]struct {
+		files map[string]string
+
+		expectCompliant      bool
+		expectMissingLicense []string
+		expectUncommentable  []string
+		expectMissingIDs     []string
+		expectUnusedIDs      []string
+	}{
+		"compliant": {
+			files: map[string]string{
+				"LICENSES/Apache-2.0.txt": "Apache License\n",
+				"LICENSE":                 "Apache License\n",
+				"main.go":                 header,
+				"logo.png":                "\x89PNG\r\n\x1a\n\x00\x00",
+				"logo.png.license":        "SPDX-FileCopyrightText: 2024 The cert-manager Authors\nSPDX-License-Identifier: Apache-2.0\n",
+				"empty.txt":               "",
+			},
+			expectCompliant: true,
+		},
+		"file without a license": {
+			files: map[string]string{
+				"LICENSES/Apache-2.0.txt": "Apache License\n",
+				"main.go":                 header,
+				"util.go":                 "// Copyright 2024 The cert-manager Authors.\n\npackage main\n",
+			},
+			expectMissingLicense: []string{"util.go"},
+		},
+		"binary file without a companion file": {
+			files: map[string]string{
+				"LICENSES/Apache-2.0.txt": "Apache License\n",
+				"main.go":                 header,
+				"logo.png":                "\x89PNG\r\n\x1a\n\x00\x00",
+			},
+			expectUncommentable: []string{"logo.png"},
+		},
+		"missing and unused licenses": {
+			files: map[string]string{
+				"LICENSES/MIT.txt": "MIT License\n",
+				"main.go":          header,
+			},
+			expectMissingIDs: []string{"Apache-2.0"},
+			expectUnusedIDs:  []string{"MIT"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			var paths []string
+
+			for name, contents := range test.files {
+				path := filepath.Join(dir, name)
+
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+					t.Fatal(err)
+				}
+
+				paths = append(paths, path)
+			}
+
+			report, err := checkREUSE(dir, paths)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if report.compliant() != test.expectCompliant {
+				t.Errorf("compliant=%v, expected %v", report.compliant(), test.expectCompliant)
+			}
+
+			if got := relativeTo(t, dir, report.missingLicense); !reflect.DeepEqual(got, test.expectMissingLicense) {
+				t.Errorf("got files missing a license %q, wanted %q", got, test.expectMissingLicense)
+			}
+
+			if got := relativeTo(t, dir, report.uncommentable); !reflect.DeepEqual(got, test.expectUncommentable) {
+				t.Errorf("got uncommentable files %q, wanted %q", got, test.expectUncommentable)
+			}
+
+			if got := report.missingLicenses(); !reflect.DeepEqual(got, test.expectMissingIDs) {
+				t.Errorf("got missing licenses %q, wanted %q", got, test.expectMissingIDs)
+			}
+
+			if got := report.unusedLicenses(); !reflect.DeepEqual(got, test.expectUnusedIDs) {
+				t.Errorf("got unused licenses %q, wanted %q", got, test.expectUnusedIDs)
+			}
+		})
+	}
+}
+
+// relativeTo returns each of the given paths relative to dir
+func relativeTo(t *testing.T, dir string, paths []string) []string {
+	var out []string
+
+	for _, path := range paths {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out = append(out, filepath.ToSlash(rel))
+	}
+
+	return out
+}
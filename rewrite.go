@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// rewriteFunc returns the new text of a target and whether it changed, or an error if the target
+// can't be rewritten
+type rewriteFunc func(t target, text string) (string, bool, error)
+
+// rewriteOptions configures how rewriteTargets treats each target
+type rewriteOptions struct {
+	// stdin writes each target to stdout, whether or not it changed, rather than back to disk
+	stdin bool
+
+	// skipNonUTF8 skips files which aren't valid text with a warning, rather than reporting
+	// them as failures
+	skipNonUTF8 bool
+
+	// message is logged for each file which is rewritten, e.g. "fixed file"
+	message string
+}
+
+// rewriteTargets applies rewrite to the text of each target, writing back any which change in the
+// encoding they were read in. It returns the paths which were rewritten, along with an error for
+// each target which couldn't be. Binary files are skipped.
+func rewriteTargets(env *environment, targets []target, opts rewriteOptions, rewrite rewriteFunc) ([]string, []error) {
+	logger := env.logger
+
+	var rewritten []string
+
+	var failures []error
+
+	// with --stdin, a file which isn't rewritten is passed through unchanged
+	passThrough := func(contents []byte) {
+		if !opts.stdin {
+			return
+		}
+
+		if _, err := os.Stdout.Write(contents); err != nil {
+			fatal(logger, "failed to write to stdout", "err", err)
+		}
+	}
+
+	for _, t := range targets {
+		// stop between files so that a file is never left half-written
+		if env.ctx.Err() != nil {
+			break
+		}
+
+		contents, err := t.read()
+		if err != nil {
+			fatal(logger, "failed to read file", "path", t.path, "err", err)
+		}
+
+		if boilersuite.IsBinary(contents) {
+			logger.Debug("skipping binary file", "path", t.path)
+			passThrough(contents)
+
+			continue
+		}
+
+		text, encoding, err := boilersuite.DecodeText(contents)
+		if err != nil && opts.skipNonUTF8 {
+			logger.Warn("skipping file with invalid encoding", "path", t.path, "err", err)
+			passThrough(contents)
+
+			continue
+		} else if err != nil {
+			failures = append(failures, &fileError{path: t.path, err: err})
+			continue
+		}
+
+		updated, changed, err := rewrite(t, text)
+		if err != nil {
+			failures = append(failures, &fileError{path: t.path, err: err})
+			continue
+		}
+
+		// files are written back in the encoding they were read in
+		updatedContents := boilersuite.EncodeText(updated, encoding)
+
+		if opts.stdin {
+			passThrough(updatedContents)
+			continue
+		}
+
+		if !changed {
+			logger.Debug("file is unchanged", "path", t.path)
+			continue
+		}
+
+		// os.WriteFile keeps the permissions of existing files
+		if err := os.WriteFile(t.path, updatedContents, 0o644); err != nil {
+			fatal(logger, "failed to write file", "path", t.path, "err", err)
+		}
+
+		logger.Info(opts.message, "path", t.path)
+		rewritten = append(rewritten, t.path)
+	}
+
+	return rewritten, failures
+}
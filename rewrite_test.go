@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_rewriteTargets(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"changed.txt":   "hello\n",
+		"unchanged.txt": "goodbye\n",
+		"binary.txt":    "hello\x00\n",
+		"latin1.txt":    "hello \xe9\n",
+	}
+
+	var targets []target
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		targets = append(targets, target{path: path})
+	}
+
+	env := &environment{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ctx:    context.Background(),
+	}
+
+	upper := func(t target, text string) (string, bool, error) {
+		updated := strings.ReplaceAll(text, "hello", "HELLO")
+
+		return updated, updated != text, nil
+	}
+
+	tests := map[string]struct {
+		skipNonUTF8    bool
+		expectFailures int
+	}{
+		"non-UTF-8 files fail": {
+			skipNonUTF8:    false,
+			expectFailures: 1,
+		},
+		"non-UTF-8 files are skipped": {
+			skipNonUTF8:    true,
+			expectFailures: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := os.WriteFile(filepath.Join(dir, "changed.txt"), []byte(files["changed.txt"]), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			rewritten, failures := rewriteTargets(env, targets, rewriteOptions{skipNonUTF8: test.skipNonUTF8, message: "rewrote file"}, upper)
+
+			if expected := []string{filepath.Join(dir, "changed.txt")}; !reflect.DeepEqual(rewritten, expected) {
+				t.Errorf("rewritten=%v, expected %v", rewritten, expected)
+			}
+
+			if len(failures) != test.expectFailures {
+				t.Errorf("got %d failures, expected %d: %v", len(failures), test.expectFailures, failures)
+			}
+
+			for name, expected := range map[string]string{"changed.txt": "HELLO\n", "binary.txt": files["binary.txt"], "latin1.txt": files["latin1.txt"]} {
+				contents, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if string(contents) != expected {
+					t.Errorf("%s contains %q, expected %q", name, contents, expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseSamplePercentage parses a percentage such as "5%" or "12.5%" into a fraction between 0 and 1
+func parseSamplePercentage(raw string) (float64, error) {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(raw), "%")
+
+	percentage, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample percentage %q: %w", raw, err)
+	}
+
+	if percentage <= 0 || percentage > 100 {
+		return 0, fmt.Errorf("invalid sample percentage %q: must be greater than 0%% and at most 100%%", raw)
+	}
+
+	return percentage / 100, nil
+}
+
+// sampleTargets returns a random subset of the given targets, containing at least one target (if any were given)
+// and preserving their original order. The same seed and targets will always produce the same sample.
+func sampleTargets(targets []target, fraction float64, seed int64) []target {
+	if len(targets) == 0 {
+		return targets
+	}
+
+	count := int(math.Ceil(float64(len(targets)) * fraction))
+
+	indices := rand.New(rand.NewSource(seed)).Perm(len(targets))[:count]
+	sort.Ints(indices)
+
+	sampled := make([]target, 0, count)
+
+	for _, i := range indices {
+		sampled = append(sampled, targets[i])
+	}
+
+	return sampled
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_parseSamplePercentage(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		expected  float64
+		expectErr bool
+	}{
+		"percentage":         {input: "5%", expected: 0.05},
+		"fractional percent": {input: "12.5%", expected: 0.125},
+		"no percent sign":    {input: "50", expected: 0.5},
+		"everything":         {input: "100%", expected: 1},
+		"zero":               {input: "0%", expectErr: true},
+		"too large":          {input: "101%", expectErr: true},
+		"not a number":       {input: "some%", expectErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fraction, err := parseSamplePercentage(test.input)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if fraction != test.expected {
+				t.Errorf("fraction=%v, expected=%v", fraction, test.expected)
+			}
+		})
+	}
+}
+
+func Test_sampleTargets(t *testing.T) {
+	var targets []target
+
+	for i := 0; i < 100; i++ {
+		targets = append(targets, target{path: fmt.Sprintf("file%02d.go", i)})
+	}
+
+	first := sampleTargets(targets, 0.05, 42)
+	second := sampleTargets(targets, 0.05, 42)
+
+	if len(first) != 5 {
+		t.Errorf("expected 5 sampled targets but got %d", len(first))
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the same seed to produce the same sample but got %v and %v", first, second)
+	}
+
+	for i := 1; i < len(first); i++ {
+		if first[i-1].path >= first[i].path {
+			t.Errorf("expected sampled targets to retain their order but got %v", first)
+		}
+	}
+
+	if len(sampleTargets(targets[:3], 0.01, 42)) != 1 {
+		t.Errorf("expected at least one target to be sampled")
+	}
+}
+
+func Test_runCheckRecordsSample(t *testing.T) {
+	dir := t.TempDir()
+
+	for i := 0; i < 4; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("file%d.go", i))
+
+		if err := os.WriteFile(path, []byte(validGoHeader+"package example\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	historyPath := filepath.Join(t.TempDir(), "history.jsonl")
+
+	runCheck([]string{"--no-cache", "--sample", "50%", "--sample-seed", "42", "--history-file", historyPath, dir}, false)
+
+	contents, err := os.ReadFile(historyPath)
+	if err != nil {
+		t.Fatalf("failed to read history: %s", err)
+	}
+
+	var summary runSummary
+	if err := json.Unmarshal(contents, &summary); err != nil {
+		t.Fatalf("failed to parse history: %s", err)
+	}
+
+	if summary.Checked != 2 || summary.Sample != "50%" || summary.Seed != 42 {
+		t.Errorf("expected 2 files to be checked with sample 50%% and seed 42 but got %+v", summary)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var byteSizeSuffixes = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"G", 1 << 30},
+	{"M", 1 << 20},
+	{"K", 1 << 10},
+}
+
+// parseByteSize parses a size such as "512", "64K", "10M" or "1G", where suffixes are powers of 1024
+func parseByteSize(s string) (int64, error) {
+	trimmed := strings.TrimSuffix(strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(s)), "B"), "I")
+
+	multiplier := int64(1)
+
+	for _, suffix := range byteSizeSuffixes {
+		if strings.HasSuffix(trimmed, suffix.suffix) {
+			trimmed = strings.TrimSuffix(trimmed, suffix.suffix)
+			multiplier = suffix.multiplier
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(trimmed, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q; must be a number of bytes, optionally followed by K, M or G", s)
+	}
+
+	return n * multiplier, nil
+}
+
+// filterLargeFiles removes targets which are larger than maxSize bytes, warning about each one
+func filterLargeFiles(targets []target, maxSize int64, logger *slog.Logger) ([]target, error) {
+	var filtered []target
+
+	for _, t := range targets {
+		if t.contents != nil {
+			filtered = append(filtered, t)
+			continue
+		}
+
+		info, err := os.Stat(t.path)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Size() > maxSize {
+			logger.Warn("skipping file which is larger than --max-file-size", "path", t.path, "size", info.Size())
+			continue
+		}
+
+		filtered = append(filtered, t)
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseByteSize(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		expected  int64
+		expectErr bool
+	}{
+		"bytes":        {input: "512", expected: 512},
+		"kibibytes":    {input: "64K", expected: 64 * 1024},
+		"lower case":   {input: "10m", expected: 10 * 1024 * 1024},
+		"with B":       {input: "10MB", expected: 10 * 1024 * 1024},
+		"with iB":      {input: "1GiB", expected: 1024 * 1024 * 1024},
+		"zero":         {input: "0", expected: 0},
+		"negative":     {input: "-1", expectErr: true},
+		"not a number": {input: "big", expectErr: true},
+		"empty":        {input: "", expectErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			size, err := parseByteSize(test.input)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("err=%v, expectErr=%v", err, test.expectErr)
+			}
+
+			if size != test.expected {
+				t.Errorf("got %d, wanted %d", size, test.expected)
+			}
+		})
+	}
+}
+
+func Test_filterLargeFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	small := filepath.Join(dir, "small.go")
+	large := filepath.Join(dir, "large.go")
+
+	if err := os.WriteFile(small, []byte("package a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(large, []byte(strings.Repeat("a", 2048)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	targets := []target{
+		{path: small},
+		{path: large},
+		{path: "stdin.go", contents: []byte(strings.Repeat("a", 2048))},
+	}
+
+	filtered, err := filterLargeFiles(targets, 1024, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("failed to filter targets: %s", err)
+	}
+
+	var paths []string
+
+	for _, t := range filtered {
+		paths = append(paths, t.path)
+	}
+
+	expected := []string{small, "stdin.go"}
+
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("got %v, wanted %v", paths, expected)
+	}
+}
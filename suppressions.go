@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// listSuppressions writes a line for each target which has a skip_license_check marker, so that
+// suppressions can be audited. It returns the number of suppressions found.
+func listSuppressions(w io.Writer, targets []target) (int, error) {
+	found := 0
+
+	for _, t := range targets {
+		contents, err := t.readText()
+		if err != nil {
+			return found, fmt.Errorf("failed to read %q: %w", t.path, err)
+		}
+
+		suppression, ok, err := boilersuite.FindSuppression(contents)
+		if err != nil {
+			fmt.Fprintf(w, "%s: %s\n", t.path, err)
+			found++
+
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		found++
+
+		expiry := "never expires"
+		if !suppression.Until.IsZero() {
+			expiry = "until " + suppression.Until.Format(boilersuite.SkipExpiryLayout)
+
+			if suppression.Expired() {
+				expiry += " (expired)"
+			}
+		}
+
+		reason := suppression.Reason
+		if reason == "" {
+			reason = "no reason given"
+		}
+
+		fmt.Fprintf(w, "%s:%d: %s: %s\n", t.path, suppression.Line, expiry, reason)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// symlinkPolicy decides how symlinks found while walking a target directory are handled. By default,
+// symlinks to directories aren't walked and symlinks which point outside of the target directory are
+// skipped. If symlinks are followed, every directory is walked at most once so that loops terminate.
+type symlinkPolicy struct {
+	follow bool
+
+	// root is the target directory with every symlink resolved
+	root string
+
+	// visited holds the resolved paths of directories which have been walked
+	visited map[string]struct{}
+}
+
+func newSymlinkPolicy(targetBase string, follow bool) (*symlinkPolicy, error) {
+	absBase, err := filepath.Abs(targetBase)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := filepath.EvalSymlinks(absBase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &symlinkPolicy{
+		follow:  follow,
+		root:    root,
+		visited: make(map[string]struct{}),
+	}, nil
+}
+
+// resolve returns the path which the symlink at path points to, with every symlink resolved
+func (p *symlinkPolicy) resolve(path string) (string, os.FileInfo, error) {
+	linked, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	linked, err = filepath.Abs(linked)
+	if err != nil {
+		return "", nil, err
+	}
+
+	info, err := os.Stat(linked)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return linked, info, nil
+}
+
+// insideRoot returns true if the resolved path is the target directory or is inside it
+func (p *symlinkPolicy) insideRoot(resolved string) bool {
+	return resolved == p.root || strings.HasPrefix(resolved, p.root+string(filepath.Separator))
+}
+
+// visit records that the directory at the resolved path is being walked, returning false if it
+// has already been walked
+func (p *symlinkPolicy) visit(resolved string) bool {
+	if _, ok := p.visited[resolved]; ok {
+		return false
+	}
+
+	p.visited[resolved] = struct{}{}
+
+	return true
+}
+
+// displayPath maps a path found while walking root onto the same path under displayRoot, so that
+// files found through a symlink are reported using the symlink's path
+func displayPath(root string, displayRoot string, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(displayRoot, rel)
+}
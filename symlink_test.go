@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	boilerplatetemplates "github.com/cert-manager/boilersuite/boilerplate-templates"
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_getTargetsSymlinks(t *testing.T) {
+	templates, err := boilersuite.LoadTemplates(boilerplatetemplates.FS, defaultAuthor)
+	if err != nil {
+		t.Fatalf("failed to load templates: %s", err)
+	}
+
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	outside := filepath.Join(dir, "outside")
+
+	for _, d := range []string{filepath.Join(root, "sub"), outside} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, f := range []string{filepath.Join(root, "a.go"), filepath.Join(root, "sub", "b.go"), filepath.Join(outside, "c.go")} {
+		if err := os.WriteFile(f, []byte("package a\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	links := map[string]string{
+		"alias.go":   "a.go",
+		"loop":       ".",
+		"escape.go":  filepath.Join(outside, "c.go"),
+		"linked-dir": outside,
+		"broken.go":  "missing.go",
+	}
+
+	for name, linkTarget := range links {
+		if err := os.Symlink(linkTarget, filepath.Join(root, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]struct {
+		followSymlinks bool
+		expected       []string
+	}{
+		"symlinks escaping the root and symlinks to directories are skipped by default": {
+			followSymlinks: false,
+			expected:       []string{"a.go", "alias.go", "sub/b.go"},
+		},
+		"followed symlinks are walked once": {
+			followSymlinks: true,
+			expected:       []string{"a.go", "alias.go", "escape.go", "linked-dir/c.go", "sub/b.go"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			targets, err := getTargets(context.Background(), root, templateResolver{templates: templates}, nil, nil, test.followSymlinks, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err != nil {
+				t.Fatalf("failed to get targets: %s", err)
+			}
+
+			var paths []string
+
+			for _, target := range targets {
+				rel, err := filepath.Rel(root, target.path)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				paths = append(paths, filepath.ToSlash(rel))
+			}
+
+			sort.Strings(paths)
+
+			if !reflect.DeepEqual(paths, test.expected) {
+				t.Errorf("got %v, wanted %v", paths, test.expected)
+			}
+		})
+	}
+}
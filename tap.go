@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// outputTAP is the Test Anything Protocol, for use with prove-style test harnesses
+const outputTAP = "tap"
+
+// tapTest is a single test point in a TAP report; either a file which passed or a failure
+type tapTest struct {
+	description string
+	failure     *reportFailure
+}
+
+// writeTAPReport writes the report as a TAP version 13 stream, with a test point for each file
+// which passed and each failure. Failures include their message and kind as a YAML block.
+func writeTAPReport(w io.Writer, r report) error {
+	tests := make([]tapTest, 0, len(r.passed)+len(r.Failures))
+
+	for _, path := range r.passed {
+		tests = append(tests, tapTest{description: path})
+	}
+
+	for i := range r.Failures {
+		failure := &r.Failures[i]
+
+		description := failure.Path
+		if description == "" {
+			description = failure.Message
+		}
+
+		tests = append(tests, tapTest{description: description, failure: failure})
+	}
+
+	sort.SliceStable(tests, func(i, j int) bool {
+		return tests[i].description < tests[j].description
+	})
+
+	var b strings.Builder
+
+	b.WriteString("TAP version 13\n")
+	fmt.Fprintf(&b, "1..%d\n", len(tests))
+
+	for i, test := range tests {
+		if test.failure == nil {
+			fmt.Fprintf(&b, "ok %d - %s\n", i+1, tapEscaper.Replace(test.description))
+			continue
+		}
+
+		fmt.Fprintf(&b, "not ok %d - %s\n", i+1, tapEscaper.Replace(test.description))
+		b.WriteString("  ---\n")
+		fmt.Fprintf(&b, "  message: %s\n", strconv.Quote(test.failure.Message))
+
+		if test.failure.Kind != "" {
+			fmt.Fprintf(&b, "  kind: %s\n", test.failure.Kind)
+		}
+
+		if test.failure.Line > 0 {
+			fmt.Fprintf(&b, "  line: %d\n", test.failure.Line)
+		}
+
+		b.WriteString("  ...\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// tapEscaper escapes characters which have a meaning in a TAP test description
+var tapEscaper = strings.NewReplacer("\\", "\\\\", "#", "\\#", "\n", " ")
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+func Test_writeTAPReport(t *testing.T) {
+	validationErrors := []error{
+		&fileError{
+			path: "b.sh",
+			err: &boilersuite.ValidationError{
+				Reason: "does not start with expected template type",
+				Kind:   boilersuite.ErrorKindWrongAuthor,
+				Line:   1,
+			},
+		},
+		errors.New("license # missing"),
+	}
+
+	results := newReport(3, validationErrors, noDeviations)
+	results.passed = []string{"c.sh", "a.sh"}
+
+	expected := `TAP version 13
+1..4
+ok 1 - a.sh
+not ok 2 - b.sh
+  ---
+  message: "does not start with expected template type"
+  kind: wrong-author
+  line: 1
+  ...
+ok 3 - c.sh
+not ok 4 - license \# missing
+  ---
+  message: "license # missing"
+  ...
+`
+
+	var buf bytes.Buffer
+
+	if err := writeReport(&buf, outputTAP, results); err != nil {
+		t.Fatalf("failed to write report: %s", err)
+	}
+
+	if buf.String() != expected {
+		t.Errorf("got:\n%s\nwanted:\n%s", buf.String(), expected)
+	}
+}
@@ -35,7 +35,7 @@ func Test_Templates(t *testing.T) {
 	}
 
 	for _, entry := range dirEntries {
-		if entry.IsDir() {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".boilertmpl" {
 			continue
 		}
 
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/cert-manager/boilersuite/internal/boilersuite"
+)
+
+// errNoTemplate is reported by --strict-unknown for files which look like source but have no template
+var errNoTemplate = errors.New("no template matches this file, so its boilerplate can't be checked; add a template, or add the file to --unknown-allowlist")
+
+// defaultUnknownAllowlist holds patterns for files which never need boilerplate, and so aren't
+// reported by --strict-unknown even though they have no template
+var defaultUnknownAllowlist = []string{
+	"LICENSE*",
+	"COPYING*",
+	"NOTICE*",
+	"AUTHORS*",
+	"OWNERS*",
+	"CODEOWNERS",
+	".gitignore",
+	".gitmodules",
+	".dockerignore",
+	".boilersuite.json",
+	"*.json",
+	"*.lock",
+	"*.sum",
+	"*.txt",
+	"*.csv",
+	"*.svg",
+	"*.golden",
+	"*.boilertmpl",
+	"*.boilerfrag",
+}
+
+// excludeFromDefaultTemplate returns a copy of rules in which any default strategy also skips files
+// matching allowlist, whose patterns are relative to base if they contain a "/"
+func excludeFromDefaultTemplate(rules []boilersuite.ResolutionRule, base string, allowlist []string) []boilersuite.ResolutionRule {
+	var exclude []string
+
+	for _, pattern := range allowlist {
+		if strings.Contains(pattern, "/") {
+			pattern = filepath.ToSlash(filepath.Join(base, pattern))
+		}
+
+		exclude = append(exclude, pattern)
+	}
+
+	out := make([]boilersuite.ResolutionRule, len(rules))
+
+	for i, rule := range rules {
+		if rule.Strategy == boilersuite.ResolveDefault {
+			rule.Exclude = append(append([]string(nil), rule.Exclude...), exclude...)
+		}
+
+		out[i] = rule
+	}
+
+	return out
+}
+
+// unknownFiles collects files which were found while selecting targets but which have no template.
+// A nil *unknownFiles ignores them.
+type unknownFiles struct {
+	paths []string
+}
+
+func (u *unknownFiles) record(path string) {
+	if u == nil {
+		return
+	}
+
+	u.paths = append(u.paths, path)
+}
+
+// unknownFileErrors returns an error for each of the given files which has no template but looks
+// like it should, since it's a text file and doesn't match any pattern in allowlist or the default
+// allowlist. Patterns containing a "/" are matched against the path relative to base.
+func unknownFileErrors(base string, paths []string, allowlist []string) ([]error, error) {
+	patterns := append(append([]string(nil), defaultUnknownAllowlist...), allowlist...)
+
+	var errs []error
+
+	for _, path := range paths {
+		if allowlisted(base, path, patterns) {
+			continue
+		}
+
+		head, _, err := target{path: path}.readHead(8000)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(head) == 0 || boilersuite.IsBinary(head) {
+			continue
+		}
+
+		errs = append(errs, &fileError{path: path, err: errNoTemplate})
+	}
+
+	return errs, nil
+}
+
+// allowlisted reports whether the file at path matches any of the given patterns
+func allowlisted(base string, path string, patterns []string) bool {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		rel = path
+	}
+
+	for _, pattern := range patterns {
+		subject := filepath.Base(path)
+		if strings.Contains(pattern, "/") {
+			subject = filepath.ToSlash(rel)
+		}
+
+		if boilersuite.MatchGlob(pattern, subject) {
+			return true
+		}
+	}
+
+	return false
+}
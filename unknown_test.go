@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_unknownFileErrors(t *testing.T) {
+	tests := map[string]struct {
+		path      string
+		contents  string
+		allowlist []string
+		expectErr bool
+	}{
+		"unknown source file": {
+			path:      "main.nim",
+			contents:  "echo \"hello\"\n",
+			expectErr: true,
+		},
+		"file in the default allowlist": {
+			path:     "LICENSE",
+			contents: "Apache License\n",
+		},
+		"file matching a name pattern": {
+			path:      "main.nim",
+			contents:  "echo \"hello\"\n",
+			allowlist: []string{"*.nim"},
+		},
+		"file matching a path pattern": {
+			path:      "vendor/nim/main.nim",
+			contents:  "echo \"hello\"\n",
+			allowlist: []string{"vendor/**"},
+		},
+		"path pattern for another directory": {
+			path:      "src/main.nim",
+			contents:  "echo \"hello\"\n",
+			allowlist: []string{"vendor/**"},
+			expectErr: true,
+		},
+		"binary file": {
+			path:     "logo.png",
+			contents: "\x89PNG\r\n\x1a\n\x00\x00",
+		},
+		"empty file": {
+			path: "py.typed",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, test.path)
+
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(path, []byte(test.contents), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			errs, err := unknownFileErrors(dir, []string{path}, test.allowlist)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !test.expectErr {
+				if len(errs) > 0 {
+					t.Fatalf("expected no errors but got %v", errs)
+				}
+
+				return
+			}
+
+			if len(errs) != 1 {
+				t.Fatalf("expected exactly one error but got %v", errs)
+			}
+
+			if !errors.Is(errs[0], errNoTemplate) {
+				t.Errorf("expected errNoTemplate but got %v", errs[0])
+			}
+		})
+	}
+}